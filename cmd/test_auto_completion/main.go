@@ -105,7 +105,7 @@ func main() {
 	// Test 3: Apply auto-updates
 	fmt.Println("\n3. Testing automatic status updates...")
 	
-	updates, hasChanges := task.AutoUpdateTaskStatuses(project)
+	updates, hasChanges := task.AutoUpdateTaskStatuses(project, task.DefaultCompletionRules())
 	
 	if hasChanges {
 		fmt.Printf("✅ Found %d automatic updates:\n", len(updates))
@@ -127,7 +127,7 @@ func main() {
 	// Test 4: Check tasks needing attention
 	fmt.Println("\n4. Testing attention detection...")
 	
-	attention := task.GetTasksNeedingAttention(project)
+	attention := task.GetTasksNeedingAttention(project, task.DefaultCompletionRules())
 	
 	if len(attention) > 0 {
 		fmt.Printf("⚠️  Found %d tasks needing attention:\n", len(attention))
@@ -113,7 +113,7 @@ func main() {
 	// Test 4: Test auto-update functionality
 	fmt.Println("\n4. Testing auto-update functionality...")
 
-	updates, hasChanges := task.AutoUpdateTaskStatuses(project)
+	updates, hasChanges := task.AutoUpdateTaskStatuses(project, task.DefaultCompletionRules())
 	if hasChanges {
 		fmt.Printf("✅ Auto-updates applied:\n")
 		for _, update := range updates {
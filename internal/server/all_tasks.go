@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// allTasksCacheTTL is how long all_tasks reuses the tasks it loaded from every project
+const allTasksCacheTTL = 10 * time.Second
+
+// Pagination bounds for all_tasks
+const (
+	defaultAllTasksLimit = 50
+	maxAllTasksLimit     = 500
+)
+
+// projectTask is a task together with the project it belongs to
+type projectTask struct {
+	project string
+	task    task.Task
+}
+
+// crossProjectTask is one entry of the all_tasks result
+type crossProjectTask struct {
+	Project  string            `json:"project"`
+	ID       int               `json:"id"`
+	Title    string            `json:"title"`
+	Status   task.TaskStatus   `json:"status"`
+	Priority task.TaskPriority `json:"priority"`
+	Category task.TaskCategory `json:"category,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Progress float64           `json:"progress"`
+}
+
+// allTasksCache holds the tasks of every project for a short time, since
+// loading them all on each all_tasks call is expensive for large task directories.
+// Saves through the manager invalidate it immediately.
+type allTasksCache struct {
+	mutex    sync.Mutex
+	tasks    []projectTask
+	loadedAt time.Time
+}
+
+// invalidate drops the cached tasks; registered as a task.Manager save listener
+func (c *allTasksCache) invalidate(task.Project) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tasks = nil
+}
+
+// load returns the tasks of every project, from the cache when it is fresh
+func (c *allTasksCache) load(manager *task.Manager) ([]projectTask, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.tasks != nil && time.Since(c.loadedAt) < allTasksCacheTTL {
+		return c.tasks, nil
+	}
+
+	projectNames, err := manager.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []projectTask{}
+	for _, projectName := range projectNames {
+		project, err := manager.LoadProject(projectName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project %s: %w", projectName, err)
+		}
+		for _, t := range project.Tasks {
+			tasks = append(tasks, projectTask{project: projectName, task: t})
+		}
+	}
+
+	c.tasks = tasks
+	c.loadedAt = time.Now()
+	return tasks, nil
+}
+
+// handleAllTasks handles the all_tasks tool
+func (tms *TaskManagerServer) handleAllTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	filter, err := tms.parseTaskFilter(request)
+	if err != nil {
+		return tms.createErrorResult("all_tasks", err), nil
+	}
+
+	var tag string
+	if value := mcp.ParseString(request, "tag", ""); value != "" {
+		if tag, err = task.ValidateTag(value); err != nil {
+			return tms.createErrorResult("all_tasks", err), nil
+		}
+	}
+
+	offset := tms.parseNumberField(request, "offset", 0)
+	if offset < 0 {
+		return tms.createErrorResult("all_tasks", task.NewError(task.ErrInvalidInput, "offset cannot be negative, got %d", offset)), nil
+	}
+	limit := tms.parseNumberField(request, "limit", defaultAllTasksLimit)
+	if limit < 1 || limit > maxAllTasksLimit {
+		return tms.createErrorResult("all_tasks", task.NewError(task.ErrInvalidInput, "limit must be between 1 and %d, got %d", maxAllTasksLimit, limit)), nil
+	}
+
+	tasks, err := tms.allTasks.load(tms.taskManager)
+	if err != nil {
+		return tms.createErrorResult("all_tasks", err), nil
+	}
+
+	matches := []crossProjectTask{}
+	for _, entry := range tasks {
+		t := entry.task
+		if !filter.Matches(&t) || (tag != "" && !t.HasTag(tag)) {
+			continue
+		}
+		matches = append(matches, crossProjectTask{
+			Project:  entry.project,
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   t.Status,
+			Priority: t.Priority,
+			Category: t.Category,
+			Tags:     t.Tags,
+			Progress: taskProgress(&t, tms.config.DoneCriteriaWeight),
+		})
+	}
+
+	total := len(matches)
+	page := matches[min(offset, total):min(offset+limit, total)]
+
+	result := map[string]interface{}{
+		"total":    total,
+		"offset":   offset,
+		"limit":    limit,
+		"count":    len(page),
+		"has_more": offset+len(page) < total,
+		"tasks":    page,
+	}
+	if offset+len(page) < total {
+		result["next_offset"] = offset + len(page)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("all_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// maxBulkAddTasks bounds how many tasks one bulk_add_tasks call may add
+const maxBulkAddTasks = 100
+
+// bulkAddTaskSchema describes one entry of the bulk_add_tasks tasks array
+var bulkAddTaskSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"title":       map[string]any{"type": "string", "description": "Task title"},
+		"description": map[string]any{"type": "string", "description": "Task description"},
+		"subtasks": map[string]any{
+			"type":        "array",
+			"description": "Optional list of subtask titles",
+			"items":       map[string]any{"type": "string"},
+		},
+		"priority": map[string]any{"type": "string", "enum": []string{"P0", "P1", "P2", "P3"}},
+		"category": map[string]any{"type": "string", "enum": []string{"[MVP]", "[AI]", "[UX]", "[INFRA]", "[GENERAL]"}},
+	},
+	"required": []string{"title", "description"},
+}
+
+// handleBulkAddTasks handles the bulk_add_tasks tool. Every task is validated
+// before anything is written, and the project is loaded and saved once.
+func (tms *TaskManagerServer) handleBulkAddTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("bulk_add_tasks", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if err := tms.validateProjectName(projectName); err != nil {
+		return tms.createErrorResult("bulk_add_tasks", err), nil
+	}
+
+	rawTasks, ok := request.GetArguments()["tasks"].([]interface{})
+	if !ok {
+		return tms.createErrorResult("bulk_add_tasks", task.NewError(task.ErrInvalidInput, "field 'tasks' must be an array of task objects")), nil
+	}
+	if len(rawTasks) == 0 {
+		return tms.createErrorResult("bulk_add_tasks", task.NewError(task.ErrInvalidInput, "at least one task is required")), nil
+	}
+	if len(rawTasks) > maxBulkAddTasks {
+		return tms.createErrorResult("bulk_add_tasks", task.NewError(task.ErrInvalidInput, "too many tasks (max %d, got %d)", maxBulkAddTasks, len(rawTasks))), nil
+	}
+
+	newTasks := make([]task.Task, 0, len(rawTasks))
+	for i, raw := range rawTasks {
+		newTask, err := tms.parseBulkTask(raw)
+		if err != nil {
+			return tms.createErrorResult("bulk_add_tasks", fmt.Errorf("invalid task %d: %w", i+1, err)), nil
+		}
+		newTasks = append(newTasks, newTask)
+	}
+
+	if !tms.taskManager.ProjectExists(projectName) {
+		return tms.createErrorResult("bulk_add_tasks", task.NewError(task.ErrNotFound, "project '%s' does not exist. Use create_task_file to create it first", projectName)), nil
+	}
+
+	if err := tms.taskManager.AddTasks(projectName, newTasks); err != nil {
+		return tms.createErrorResult("bulk_add_tasks", err), nil
+	}
+
+	type addedTask struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	added := make([]addedTask, 0, len(newTasks))
+	taskIDs := make([]int, 0, len(newTasks))
+	for _, t := range newTasks {
+		added = append(added, addedTask{ID: t.ID, Title: t.Title})
+		taskIDs = append(taskIDs, t.ID)
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":  projectName,
+		"added":    len(added),
+		"task_ids": taskIDs,
+		"tasks":    added,
+		"message":  fmt.Sprintf("Added %d tasks to project '%s'", len(added), projectName),
+	})
+	if err != nil {
+		return tms.createErrorResult("bulk_add_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// parseBulkTask validates one entry of the bulk_add_tasks tasks array with the
+// same rules as add_task
+func (tms *TaskManagerServer) parseBulkTask(raw interface{}) (task.Task, error) {
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return task.Task{}, task.NewError(task.ErrInvalidInput, "must be an object")
+	}
+
+	title, _ := fields["title"].(string)
+	title, err := tms.validateTaskTitle(title)
+	if err != nil {
+		return task.Task{}, err
+	}
+
+	description, _ := fields["description"].(string)
+	if err := tms.validateTaskDescription(description); err != nil {
+		return task.Task{}, err
+	}
+
+	newTask := task.Task{
+		Title:       title,
+		Description: description,
+		Status:      task.DefaultTaskStatus(),
+		Priority:    task.DefaultTaskPriority(),
+	}
+
+	if priority, ok := fields["priority"].(string); ok && priority != "" {
+		if newTask.Priority, err = task.ValidateTaskPriority(priority); err != nil {
+			return task.Task{}, err
+		}
+	}
+
+	if category, ok := fields["category"].(string); ok && category != "" {
+		if newTask.Category, err = task.ValidateTaskCategory(category); err != nil {
+			return task.Task{}, err
+		}
+	}
+
+	if rawSubtasks := fields["subtasks"]; rawSubtasks != nil {
+		subtasks, ok := rawSubtasks.([]interface{})
+		if !ok {
+			return task.Task{}, task.NewError(task.ErrInvalidInput, "field 'subtasks' must be an array")
+		}
+		if len(subtasks) > maxTaskSubtasks {
+			return task.Task{}, task.NewError(task.ErrInvalidInput, "too many subtasks (max %d, got %d)", maxTaskSubtasks, len(subtasks))
+		}
+
+		for i, rawSubtask := range subtasks {
+			subtaskTitle, ok := rawSubtask.(string)
+			if !ok {
+				return task.Task{}, task.NewError(task.ErrInvalidInput, "subtask at index %d must be a string", i)
+			}
+			subtaskTitle, err := task.ValidateTaskTitle(subtaskTitle)
+			if err != nil {
+				return task.Task{}, fmt.Errorf("invalid subtask %d: %w", i+1, err)
+			}
+
+			newTask.Subtasks = append(newTask.Subtasks, task.Subtask{
+				Title:     subtaskTitle,
+				Status:    task.DefaultTaskStatus(),
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			})
+		}
+	}
+
+	return newTask, nil
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// maxBulkStatusUpdates bounds how many entries one update_task_statuses call may apply
+const maxBulkStatusUpdates = 100
+
+// Outcomes of one update_task_statuses entry
+const (
+	statusUpdateUpdated  = "updated"
+	statusUpdateNotFound = "not_found"
+	statusUpdateConflict = "conflict"
+	statusUpdateInvalid  = "invalid"
+)
+
+// statusUpdateSchema describes one entry of the update_task_statuses updates array
+var statusUpdateSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"task_title":    map[string]any{"type": "string", "description": "Title of the task"},
+		"subtask_title": map[string]any{"type": "string", "description": "Optional title of the subtask"},
+		"status":        map[string]any{"type": "string", "enum": []string{"todo", "in_progress", "done", "blocked"}},
+	},
+	"required": []string{"task_title", "status"},
+}
+
+// statusUpdateResult reports what happened to one update_task_statuses entry
+type statusUpdateResult struct {
+	TaskTitle    string          `json:"task_title"`
+	SubtaskTitle string          `json:"subtask_title,omitempty"`
+	Status       task.TaskStatus `json:"status,omitempty"`
+	Result       string          `json:"result"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// handleUpdateTaskStatuses handles the update_task_statuses tool. Entries are
+// applied in order to one loaded project, which is saved once. An entry that
+// fails is reported in its result and doesn't stop the others.
+func (tms *TaskManagerServer) handleUpdateTaskStatuses(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("update_task_statuses", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	updates, ok := request.GetArguments()["updates"].([]interface{})
+	if !ok {
+		return tms.createErrorResult("update_task_statuses", task.NewError(task.ErrInvalidInput, "field 'updates' must be an array of status updates")), nil
+	}
+	if len(updates) == 0 {
+		return tms.createErrorResult("update_task_statuses", task.NewError(task.ErrInvalidInput, "at least one update is required")), nil
+	}
+	if len(updates) > maxBulkStatusUpdates {
+		return tms.createErrorResult("update_task_statuses", task.NewError(task.ErrInvalidInput, "too many updates (max %d, got %d)", maxBulkStatusUpdates, len(updates))), nil
+	}
+
+	partialMatch := tms.parseBooleanField(request, "partial_match", false)
+	force := tms.parseBooleanField(request, "force", false)
+
+	var results []statusUpdateResult
+	var additionalUpdates []string
+	updated := 0
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		results = make([]statusUpdateResult, 0, len(updates))
+		additionalUpdates = []string{}
+		updated = 0
+
+		for _, raw := range updates {
+			result, sideEffects := tms.applyStatusUpdate(project, raw, partialMatch, force)
+			if result.Result == statusUpdateUpdated {
+				updated++
+				additionalUpdates = append(additionalUpdates, sideEffects...)
+			}
+			results = append(results, result)
+		}
+
+		if updated == 0 {
+			return task.SkipSave
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("update_task_statuses", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":            projectName,
+		"updated":            updated,
+		"failed":             len(results) - updated,
+		"results":            results,
+		"additional_updates": additionalUpdates,
+	})
+	if err != nil {
+		return tms.createErrorResult("update_task_statuses", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// applyStatusUpdate applies one update_task_statuses entry to the project with
+// the same rules as update_task_status, returning its result and any
+// auto-completion side effects
+func (tms *TaskManagerServer) applyStatusUpdate(project *task.Project, raw interface{}, partialMatch bool, force bool) (statusUpdateResult, []string) {
+	fields, _ := raw.(map[string]interface{})
+	taskTitle, _ := fields["task_title"].(string)
+	subtaskTitle, _ := fields["subtask_title"].(string)
+	statusStr, _ := fields["status"].(string)
+
+	result := statusUpdateResult{TaskTitle: taskTitle, SubtaskTitle: subtaskTitle}
+	fail := func(err error) (statusUpdateResult, []string) {
+		switch {
+		case errors.Is(err, task.ErrNotFound):
+			result.Result = statusUpdateNotFound
+		case errors.Is(err, task.ErrConflict):
+			result.Result = statusUpdateConflict
+		default:
+			result.Result = statusUpdateInvalid
+		}
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	if fields == nil {
+		return fail(task.NewError(task.ErrInvalidInput, "update must be an object"))
+	}
+
+	status, err := task.ValidateTaskStatus(statusStr)
+	if err != nil {
+		return fail(err)
+	}
+	result.Status = status
+
+	taskTitle, err = tms.validateTaskTitle(taskTitle)
+	if err != nil {
+		return fail(err)
+	}
+	if subtaskTitle != "" {
+		if subtaskTitle, err = tms.validateTaskTitle(subtaskTitle); err != nil {
+			return fail(fmt.Errorf("invalid subtask title: %w", err))
+		}
+		result.SubtaskTitle = subtaskTitle
+	}
+
+	targetTask, _, err := tms.resolveTaskTitle(project, taskTitle, partialMatch)
+	if err != nil {
+		return fail(err)
+	}
+	result.TaskTitle = targetTask.Title
+
+	sideEffects, err := targetTask.ApplyStatus(subtaskTitle, status, tms.requireSubtasksDone(force))
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Result = statusUpdateUpdated
+	return result, sideEffects
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// categoryProgress is one category's entry in the get_category_progress result
+type categoryProgress struct {
+	Category  task.TaskCategory `json:"category"`
+	Total     int               `json:"total"`
+	Completed int               `json:"completed"`
+	Progress  float64           `json:"progress"`
+}
+
+// handleGetCategoryProgress handles the get_category_progress tool
+func (tms *TaskManagerServer) handleGetCategoryProgress(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("get_category_progress", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("get_category_progress", err), nil
+	}
+
+	// Categories without tasks are left out, in the order markdown files list them
+	breakdown := project.GetCategoryBreakdown()
+	categories := []categoryProgress{}
+	for _, category := range task.AllCategories() {
+		counts, exists := breakdown[category]
+		if !exists {
+			continue
+		}
+		categories = append(categories, categoryProgress{
+			Category:  category,
+			Total:     counts.Total,
+			Completed: counts.Completed,
+			Progress:  float64(counts.Completed) / float64(counts.Total) * 100,
+		})
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":    projectName,
+		"categories": categories,
+	})
+	if err != nil {
+		return tms.createErrorResult("get_category_progress", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
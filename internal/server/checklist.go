@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleExportChecklist handles the export_checklist tool
+func (tms *TaskManagerServer) handleExportChecklist(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("export_checklist", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("export_checklist", err), nil
+	}
+
+	if len(project.Tasks) == 0 {
+		return tms.createSuccessResult("No tasks found in project. Use add_task to create tasks."), nil
+	}
+
+	// Plain markdown rather than JSON so it can be pasted directly
+	return tms.createSuccessResult(task.GenerateChecklist(*project)), nil
+}
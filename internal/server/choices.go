@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleCreateChoice handles the create_choice tool
+func (tms *TaskManagerServer) handleCreateChoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("create_choice", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("create_choice", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	question, err := request.RequireString("question")
+	if err != nil {
+		return tms.createErrorResult("create_choice", task.NewError(task.ErrInvalidInput, "missing question: %w", err)), nil
+	}
+
+	options, err := tms.parseStringArray(request, "options")
+	if err != nil {
+		return tms.createErrorResult("create_choice", err), nil
+	}
+
+	choice := task.Choice{
+		ID:        task.GenerateChoiceID(),
+		Question:  strings.TrimSpace(question),
+		Options:   options,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := task.ValidateChoice(choice); err != nil {
+		return tms.createErrorResult("create_choice", err), nil
+	}
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		targetTask.Choices = append(targetTask.Choices, choice)
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("create_choice", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project": projectName,
+		"task":    targetTask.Title,
+		"choice":  choice,
+		"message": fmt.Sprintf("Added choice '%s' to task '%s'; resolve it with resolve_choice and choice_id %s", choice.Question, targetTask.Title, choice.ID),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("create_choice", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleResolveChoice handles the resolve_choice tool
+func (tms *TaskManagerServer) handleResolveChoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	choiceID, err := request.RequireString("choice_id")
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", task.NewError(task.ErrInvalidInput, "missing choice_id: %w", err)), nil
+	}
+
+	selected, err := request.RequireString("selected_option")
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", task.NewError(task.ErrInvalidInput, "missing selected_option: %w", err)), nil
+	}
+
+	reasoning := strings.Join(strings.Fields(mcp.ParseString(request, "reasoning", "")), " ")
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	var choice *task.Choice
+	var previous string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		choice = targetTask.FindChoice(strings.TrimSpace(choiceID))
+		if choice == nil {
+			return task.NewError(task.ErrNotFound, "choice '%s' not found in task '%s'", choiceID, targetTask.Title)
+		}
+
+		// Validate the resolved choice before changing the task
+		resolved := *choice
+		resolved.Selected = strings.TrimSpace(selected)
+		if err := task.ValidateChoice(resolved); err != nil {
+			return err
+		}
+
+		previous = choice.Selected
+		now := time.Now().UTC()
+		choice.Selected = resolved.Selected
+		if reasoning != "" {
+			choice.Reasoning = reasoning
+		}
+		choice.ResolvedAt = &now
+		targetTask.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":         projectName,
+		"task":            targetTask.Title,
+		"choice":          *choice,
+		"previous":        previous,
+		"pending_choices": targetTask.ToSummary().PendingChoices,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("resolve_choice", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
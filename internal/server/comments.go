@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleAddTaskComment handles the add_task_comment tool
+func (tms *TaskManagerServer) handleAddTaskComment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	text, err := request.RequireString("text")
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", task.NewError(task.ErrInvalidInput, "missing text: %w", err)), nil
+	}
+
+	author, text, err := task.ValidateComment(mcp.ParseString(request, "author", ""), text)
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", err), nil
+	}
+
+	// Comments are written with second precision
+	now := time.Now().UTC().Truncate(time.Second)
+	comment := task.Comment{
+		Author:    author,
+		Text:      text,
+		CreatedAt: now,
+	}
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		targetTask.Comments = append(targetTask.Comments, comment)
+		targetTask.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":       projectName,
+		"task":          targetTask.Title,
+		"comment":       comment,
+		"comment_count": len(targetTask.Comments),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("add_task_comment", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// defaultBreakdownMinSubtasks is the subtask count below which a high
+// complexity task is suggested for breakdown
+const defaultBreakdownMinSubtasks = 2
+
+// defaultSubtaskComplexityGate is the lowest complexity for which
+// estimate_task_complexity auto-creates suggested subtasks
+const defaultSubtaskComplexityGate = task.ComplexityMedium
+
+// subtaskComplexityGate returns the complexity gate for auto-created subtasks:
+// the min_complexity_for_subtasks parameter if given, otherwise the configured gate
+func (tms *TaskManagerServer) subtaskComplexityGate(request mcp.CallToolRequest) (task.TaskComplexity, error) {
+	if gate := mcp.ParseString(request, "min_complexity_for_subtasks", ""); gate != "" {
+		return task.ValidateTaskComplexity(gate)
+	}
+	if gate, err := task.ValidateTaskComplexity(tms.config.SubtaskComplexityGate); err == nil {
+		return gate, nil
+	}
+	return defaultSubtaskComplexityGate, nil
+}
+
+// breakdownCandidate is a high complexity task with too few subtasks
+type breakdownCandidate struct {
+	TaskID       int                 `json:"task_id"`
+	Title        string              `json:"title"`
+	Status       task.TaskStatus     `json:"status"`
+	Complexity   task.TaskComplexity `json:"complexity"`
+	SubtaskCount int                 `json:"subtask_count"`
+}
+
+// handleComplexityBreakdown handles the complexity_breakdown tool
+func (tms *TaskManagerServer) handleComplexityBreakdown(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("complexity_breakdown", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	minSubtasks := tms.parseNumberField(request, "min_subtasks", defaultBreakdownMinSubtasks)
+	if minSubtasks < 1 {
+		return tms.createErrorResult("complexity_breakdown", task.NewError(task.ErrInvalidInput, "min_subtasks must be at least 1")), nil
+	}
+	includeCompleted := tms.parseBooleanField(request, "include_completed", false)
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("complexity_breakdown", err), nil
+	}
+
+	distribution, candidates := complexityBreakdown(project, minSubtasks, includeCompleted)
+
+	result := map[string]interface{}{
+		"project":              projectName,
+		"distribution":         distribution,
+		"min_subtasks":         minSubtasks,
+		"breakdown_candidates": candidates,
+		"candidate_count":      len(candidates),
+	}
+	if len(candidates) > 0 {
+		result["next_step"] = "Use expand_task to break down the candidates into subtasks"
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("complexity_breakdown", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// complexityBreakdown counts tasks per complexity level ("unset" when none is
+// recorded) and lists high complexity tasks with fewer than minSubtasks
+// subtasks. Completed tasks are only candidates when includeCompleted is set.
+func complexityBreakdown(project *task.Project, minSubtasks int, includeCompleted bool) (map[string]int, []breakdownCandidate) {
+	distribution := map[string]int{
+		string(task.ComplexityLow):    0,
+		string(task.ComplexityMedium): 0,
+		string(task.ComplexityHigh):   0,
+		"unset":                       0,
+	}
+	candidates := []breakdownCandidate{}
+
+	for _, t := range project.Tasks {
+		if t.Complexity == "" {
+			distribution["unset"]++
+		} else {
+			distribution[string(t.Complexity)]++
+		}
+
+		if t.Complexity != task.ComplexityHigh || len(t.Subtasks) >= minSubtasks {
+			continue
+		}
+		if t.IsCompleted() && !includeCompleted {
+			continue
+		}
+		candidates = append(candidates, breakdownCandidate{
+			TaskID:       t.ID,
+			Title:        t.Title,
+			Status:       t.Status,
+			Complexity:   t.Complexity,
+			SubtaskCount: len(t.Subtasks),
+		})
+	}
+
+	return distribution, candidates
+}
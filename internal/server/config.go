@@ -2,11 +2,16 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
+
+	"mcp-task-manager-go/internal/task"
 )
 
 // ServerConfig holds configuration for the task manager server
@@ -14,29 +19,142 @@ type ServerConfig struct {
 	AutoEvaluation AutoEvaluationConfig `json:"auto_evaluation"`
 	TasksDir       string               `json:"tasks_dir"`
 	LogLevel       string               `json:"log_level"`
+
+	// StrictFileTypes makes generate_task_file require an explicit file_type
+	StrictFileTypes bool `json:"strict_file_types"`
+
+	// ContextPrimerMaxBytes bounds the size of the context_primer summary
+	ContextPrimerMaxBytes int `json:"context_primer_max_bytes"`
+
+	// PriorityWeights overrides the per-priority weights used for weighted progress
+	PriorityWeights map[string]float64 `json:"priority_weights,omitempty"`
+
+	// MaxFileSize is the largest project file, in bytes, the server will load
+	MaxFileSize int64 `json:"max_file_size"`
+
+	// AutoCreateProjects makes read tools create a missing project instead of erroring
+	AutoCreateProjects bool `json:"auto_create_projects"`
+
+	// MaxWatchSubscribers bounds concurrent watch_project subscriptions
+	MaxWatchSubscribers int `json:"max_watch_subscribers"`
+
+	// Transport, Host and Port select how the server is served (stdio, sse or streamable-http)
+	Transport string `json:"transport"`
+	Host      string `json:"host"`
+	Port      string `json:"port"`
+
+	// ReadOnly exposes only tools that never write, e.g. for a shared view over SSE
+	ReadOnly bool `json:"read_only"`
+
+	// Namespace prefixes project file names so several servers can share a tasks directory
+	Namespace string `json:"namespace,omitempty"`
+
+	// CompletionMessage is shown when every task in a project is done
+	CompletionMessage string `json:"completion_message"`
+
+	// ExcludeEmptyProjects leaves projects without tasks out of list_projects and
+	// project auto-detection
+	ExcludeEmptyProjects bool `json:"exclude_empty_projects"`
+
+	// FallbackTasksDir is used when no tasks directory is configured and project
+	// root detection fails (default ~/.mcp-task-manager/tasks)
+	FallbackTasksDir string `json:"fallback_tasks_dir,omitempty"`
+
+	// DisableFallback makes the server fail to start instead of writing tasks
+	// to a fallback directory
+	DisableFallback bool `json:"disable_fallback"`
+
+	// HeadingLevel is the markdown heading level of tasks in project files (2-5)
+	HeadingLevel int `json:"heading_level,omitempty"`
+
+	// MarkdownLabels overrides the headings and field markers used in project
+	// files (e.g. {"subtasks": "Teilaufgaben"}) so they can be written in another language
+	MarkdownLabels map[string]string `json:"markdown_labels,omitempty"`
+
+	// SubtaskComplexityGate is the lowest complexity for which estimate_task_complexity
+	// auto-creates suggested subtasks (low, medium or high)
+	SubtaskComplexityGate string `json:"subtask_complexity_gate"`
+
+	// SuggestionWeights overrides the scoring weights used by suggest_next_actions
+	SuggestionWeights map[string]int `json:"suggestion_weights,omitempty"`
+
+	// IncompleteSubtasksMode decides what marking a task done does to its open
+	// subtasks: "complete" completes them, "confirm" refuses unless force is set
+	IncompleteSubtasksMode string `json:"incomplete_subtasks_mode"`
+
+	// DoneCriteriaWeight is the share (0-1) of a task's completion given to its
+	// done criteria when it has both subtasks and criteria
+	DoneCriteriaWeight float64 `json:"done_criteria_weight"`
+
+	// LockTimeout is how long a save waits for another server process to
+	// release a project file before failing
+	LockTimeout time.Duration `json:"lock_timeout"`
+
+	// HistoryLimit is how many previous versions of each project are kept for
+	// undo_last_change and restore_version; negative disables history
+	HistoryLimit int `json:"history_limit"`
 }
 
+// IncompleteSubtasksMode values
+const (
+	incompleteSubtasksComplete = "complete"
+	incompleteSubtasksConfirm  = "confirm"
+)
+
+// defaultCompletionMessage is the default CompletionMessage
+const defaultCompletionMessage = "🎉 All tasks are completed!"
+
+// Defaults for serving the MCP server
+const (
+	defaultTransport = "stdio"
+	defaultHost      = "0.0.0.0"
+	defaultPort      = "8050"
+)
+
 // LoadServerConfig loads configuration from environment variables and config file
 func LoadServerConfig() (ServerConfig, error) {
 	config := ServerConfig{
-		AutoEvaluation: DefaultAutoEvaluationConfig(),
-		LogLevel:       "info",
+		AutoEvaluation:         DefaultAutoEvaluationConfig(),
+		LogLevel:               "info",
+		ContextPrimerMaxBytes:  defaultContextPrimerMaxBytes,
+		MaxFileSize:            task.DefaultMaxFileSize,
+		MaxWatchSubscribers:    defaultMaxWatchSubscribers,
+		Transport:              defaultTransport,
+		Host:                   defaultHost,
+		Port:                   defaultPort,
+		CompletionMessage:      defaultCompletionMessage,
+		SubtaskComplexityGate:  string(defaultSubtaskComplexityGate),
+		IncompleteSubtasksMode: incompleteSubtasksComplete,
+		DoneCriteriaWeight:     task.DefaultCriteriaWeight,
+		LockTimeout:            task.DefaultLockTimeout,
+		HistoryLimit:           task.DefaultHistoryLimit,
 	}
 
 	// Load from environment variables
-	config.loadFromEnv()
+	if err := config.loadFromEnv(); err != nil {
+		return config, fmt.Errorf("invalid environment configuration: %w", err)
+	}
 
 	// Try to load from config file
 	if err := config.loadFromFile(); err != nil {
 		// Config file is optional, just log the error
-		fmt.Printf("Config file not found or invalid, using defaults: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Config file not found or invalid, using defaults: %v\n", err)
+	}
+
+	// Checked after merging, since the config file can set these too
+	if err := config.AutoEvaluation.Validate(); err != nil {
+		return config, fmt.Errorf("invalid auto-evaluation configuration: %w", err)
 	}
 
 	return config, nil
 }
 
-// loadFromEnv loads configuration from environment variables
-func (c *ServerConfig) loadFromEnv() {
+// loadFromEnv loads configuration from environment variables. Values that
+// fail to parse or are out of range are reported together in the returned
+// error rather than silently replaced by defaults.
+func (c *ServerConfig) loadFromEnv() error {
+	var errs envErrors
+
 	// Tasks directory
 	if tasksDir := os.Getenv("TASKS_DIR"); tasksDir != "" {
 		c.TasksDir = tasksDir
@@ -47,36 +165,317 @@ func (c *ServerConfig) loadFromEnv() {
 		c.LogLevel = logLevel
 	}
 
+	// Require explicit file types in generate_task_file
+	if strict := os.Getenv("STRICT_FILE_TYPES"); strict != "" {
+		if val, err := strconv.ParseBool(strict); err == nil {
+			c.StrictFileTypes = val
+		} else {
+			errs.add("STRICT_FILE_TYPES", strict, err)
+		}
+	}
+
+	if maxBytes := os.Getenv("CONTEXT_PRIMER_MAX_BYTES"); maxBytes != "" {
+		if val, err := parsePositiveInt(maxBytes); err == nil {
+			c.ContextPrimerMaxBytes = val
+		} else {
+			errs.add("CONTEXT_PRIMER_MAX_BYTES", maxBytes, err)
+		}
+	}
+
+	// Priority weights, e.g. PRIORITY_WEIGHTS="P0=5,P1=3,P2=2,P3=1"
+	if weights := os.Getenv("PRIORITY_WEIGHTS"); weights != "" {
+		if parsed, err := parsePriorityWeights(weights); err == nil {
+			c.PriorityWeights = parsed
+		} else {
+			errs.add("PRIORITY_WEIGHTS", weights, err)
+		}
+	}
+
+	// Maximum project file size in bytes
+	if maxSize := os.Getenv("MAX_FILE_SIZE"); maxSize != "" {
+		if val, err := strconv.ParseInt(strings.TrimSpace(maxSize), 10, 64); err != nil {
+			errs.add("MAX_FILE_SIZE", maxSize, errors.New("not a whole number"))
+		} else if val <= 0 {
+			errs.add("MAX_FILE_SIZE", maxSize, errors.New("must be greater than zero"))
+		} else {
+			c.MaxFileSize = val
+		}
+	}
+
+	// Let read tools create missing projects
+	if autoCreate := os.Getenv("AUTO_CREATE_PROJECTS"); autoCreate != "" {
+		if val, err := strconv.ParseBool(autoCreate); err == nil {
+			c.AutoCreateProjects = val
+		} else {
+			errs.add("AUTO_CREATE_PROJECTS", autoCreate, err)
+		}
+	}
+
+	if maxWatchers := os.Getenv("MAX_WATCH_SUBSCRIBERS"); maxWatchers != "" {
+		if val, err := parsePositiveInt(maxWatchers); err == nil {
+			c.MaxWatchSubscribers = val
+		} else {
+			errs.add("MAX_WATCH_SUBSCRIBERS", maxWatchers, err)
+		}
+	}
+
+	// Transport and network listen address
+	if transport := os.Getenv("TRANSPORT"); transport != "" {
+		c.Transport = transport
+	}
+	if host := os.Getenv("HOST"); host != "" {
+		c.Host = host
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		if err := validatePort(port); err == nil {
+			c.Port = port
+		} else {
+			errs.add("PORT", port, err)
+		}
+	}
+
+	// Read-only server mode
+	if readOnly := os.Getenv("READ_ONLY"); readOnly != "" {
+		if val, err := strconv.ParseBool(readOnly); err == nil {
+			c.ReadOnly = val
+		} else {
+			errs.add("READ_ONLY", readOnly, err)
+		}
+	}
+
+	// Project file namespace for shared tasks directories
+	if namespace := os.Getenv("PROJECT_NAMESPACE"); namespace != "" {
+		c.Namespace = namespace
+	}
+
+	// Completion message, e.g. without emoji for clients that can't render it
+	if message := os.Getenv("COMPLETION_MESSAGE"); message != "" {
+		c.CompletionMessage = message
+	}
+
+	// Hide placeholder projects without tasks
+	if excludeEmpty := os.Getenv("EXCLUDE_EMPTY_PROJECTS"); excludeEmpty != "" {
+		if val, err := strconv.ParseBool(excludeEmpty); err == nil {
+			c.ExcludeEmptyProjects = val
+		} else {
+			errs.add("EXCLUDE_EMPTY_PROJECTS", excludeEmpty, err)
+		}
+	}
+
+	// Fallback tasks directory when project root detection fails
+	if fallbackDir := os.Getenv("FALLBACK_TASKS_DIR"); fallbackDir != "" {
+		c.FallbackTasksDir = fallbackDir
+	}
+	if disableFallback := os.Getenv("DISABLE_TASKS_DIR_FALLBACK"); disableFallback != "" {
+		if val, err := strconv.ParseBool(disableFallback); err == nil {
+			c.DisableFallback = val
+		} else {
+			errs.add("DISABLE_TASKS_DIR_FALLBACK", disableFallback, err)
+		}
+	}
+
+	// Markdown heading level for tasks
+	if level := os.Getenv("TASK_HEADING_LEVEL"); level != "" {
+		if val, err := strconv.Atoi(strings.TrimSpace(level)); err != nil {
+			errs.add("TASK_HEADING_LEVEL", level, errors.New("not a whole number"))
+		} else if val < 2 || val > 5 {
+			errs.add("TASK_HEADING_LEVEL", level, errors.New("must be between 2 and 5"))
+		} else {
+			c.HeadingLevel = val
+		}
+	}
+
+	// Markdown labels, e.g. MARKDOWN_LABELS="subtasks=Teilaufgaben,tags=Schlagworte"
+	if labels := os.Getenv("MARKDOWN_LABELS"); labels != "" {
+		if parsed, err := task.ParseMarkdownLabels(labels); err == nil {
+			c.MarkdownLabels = parsed
+		} else {
+			errs.add("MARKDOWN_LABELS", labels, err)
+		}
+	}
+
+	// Complexity gate for auto-created subtasks
+	if gate := os.Getenv("SUBTASK_COMPLEXITY_GATE"); gate != "" {
+		if complexity, err := task.ValidateTaskComplexity(strings.TrimSpace(gate)); err == nil {
+			c.SubtaskComplexityGate = string(complexity)
+		} else {
+			errs.add("SUBTASK_COMPLEXITY_GATE", gate, err)
+		}
+	}
+
+	// Suggestion scoring weights, e.g. SUGGESTION_WEIGHTS="ready=120,priority_p0=60"
+	if weights := os.Getenv("SUGGESTION_WEIGHTS"); weights != "" {
+		if parsed, err := parseSuggestionWeights(weights); err == nil {
+			c.SuggestionWeights = parsed
+		} else {
+			errs.add("SUGGESTION_WEIGHTS", weights, err)
+		}
+	}
+
+	// What marking a task done does to its incomplete subtasks
+	if mode := os.Getenv("INCOMPLETE_SUBTASKS_MODE"); mode != "" {
+		switch normalized := strings.ToLower(strings.TrimSpace(mode)); normalized {
+		case incompleteSubtasksComplete, incompleteSubtasksConfirm:
+			c.IncompleteSubtasksMode = normalized
+		default:
+			errs.add("INCOMPLETE_SUBTASKS_MODE", mode, fmt.Errorf("must be %s or %s", incompleteSubtasksComplete, incompleteSubtasksConfirm))
+		}
+	}
+
+	// Blend of subtasks and done criteria in task completion, e.g. DONE_CRITERIA_WEIGHT=0.3
+	if weight := os.Getenv("DONE_CRITERIA_WEIGHT"); weight != "" {
+		if val, err := strconv.ParseFloat(strings.TrimSpace(weight), 64); err != nil {
+			errs.add("DONE_CRITERIA_WEIGHT", weight, errors.New("not a number"))
+		} else if val < 0 || val > 1 {
+			errs.add("DONE_CRITERIA_WEIGHT", weight, errors.New("must be between 0 and 1"))
+		} else {
+			c.DoneCriteriaWeight = val
+		}
+	}
+
+	// How long saves wait on a project file locked by another process, e.g. FILE_LOCK_TIMEOUT=10s
+	if timeout := os.Getenv("FILE_LOCK_TIMEOUT"); timeout != "" {
+		if duration, err := parsePositiveDuration(timeout); err == nil {
+			c.LockTimeout = duration
+		} else {
+			errs.add("FILE_LOCK_TIMEOUT", timeout, err)
+		}
+	}
+
+	// Previous project versions kept for undo, e.g. PROJECT_HISTORY_LIMIT=50 (-1 disables)
+	if limit := os.Getenv("PROJECT_HISTORY_LIMIT"); limit != "" {
+		if val, err := strconv.Atoi(strings.TrimSpace(limit)); err != nil {
+			errs.add("PROJECT_HISTORY_LIMIT", limit, errors.New("not a whole number"))
+		} else if val == 0 {
+			errs.add("PROJECT_HISTORY_LIMIT", limit, errors.New("must not be zero; use -1 to keep no history"))
+		} else {
+			c.HistoryLimit = val
+		}
+	}
+
 	// Auto-evaluation settings
 	if enabled := os.Getenv("AUTO_EVAL_ENABLED"); enabled != "" {
 		if val, err := strconv.ParseBool(enabled); err == nil {
 			c.AutoEvaluation.Enabled = val
+		} else {
+			errs.add("AUTO_EVAL_ENABLED", enabled, err)
 		}
 	}
 
 	if timeout := os.Getenv("AUTO_EVAL_CACHE_TIMEOUT"); timeout != "" {
-		if duration, err := time.ParseDuration(timeout); err == nil {
+		if duration, err := parsePositiveDuration(timeout); err == nil {
 			c.AutoEvaluation.CacheTimeout = duration
+		} else {
+			errs.add("AUTO_EVAL_CACHE_TIMEOUT", timeout, err)
 		}
 	}
 
 	if maxConcurrent := os.Getenv("AUTO_EVAL_MAX_CONCURRENT"); maxConcurrent != "" {
-		if val, err := strconv.Atoi(maxConcurrent); err == nil {
+		if val, err := parsePositiveInt(maxConcurrent); err == nil {
 			c.AutoEvaluation.MaxConcurrent = val
+		} else {
+			errs.add("AUTO_EVAL_MAX_CONCURRENT", maxConcurrent, err)
 		}
 	}
 
 	if skipReadOnly := os.Getenv("AUTO_EVAL_SKIP_READ_ONLY"); skipReadOnly != "" {
 		if val, err := strconv.ParseBool(skipReadOnly); err == nil {
 			c.AutoEvaluation.SkipReadOnlyTools = val
+		} else {
+			errs.add("AUTO_EVAL_SKIP_READ_ONLY", skipReadOnly, err)
+		}
+	}
+
+	if maxEntries := os.Getenv("AUTO_EVAL_MAX_CACHE_ENTRIES"); maxEntries != "" {
+		if val, err := parsePositiveInt(maxEntries); err == nil {
+			c.AutoEvaluation.MaxCacheEntries = val
+		} else {
+			errs.add("AUTO_EVAL_MAX_CACHE_ENTRIES", maxEntries, err)
+		}
+	}
+
+	if mode := os.Getenv("AUTO_EVAL_SUMMARY"); mode != "" {
+		if val, err := ValidateSummaryMode(strings.ToLower(strings.TrimSpace(mode))); err == nil {
+			c.AutoEvaluation.SummaryMode = val
+		} else {
+			errs.add("AUTO_EVAL_SUMMARY", mode, err)
 		}
 	}
 
 	if verbose := os.Getenv("AUTO_EVAL_VERBOSE"); verbose != "" {
 		if val, err := strconv.ParseBool(verbose); err == nil {
 			c.AutoEvaluation.VerboseLogging = val
+		} else {
+			errs.add("AUTO_EVAL_VERBOSE", verbose, err)
+		}
+	}
+
+	// Completion rules
+	if days := os.Getenv("AUTO_COMPLETE_STALE_DAYS"); days != "" {
+		if val, err := parsePositiveInt(days); err == nil {
+			c.AutoEvaluation.CompletionRules.StaleDays = val
+		} else {
+			errs.add("AUTO_COMPLETE_STALE_DAYS", days, err)
+		}
+	}
+
+	if days := os.Getenv("AUTO_COMPLETE_TODO_AGE_DAYS"); days != "" {
+		if val, err := parsePositiveInt(days); err == nil {
+			c.AutoEvaluation.CompletionRules.TodoAgeDays = val
+		} else {
+			errs.add("AUTO_COMPLETE_TODO_AGE_DAYS", days, err)
+		}
+	}
+
+	if days := os.Getenv("AUTO_COMPLETE_SUBTASK_STALE_DAYS"); days != "" {
+		if val, err := parsePositiveInt(days); err == nil {
+			c.AutoEvaluation.CompletionRules.SubtaskStaleDays = val
+		} else {
+			errs.add("AUTO_COMPLETE_SUBTASK_STALE_DAYS", days, err)
+		}
+	}
+
+	if parents := os.Getenv("AUTO_COMPLETE_PARENTS"); parents != "" {
+		if val, err := strconv.ParseBool(parents); err == nil {
+			c.AutoEvaluation.CompletionRules.KeepParentsOpen = !val
+		} else {
+			errs.add("AUTO_COMPLETE_PARENTS", parents, err)
 		}
 	}
+
+	return errors.Join(errs...)
+}
+
+// envErrors collects environment variables whose values could not be used
+type envErrors []error
+
+// add records an unusable value for an environment variable
+func (e *envErrors) add(name, value string, err error) {
+	*e = append(*e, fmt.Errorf("%s=%q: %w", name, value, err))
+}
+
+// parsePositiveInt parses a whole number greater than zero
+func parsePositiveInt(value string) (int, error) {
+	val, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.New("not a whole number")
+	}
+	if val <= 0 {
+		return 0, errors.New("must be greater than zero")
+	}
+	return val, nil
+}
+
+// parsePositiveDuration parses a duration such as "10s" that is greater than zero
+func parsePositiveDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, errors.New("must be greater than zero")
+	}
+	return duration, nil
 }
 
 // loadFromFile loads configuration from a JSON config file
@@ -109,6 +508,75 @@ func (c *ServerConfig) mergeConfig(other ServerConfig) {
 	if other.LogLevel != "" {
 		c.LogLevel = other.LogLevel
 	}
+	if other.StrictFileTypes {
+		c.StrictFileTypes = true
+	}
+	if other.ContextPrimerMaxBytes > 0 {
+		c.ContextPrimerMaxBytes = other.ContextPrimerMaxBytes
+	}
+	if len(other.PriorityWeights) > 0 {
+		c.PriorityWeights = other.PriorityWeights
+	}
+	if other.MaxFileSize > 0 {
+		c.MaxFileSize = other.MaxFileSize
+	}
+	if other.AutoCreateProjects {
+		c.AutoCreateProjects = true
+	}
+	if other.MaxWatchSubscribers > 0 {
+		c.MaxWatchSubscribers = other.MaxWatchSubscribers
+	}
+	if other.Transport != "" {
+		c.Transport = other.Transport
+	}
+	if other.Host != "" {
+		c.Host = other.Host
+	}
+	if other.Port != "" {
+		c.Port = other.Port
+	}
+	if other.ReadOnly {
+		c.ReadOnly = true
+	}
+	if other.Namespace != "" {
+		c.Namespace = other.Namespace
+	}
+	if other.CompletionMessage != "" {
+		c.CompletionMessage = other.CompletionMessage
+	}
+	if other.ExcludeEmptyProjects {
+		c.ExcludeEmptyProjects = true
+	}
+	if other.FallbackTasksDir != "" {
+		c.FallbackTasksDir = other.FallbackTasksDir
+	}
+	if other.DisableFallback {
+		c.DisableFallback = true
+	}
+	if other.HeadingLevel != 0 {
+		c.HeadingLevel = other.HeadingLevel
+	}
+	if len(other.MarkdownLabels) > 0 {
+		c.MarkdownLabels = other.MarkdownLabels
+	}
+	if other.SubtaskComplexityGate != "" {
+		c.SubtaskComplexityGate = other.SubtaskComplexityGate
+	}
+	if len(other.SuggestionWeights) > 0 {
+		c.SuggestionWeights = other.SuggestionWeights
+	}
+	if other.IncompleteSubtasksMode != "" {
+		c.IncompleteSubtasksMode = other.IncompleteSubtasksMode
+	}
+	if other.DoneCriteriaWeight > 0 && other.DoneCriteriaWeight <= 1 {
+		c.DoneCriteriaWeight = other.DoneCriteriaWeight
+	}
+	if other.LockTimeout > 0 {
+		c.LockTimeout = other.LockTimeout
+	}
+	if other.HistoryLimit != 0 {
+		c.HistoryLimit = other.HistoryLimit
+	}
 
 	// Merge auto-evaluation config
 	if other.AutoEvaluation.CacheTimeout != 0 {
@@ -117,12 +585,126 @@ func (c *ServerConfig) mergeConfig(other ServerConfig) {
 	if other.AutoEvaluation.MaxConcurrent != 0 {
 		c.AutoEvaluation.MaxConcurrent = other.AutoEvaluation.MaxConcurrent
 	}
+	if other.AutoEvaluation.MaxCacheEntries > 0 {
+		c.AutoEvaluation.MaxCacheEntries = other.AutoEvaluation.MaxCacheEntries
+	}
+	if other.AutoEvaluation.SummaryMode != "" {
+		c.AutoEvaluation.SummaryMode = other.AutoEvaluation.SummaryMode
+	}
+	if other.AutoEvaluation.CompletionRules.StaleDays > 0 {
+		c.AutoEvaluation.CompletionRules.StaleDays = other.AutoEvaluation.CompletionRules.StaleDays
+	}
+	if other.AutoEvaluation.CompletionRules.TodoAgeDays > 0 {
+		c.AutoEvaluation.CompletionRules.TodoAgeDays = other.AutoEvaluation.CompletionRules.TodoAgeDays
+	}
+	if other.AutoEvaluation.CompletionRules.SubtaskStaleDays > 0 {
+		c.AutoEvaluation.CompletionRules.SubtaskStaleDays = other.AutoEvaluation.CompletionRules.SubtaskStaleDays
+	}
+	if other.AutoEvaluation.CompletionRules.KeepParentsOpen {
+		c.AutoEvaluation.CompletionRules.KeepParentsOpen = true
+	}
 	// Note: boolean fields are merged as-is since false is a valid value
 	c.AutoEvaluation.Enabled = other.AutoEvaluation.Enabled
 	c.AutoEvaluation.SkipReadOnlyTools = other.AutoEvaluation.SkipReadOnlyTools
 	c.AutoEvaluation.VerboseLogging = other.AutoEvaluation.VerboseLogging
 }
 
+// parsePriorityWeights parses a "P0=4,P1=3" style list into priority weights
+func parsePriorityWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid priority weight %q (expected P0=4)", pair)
+		}
+		priority, err := task.ValidateTaskPriority(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight for %s: %q", priority, parts[1])
+		}
+		weights[string(priority)] = weight
+	}
+	return weights, nil
+}
+
+// ValidateTransport checks the transport, host and port, returning a clear
+// error instead of letting a bad value fail deep in the network stack
+func (c *ServerConfig) ValidateTransport() error {
+	switch c.Transport {
+	case "stdio", "sse", "streamable-http":
+	default:
+		return fmt.Errorf("invalid transport %q: must be stdio, sse or streamable-http", c.Transport)
+	}
+
+	// Host and port only matter when listening on the network
+	if c.Transport == "stdio" {
+		return nil
+	}
+
+	if err := validateHost(c.Host); err != nil {
+		return err
+	}
+	return validatePort(c.Port)
+}
+
+// validatePort checks that a port is numeric and within 1-65535
+func validatePort(port string) error {
+	value, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("invalid port %q: must be a number", port)
+	}
+	if value < 1 || value > 65535 {
+		return fmt.Errorf("invalid port %d: must be between 1 and 65535", value)
+	}
+	return nil
+}
+
+// validateHost checks that a host is an IP address or a well-formed hostname
+func validateHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("invalid host: cannot be empty")
+	}
+	if net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return nil
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid host %q: not an IP address or hostname", host)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("invalid host %q: not an IP address or hostname", host)
+			}
+		}
+	}
+	return nil
+}
+
+// GetPriorityWeights returns the configured priority weights layered over the defaults
+func (c *ServerConfig) GetPriorityWeights() task.PriorityWeights {
+	weights := task.DefaultPriorityWeights()
+	for priority, weight := range c.PriorityWeights {
+		if _, err := task.ValidateTaskPriority(priority); err == nil && weight >= 0 {
+			weights[task.TaskPriority(priority)] = weight
+		}
+	}
+	return weights
+}
+
+// GetSuggestionWeights returns the configured suggestion weights layered over the
+// defaults; unknown weight names are ignored
+func (c *ServerConfig) GetSuggestionWeights() SuggestionWeights {
+	weights := DefaultSuggestionWeights()
+	for name, weight := range c.SuggestionWeights {
+		_ = weights.set(name, weight)
+	}
+	return weights
+}
+
 // SaveConfigTemplate saves a template configuration file
 func SaveConfigTemplate(path string) error {
 	config := ServerConfig{
@@ -148,12 +730,37 @@ func (c *ServerConfig) GetConfigSummary() map[string]interface{} {
 	return map[string]interface{}{
 		"tasks_dir":  c.TasksDir,
 		"log_level":  c.LogLevel,
+		"strict_file_types": c.StrictFileTypes,
+		"context_primer_max_bytes": c.ContextPrimerMaxBytes,
+		"max_file_size": c.MaxFileSize,
+		"auto_create_projects": c.AutoCreateProjects,
+		"max_watch_subscribers": c.MaxWatchSubscribers,
+		"transport": c.Transport,
+		"host": c.Host,
+		"port": c.Port,
+		"read_only": c.ReadOnly,
+		"namespace": c.Namespace,
+		"completion_message": c.CompletionMessage,
+		"exclude_empty_projects": c.ExcludeEmptyProjects,
+		"fallback_tasks_dir": c.FallbackTasksDir,
+		"disable_fallback": c.DisableFallback,
+		"heading_level": c.HeadingLevel,
+		"markdown_labels": c.MarkdownLabels,
+		"subtask_complexity_gate": c.SubtaskComplexityGate,
+		"suggestion_weights": c.GetSuggestionWeights(),
+		"incomplete_subtasks_mode": c.IncompleteSubtasksMode,
+		"done_criteria_weight": c.DoneCriteriaWeight,
+		"lock_timeout": c.LockTimeout.String(),
+		"history_limit": c.HistoryLimit,
 		"auto_evaluation": map[string]interface{}{
 			"enabled":             c.AutoEvaluation.Enabled,
 			"cache_timeout":       c.AutoEvaluation.CacheTimeout.String(),
 			"max_concurrent":      c.AutoEvaluation.MaxConcurrent,
 			"skip_read_only_tools": c.AutoEvaluation.SkipReadOnlyTools,
 			"verbose_logging":     c.AutoEvaluation.VerboseLogging,
+			"max_cache_entries":   c.AutoEvaluation.MaxCacheEntries,
+			"summary_mode":        c.AutoEvaluation.SummaryMode,
+			"completion_rules":    c.AutoEvaluation.CompletionRules,
 		},
 	}
 }
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadFromEnvReportsInvalidValues(t *testing.T) {
+	tests := []struct {
+		env   string
+		value string
+	}{
+		{"PORT", "http"},
+		{"PORT", "70000"},
+		{"TASK_HEADING_LEVEL", "two"},
+		{"TASK_HEADING_LEVEL", "7"},
+		{"MARKDOWN_LABELS", "subtasks"},
+		{"STRICT_FILE_TYPES", "yes please"},
+		{"READ_ONLY", "maybe"},
+		{"AUTO_EVAL_ENABLED", "on-ish"},
+		{"CONTEXT_PRIMER_MAX_BYTES", "-5"},
+		{"MAX_FILE_SIZE", "10MB"},
+		{"AUTO_EVAL_MAX_CONCURRENT", "four"},
+		{"AUTO_EVAL_CACHE_TIMEOUT", "5 minutes"},
+		{"AUTO_EVAL_MAX_CONCURRENT", "0"},
+		{"AUTO_EVAL_MAX_CONCURRENT", "-2"},
+		{"AUTO_EVAL_CACHE_TIMEOUT", "0s"},
+		{"AUTO_EVAL_CACHE_TIMEOUT", "-1m"},
+		{"FILE_LOCK_TIMEOUT", "0s"},
+		{"DONE_CRITERIA_WEIGHT", "1.5"},
+		{"INCOMPLETE_SUBTASKS_MODE", "ask"},
+		{"PRIORITY_WEIGHTS", "P9=1"},
+		{"PROJECT_HISTORY_LIMIT", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			config := ServerConfig{Port: defaultPort, DoneCriteriaWeight: 0.5}
+			err := config.loadFromEnv()
+			if err == nil || !strings.Contains(err.Error(), tt.env) {
+				t.Fatalf("loadFromEnv() = %v, want an error naming %s", err, tt.env)
+			}
+			if config.Port != defaultPort || config.HeadingLevel != 0 || config.DoneCriteriaWeight != 0.5 {
+				t.Errorf("invalid value was applied: %+v", config)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvReportsEveryInvalidValue(t *testing.T) {
+	t.Setenv("PORT", "abc")
+	t.Setenv("READ_ONLY", "nope")
+	var config ServerConfig
+	err := config.loadFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "PORT") || !strings.Contains(err.Error(), "READ_ONLY") {
+		t.Errorf("loadFromEnv() = %v, want errors for PORT and READ_ONLY", err)
+	}
+}
+
+func TestLoadFromEnvAppliesValidValues(t *testing.T) {
+	t.Setenv("PORT", "9000")
+	t.Setenv("TASK_HEADING_LEVEL", "3")
+	t.Setenv("MARKDOWN_LABELS", "subtasks=Teilaufgaben")
+	t.Setenv("READ_ONLY", "true")
+	t.Setenv("AUTO_EVAL_CACHE_TIMEOUT", "2m")
+	t.Setenv("INCOMPLETE_SUBTASKS_MODE", " Confirm ")
+
+	var config ServerConfig
+	if err := config.loadFromEnv(); err != nil {
+		t.Fatalf("loadFromEnv: %v", err)
+	}
+	if config.Port != "9000" || config.HeadingLevel != 3 || config.MarkdownLabels["subtasks"] != "Teilaufgaben" ||
+		!config.ReadOnly || config.AutoEvaluation.CacheTimeout != 2*time.Minute || config.IncompleteSubtasksMode != incompleteSubtasksConfirm {
+		t.Errorf("config = %+v", config)
+	}
+}
+
+func TestLoadServerConfigFailsOnInvalidEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TASK_HEADING_LEVEL", "deep")
+	if _, err := LoadServerConfig(); err == nil || !strings.Contains(err.Error(), "TASK_HEADING_LEVEL") {
+		t.Errorf("LoadServerConfig() = %v, want an error naming TASK_HEADING_LEVEL", err)
+	}
+}
+
+func TestAutoEvaluationConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*AutoEvaluationConfig)
+		wantErr []string
+	}{
+		{"defaults", func(*AutoEvaluationConfig) {}, nil},
+		{"zero concurrency", func(c *AutoEvaluationConfig) { c.MaxConcurrent = 0 }, []string{"max concurrent"}},
+		{"negative concurrency", func(c *AutoEvaluationConfig) { c.MaxConcurrent = -1 }, []string{"max concurrent"}},
+		{"zero cache timeout", func(c *AutoEvaluationConfig) { c.CacheTimeout = 0 }, []string{"cache timeout"}},
+		{"negative cache timeout", func(c *AutoEvaluationConfig) { c.CacheTimeout = -time.Minute }, []string{"cache timeout"}},
+		{"both", func(c *AutoEvaluationConfig) { c.CacheTimeout, c.MaxConcurrent = 0, 0 }, []string{"cache timeout", "max concurrent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultAutoEvaluationConfig()
+			tt.change(&config)
+			err := config.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want an error containing %q", err, want)
+				}
+			}
+
+			// The middleware runs with the defaults instead
+			m := NewAutoEvaluationMiddleware(nil, config)
+			defaults := DefaultAutoEvaluationConfig()
+			if cap(m.semaphore) < 1 || m.config.CacheTimeout <= 0 || m.config.Validate() != nil {
+				t.Errorf("middleware config = timeout %s, %d concurrent (semaphore %d); want the defaults %s, %d",
+					m.config.CacheTimeout, m.config.MaxConcurrent, cap(m.semaphore), defaults.CacheTimeout, defaults.MaxConcurrent)
+			}
+		})
+	}
+}
+
+func TestLoadServerConfigRejectsInvalidAutoEvaluation(t *testing.T) {
+	// A config file can set values the environment parser would refuse
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"auto_evaluation": {"max_concurrent": -3}}`), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadServerConfig(); err == nil || !strings.Contains(err.Error(), "max concurrent") {
+		t.Errorf("LoadServerConfig() = %v, want an error about max concurrent evaluations", err)
+	}
+}
+
+func TestValidateTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport string
+		host      string
+		port      string
+		wantErr   string
+	}{
+		{"stdio ignores the address", "stdio", "", "not a port", ""},
+		{"sse", "sse", "localhost", "8050", ""},
+		{"streamable http on IPv6", "streamable-http", "[::1]", "443", ""},
+		{"unknown transport", "websocket", "localhost", "8050", "invalid transport"},
+		{"empty host", "sse", "", "8050", "invalid host"},
+		{"host with underscore", "sse", "my_host", "8050", "invalid host"},
+		{"host label starting with a dash", "sse", "-bad.example.com", "8050", "invalid host"},
+		{"non-numeric port", "sse", "localhost", "http", "must be a number"},
+		{"port zero", "sse", "localhost", "0", "between 1 and 65535"},
+		{"port too large", "streamable-http", "localhost", "65536", "between 1 and 65535"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ServerConfig{Transport: tt.transport, Host: tt.host, Port: tt.port}
+			err := config.ValidateTransport()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTransport() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateTransport() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
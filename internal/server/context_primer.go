@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultContextPrimerMaxBytes is the default size budget for context_primer output
+const defaultContextPrimerMaxBytes = 4000
+
+// contextPrimerTruncationMarker is appended when the primer is cut to fit its budget
+const contextPrimerTruncationMarker = "\n... (truncated)"
+
+// handleContextPrimer handles the context_primer tool
+func (tms *TaskManagerServer) handleContextPrimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	maxBytes := tms.parseNumberField(request, "max_bytes", tms.config.ContextPrimerMaxBytes)
+	if maxBytes <= 0 {
+		maxBytes = defaultContextPrimerMaxBytes
+	}
+	actionsPerProject := tms.parseNumberField(request, "actions_per_project", 3)
+	if actionsPerProject < 0 {
+		actionsPerProject = 0
+	}
+
+	projects, err := tms.taskManager.ListProjects()
+	if err != nil {
+		return tms.createErrorResult("context_primer", err), nil
+	}
+
+	primer := tms.buildContextPrimer(projects, actionsPerProject)
+	return tms.createSuccessResult(truncateToBudget(primer, maxBytes)), nil
+}
+
+// buildContextPrimer renders a compact plain-text overview of every project and
+// its top next actions, meant to be injected into an LLM context at session start
+func (tms *TaskManagerServer) buildContextPrimer(projects []string, actionsPerProject int) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("Task manager state: %d project(s)\n", len(projects)))
+
+	for _, projectName := range projects {
+		project, err := tms.taskManager.LoadProject(projectName)
+		if err != nil {
+			content.WriteString(fmt.Sprintf("\n[%s] unreadable: %v\n", projectName, err))
+			continue
+		}
+
+		content.WriteString(fmt.Sprintf("\n[%s] %d/%d tasks done, %.0f%% overall",
+			projectName, project.GetCompletedTaskCount(), len(project.Tasks), project.GetProgressPercentage()))
+		if pending := project.GetPendingChoicesCount(); pending > 0 {
+			content.WriteString(fmt.Sprintf(", %d pending choice(s)", pending))
+		}
+		content.WriteString("\n")
+
+		if actionsPerProject == 0 {
+			continue
+		}
+
+		suggestions := tms.analyzeProjectAndSuggest(project, "", "", actionsPerProject, false, false)
+		for _, suggestion := range suggestions {
+			line := fmt.Sprintf("- %s %s (%s, %s)", suggestion["priority"], suggestion["title"], suggestion["status"], suggestion["reason"])
+			if next, ok := suggestion["next_subtask"].(string); ok && next != "" {
+				line += fmt.Sprintf(" next: %s", next)
+			}
+			content.WriteString(line + "\n")
+		}
+	}
+
+	return content.String()
+}
+
+// truncateToBudget cuts text to at most maxBytes bytes, ending on a line
+// boundary where possible and marking the cut
+func truncateToBudget(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+
+	if maxBytes <= len(contextPrimerTruncationMarker) {
+		return text[:maxBytes]
+	}
+
+	cut := text[:maxBytes-len(contextPrimerTruncationMarker)]
+	if lastNewline := strings.LastIndex(cut, "\n"); lastNewline > 0 {
+		cut = cut[:lastNewline]
+	}
+	return cut + contextPrimerTruncationMarker
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleAddTaskDependency handles the add_task_dependency tool
+func (tms *TaskManagerServer) handleAddTaskDependency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return tms.handleTaskDependency(request, "add_task_dependency", true)
+}
+
+// handleRemoveTaskDependency handles the remove_task_dependency tool
+func (tms *TaskManagerServer) handleRemoveTaskDependency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return tms.handleTaskDependency(request, "remove_task_dependency", false)
+}
+
+// handleTaskDependency makes task_title depend, or stop depending, on depends_on_title.
+// Adding a dependency that is already present, or removing one that isn't, changes nothing.
+func (tms *TaskManagerServer) handleTaskDependency(request mcp.CallToolRequest, operation string, add bool) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	dependsOnTitle, err := request.RequireString("depends_on_title")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing depends_on_title: %w", err)), nil
+	}
+
+	partialMatch := tms.parseBooleanField(request, "partial_match", false)
+
+	var targetTask, dependency *task.Task
+	changed := false
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partialMatch)
+		if err != nil {
+			return err
+		}
+		dependency, _, err = tms.resolveTaskTitle(project, dependsOnTitle, partialMatch)
+		if err != nil {
+			return err
+		}
+
+		present := slices.Contains(targetTask.Dependencies, dependency.ID)
+		changed = false
+
+		if add && !present {
+			if dependency.ID == targetTask.ID {
+				return task.NewError(task.ErrInvalidInput, "task '%s' cannot depend on itself", targetTask.Title)
+			}
+
+			// Check before changing anything so a cycle is never saved
+			if task.WouldCreateCycle(project, targetTask.ID, dependency.ID) {
+				return task.NewError(task.ErrInvalidInput,
+					"making '%s' depend on '%s' would create a circular dependency", targetTask.Title, dependency.Title)
+			}
+
+			targetTask.Dependencies = append(targetTask.Dependencies, dependency.ID)
+			changed = true
+		}
+
+		if !add && present {
+			targetTask.Dependencies = slices.DeleteFunc(targetTask.Dependencies, func(id int) bool {
+				return id == dependency.ID
+			})
+			changed = true
+		}
+
+		if !changed {
+			return task.SkipSave
+		}
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+
+	dependencies := targetTask.Dependencies
+	if dependencies == nil {
+		dependencies = []int{}
+	}
+
+	result := map[string]interface{}{
+		"project":      projectName,
+		"task":         targetTask.Title,
+		"depends_on":   dependency.Title,
+		"changed":      changed,
+		"dependencies": dependencies,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult(operation, fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleSetDoneCriteria handles the set_done_criteria tool
+func (tms *TaskManagerServer) handleSetDoneCriteria(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("set_done_criteria", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("set_done_criteria", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	if _, present := request.GetArguments()["criteria"]; !present {
+		return tms.createErrorResult("set_done_criteria", task.NewError(task.ErrInvalidInput, "missing criteria: pass an empty array to clear them")), nil
+	}
+	values, err := tms.parseStringArray(request, "criteria")
+	if err != nil {
+		return tms.createErrorResult("set_done_criteria", err), nil
+	}
+
+	criteria := []string{}
+	for _, value := range values {
+		criterion, err := task.ValidateDoneCriterion(value)
+		if err != nil {
+			return tms.createErrorResult("set_done_criteria", err), nil
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	var targetTask *task.Task
+	var previous, met []string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.findTaskByTitle(project, taskTitle)
+		if err != nil {
+			return err
+		}
+
+		// Criteria kept from the previous list stay acknowledged
+		previous = targetTask.DoneCriteria
+		met = []string{}
+		for _, criterion := range criteria {
+			if targetTask.IsCriterionAcknowledged(criterion) {
+				met = append(met, criterion)
+			}
+		}
+		targetTask.DoneCriteria = criteria
+		targetTask.MetCriteria = met
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("set_done_criteria", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":           projectName,
+		"task":              targetTask.Title,
+		"done_criteria":     criteria,
+		"met_criteria":      met,
+		"previous_criteria": len(previous),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("set_done_criteria", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleAcknowledgeDoneCriterion handles the acknowledge_done_criterion tool
+func (tms *TaskManagerServer) handleAcknowledgeDoneCriterion(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	criterion, err := request.RequireString("criterion")
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", task.NewError(task.ErrInvalidInput, "missing criterion: %w", err)), nil
+	}
+	criterion, err = task.ValidateDoneCriterion(criterion)
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", err), nil
+	}
+
+	acknowledged := tms.parseBooleanField(request, "acknowledged", true)
+
+	var targetTask *task.Task
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.findTaskByTitle(project, taskTitle)
+		if err != nil {
+			return err
+		}
+
+		if !targetTask.AcknowledgeCriterion(criterion, acknowledged) {
+			return task.NewError(task.ErrNotFound, "task '%s' has no done criterion '%s'", targetTask.Title, criterion)
+		}
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", err), nil
+	}
+
+	met, total, _ := targetTask.GetCriteriaProgress()
+	result := map[string]interface{}{
+		"project":         projectName,
+		"task":            targetTask.Title,
+		"criterion":       criterion,
+		"acknowledged":    acknowledged,
+		"criteria_met":    met,
+		"criteria_total":  total,
+		"task_completion": targetTask.GetTaskCompletion(tms.config.DoneCriteriaWeight),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("acknowledge_done_criterion", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleGetTask handles the get_task tool
+func (tms *TaskManagerServer) handleGetTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("get_task", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("get_task", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("get_task", err), nil
+	}
+
+	targetTask, _, err := tms.resolveTaskTitle(project, taskTitle, tms.parseBooleanField(request, "partial_match", false))
+	if err != nil {
+		return tms.createErrorResult("get_task", err), nil
+	}
+
+	taskDetails := *targetTask
+	if !tms.parseBooleanField(request, "include_resolved_choices", true) {
+		taskDetails = targetTask.WithoutResolvedChoices()
+	}
+
+	result := map[string]interface{}{
+		"project": projectName,
+		"task":    taskDetails,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("get_task", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
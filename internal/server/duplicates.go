@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleFindDuplicates handles the find_duplicates tool
+func (tms *TaskManagerServer) handleFindDuplicates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("find_duplicates", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	fuzzy := tms.parseBooleanField(request, "fuzzy", true)
+	threshold := task.DefaultDuplicateThreshold
+	if raw, ok := request.GetArguments()["threshold"].(float64); ok {
+		if raw <= 0 || raw > 1 {
+			return tms.createErrorResult("find_duplicates", task.NewError(task.ErrInvalidInput, "threshold must be greater than 0 and at most 1, got %v", raw)), nil
+		}
+		threshold = raw
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("find_duplicates", err), nil
+	}
+
+	groups := project.FindDuplicateTasks(fuzzy, threshold)
+
+	result := map[string]interface{}{
+		"project":     projectName,
+		"fuzzy":       fuzzy,
+		"group_count": len(groups),
+		"groups":      groups,
+	}
+	if fuzzy {
+		result["threshold"] = threshold
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("find_duplicates", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleEvaluateAll handles the evaluate_all tool
+func (tms *TaskManagerServer) handleEvaluateAll(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	projectNames, err := tms.taskManager.ListProjects()
+	if err != nil {
+		return tms.createErrorResult("evaluate_all", err), nil
+	}
+
+	results, errs := tms.autoEvalMiddleware.EvaluateAll(ctx, projectNames, dryRun)
+
+	totalUpdates := 0
+	totalAttention := 0
+	projects := make([]map[string]interface{}, 0, len(projectNames))
+	for i, projectName := range projectNames {
+		if errs[i] != nil {
+			projects = append(projects, map[string]interface{}{
+				"project": projectName,
+				"error":   errs[i].Error(),
+			})
+			continue
+		}
+
+		result := results[i]
+		updates := result.UpdatesApplied
+		if updates == nil {
+			updates = []string{}
+		}
+		totalUpdates += len(updates)
+		totalAttention += len(result.AttentionItems)
+		projects = append(projects, map[string]interface{}{
+			"project":         projectName,
+			"updates_applied": updates,
+			"attention_items": summarizeAttention(result.AttentionItems),
+		})
+	}
+
+	summary := map[string]interface{}{
+		"dry_run":            dryRun,
+		"projects_evaluated": len(projectNames),
+		"total_updates":      totalUpdates,
+		"total_attention":    totalAttention,
+		"projects":           projects,
+	}
+
+	resultJSON, err := json.Marshal(summary)
+	if err != nil {
+		return tms.createErrorResult("evaluate_all", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleReconcileProject handles the reconcile_project tool by running the
+// auto-evaluation middleware's evaluation on demand
+func (tms *TaskManagerServer) handleReconcileProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("reconcile_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if err := tms.validateProjectName(projectName); err != nil {
+		return tms.createErrorResult("reconcile_project", err), nil
+	}
+	if !tms.taskManager.ProjectExists(projectName) {
+		return tms.createErrorResult("reconcile_project", task.NewError(task.ErrNotFound, "project '%s' does not exist. Use create_task_file to create it first", projectName)), nil
+	}
+
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	results, errs := tms.autoEvalMiddleware.EvaluateAll(ctx, []string{projectName}, dryRun)
+	if errs[0] != nil {
+		return tms.createErrorResult("reconcile_project", errs[0]), nil
+	}
+
+	updates := results[0].UpdatesApplied
+	if updates == nil {
+		updates = []string{}
+	}
+
+	result := map[string]interface{}{
+		"project":         projectName,
+		"dry_run":         dryRun,
+		"updates":         updates,
+		"update_count":    len(updates),
+		"saved":           !dryRun && len(updates) > 0,
+		"attention_items": summarizeAttention(results[0].AttentionItems),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("reconcile_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
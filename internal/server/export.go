@@ -0,0 +1,164 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// export_project formats
+const (
+	// exportFormatJSON holds the full project as JSON
+	exportFormatJSON = "json"
+	// exportFormatCSV holds one row per task, for spreadsheets
+	exportFormatCSV = "csv"
+	// exportFormatHTML is a standalone progress report page
+	exportFormatHTML = "html"
+)
+
+// handleExportProject handles the export_project tool. The JSON format holds
+// every field of the project, including IDs, timestamps, choices and
+// dependencies, so it can be read back by import_project_json. The CSV format
+// has one row per task, and optionally per subtask, for spreadsheets, and the
+// HTML format is a progress report to open in a browser.
+func (tms *TaskManagerServer) handleExportProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("export_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	format := mcp.ParseString(request, "format", exportFormatJSON)
+	if format != exportFormatJSON && format != exportFormatCSV && format != exportFormatHTML {
+		return tms.createErrorResult("export_project", task.NewError(task.ErrInvalidInput,
+			"invalid format: %s. Valid options: %s, %s, %s", format, exportFormatJSON, exportFormatCSV, exportFormatHTML)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("export_project", err), nil
+	}
+
+	var content []byte
+	switch format {
+	case exportFormatCSV:
+		var buf bytes.Buffer
+		if err := task.WriteTasksCSV(&buf, project, tms.parseBooleanField(request, "flatten_subtasks", false)); err != nil {
+			return tms.createErrorResult("export_project", err), nil
+		}
+		content = buf.Bytes()
+	case exportFormatHTML:
+		report, err := task.GenerateHTMLReport(project)
+		if err != nil {
+			return tms.createErrorResult("export_project", err), nil
+		}
+		content = []byte(report)
+	default:
+		content, err = json.MarshalIndent(project, "", "  ")
+		if err != nil {
+			return tms.createErrorResult("export_project", fmt.Errorf("failed to marshal project: %w", err)), nil
+		}
+	}
+
+	outputPath := strings.TrimSpace(mcp.ParseString(request, "output_path", ""))
+	if outputPath == "" {
+		return tms.createSuccessResult(string(content)), nil
+	}
+
+	if tms.config.ReadOnly {
+		return tms.createErrorResult("export_project", task.NewError(task.ErrInvalidInput, "output_path cannot be used while the server is read-only")), nil
+	}
+
+	fullPath, err := resolvePathInProjectRoot(outputPath)
+	if err != nil {
+		return tms.createErrorResult("export_project", err), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return tms.createErrorResult("export_project", fmt.Errorf("failed to create directory: %w", err)), nil
+	}
+	if err := os.WriteFile(fullPath, content, 0644); err != nil {
+		return tms.createErrorResult("export_project", fmt.Errorf("failed to write export file: %w", err)), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project": projectName,
+		"format":  format,
+		"path":    fullPath,
+		"bytes":   len(content),
+		"tasks":   len(project.Tasks),
+	})
+	if err != nil {
+		return tms.createErrorResult("export_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleImportProjectJSON handles the import_project_json tool
+func (tms *TaskManagerServer) handleImportProjectJSON(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content := mcp.ParseString(request, "json", "")
+	inputPath := strings.TrimSpace(mcp.ParseString(request, "input_path", ""))
+
+	switch {
+	case content != "" && inputPath != "":
+		return tms.createErrorResult("import_project_json", task.NewError(task.ErrInvalidInput, "provide either json or input_path, not both")), nil
+	case inputPath != "":
+		fullPath, err := resolvePathInProjectRoot(inputPath)
+		if err != nil {
+			return tms.createErrorResult("import_project_json", err), nil
+		}
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return tms.createErrorResult("import_project_json", task.NewError(task.ErrNotFound, "cannot read import file: %w", err)), nil
+		}
+		if info.Size() > tms.config.MaxFileSize {
+			return tms.createErrorResult("import_project_json", task.NewError(task.ErrInvalidInput,
+				"import file is %d bytes, which exceeds the maximum of %d bytes", info.Size(), tms.config.MaxFileSize)), nil
+		}
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			return tms.createErrorResult("import_project_json", fmt.Errorf("failed to read import file: %w", err)), nil
+		}
+		content = string(data)
+	case content == "":
+		return tms.createErrorResult("import_project_json", task.NewError(task.ErrInvalidInput, "provide the exported project as json or input_path")), nil
+	}
+
+	var project task.Project
+	if err := json.Unmarshal([]byte(content), &project); err != nil {
+		return tms.createErrorResult("import_project_json", task.NewError(task.ErrInvalidInput, "invalid project JSON: %w", err)), nil
+	}
+
+	// project_name imports under a different name than the exported one
+	if projectName := mcp.ParseString(request, "project_name", ""); projectName != "" {
+		project.Name = projectName
+	}
+	if project.Name == "" {
+		return tms.createErrorResult("import_project_json", task.NewError(task.ErrInvalidInput, "the JSON has no project name; pass project_name")), nil
+	}
+	if err := tms.validateProjectName(project.Name); err != nil {
+		return tms.createErrorResult("import_project_json", err), nil
+	}
+
+	if err := tms.taskManager.ImportProject(&project, tms.parseBooleanField(request, "overwrite", false)); err != nil {
+		return tms.createErrorResult("import_project_json", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project": project.Name,
+		"tasks":   len(project.Tasks),
+		"message": fmt.Sprintf("Imported project '%s' with %d tasks", project.Name, len(project.Tasks)),
+	})
+	if err != nil {
+		return tms.createErrorResult("import_project_json", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// useProjectRoot makes a temporary directory the working directory and the
+// detected project root for the rest of the test, and returns it
+func useProjectRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("MCP_WORKSPACE_ROOT", root)
+	return root
+}
+
+func TestResolvePathInProjectRoot(t *testing.T) {
+	root := useProjectRoot(t)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"report.json", filepath.Join(root, "report.json")},
+		{"exports/report.json", filepath.Join(root, "exports", "report.json")},
+		{"exports/../report.json", filepath.Join(root, "report.json")},
+		{"../report.json", ""},
+		{"exports/../../report.json", ""},
+		{"..", ""},
+		{filepath.Join(root, "report.json"), ""},
+		{"/etc/passwd", ""},
+	}
+	for _, tt := range tests {
+		got, err := resolvePathInProjectRoot(tt.path)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("resolvePathInProjectRoot(%q) = %q, want an error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolvePathInProjectRoot(%q) = %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func TestExportProjectOutputPathConfinedToProjectRoot(t *testing.T) {
+	tms := newTestServer(t)
+	root := useProjectRoot(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	outside := filepath.Join(filepath.Dir(root), "outside.json")
+	for _, path := range []string{outside, "../outside.json"} {
+		result, err := tms.handleExportProject(context.Background(), callTool(map[string]any{
+			"project_name": "p",
+			"output_path":  path,
+		}))
+		if err != nil || !result.IsError {
+			t.Errorf("export to %s succeeded: %v %v", path, err, result)
+		}
+	}
+	if _, err := os.Stat(outside); !os.IsNotExist(err) {
+		t.Errorf("export wrote outside the project root: %v", err)
+	}
+
+	result, err := tms.handleExportProject(context.Background(), callTool(map[string]any{
+		"project_name": "p",
+		"output_path":  "exports/p.json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("export inside the project root failed: %v %v", err, result)
+	}
+	if _, err := os.Stat(filepath.Join(root, "exports", "p.json")); err != nil {
+		t.Errorf("export file not written: %v", err)
+	}
+}
+
+func TestImportProjectJSONRoundTrip(t *testing.T) {
+	tms := newTestServer(t)
+	useProjectRoot(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := tms.taskManager.AddTasks("p", []task.Task{
+		{Title: "Design", Description: "Sketch the API", Priority: task.PriorityP0, Tags: []string{"api"},
+			Subtasks: []task.Subtask{{Title: "Draft", Status: task.StatusDone}}},
+		{Title: "Build", Description: "Write the code", Status: task.StatusInProgress, Dependencies: []int{1}},
+	}); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+
+	result, err := tms.handleExportProject(context.Background(), callTool(map[string]any{
+		"project_name": "p",
+		"output_path":  "p.json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("export_project failed: %v %v", err, result)
+	}
+
+	for _, path := range []string{"../p.json", filepath.Join(os.TempDir(), "p.json")} {
+		result, err := tms.handleImportProjectJSON(context.Background(), callTool(map[string]any{
+			"input_path":   path,
+			"project_name": "outside",
+		}))
+		if err != nil || !result.IsError {
+			t.Errorf("import from %s succeeded: %v %v", path, err, result)
+		}
+	}
+
+	result, err = tms.handleImportProjectJSON(context.Background(), callTool(map[string]any{
+		"input_path":   "p.json",
+		"project_name": "copy",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("import_project_json failed: %v %v", err, result)
+	}
+
+	original, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject(p): %v", err)
+	}
+	tms.taskManager.InvalidateCache("copy")
+	imported, err := tms.taskManager.LoadProject("copy")
+	if err != nil {
+		t.Fatalf("LoadProject(copy): %v", err)
+	}
+	if len(imported.Tasks) != len(original.Tasks) {
+		t.Fatalf("imported %d tasks, want %d", len(imported.Tasks), len(original.Tasks))
+	}
+	for i, want := range original.Tasks {
+		got := imported.Tasks[i]
+		if got.ID != want.ID || got.Title != want.Title || got.Description != want.Description ||
+			got.Status != want.Status || got.Priority != want.Priority ||
+			!slices.Equal(got.Tags, want.Tags) || !slices.Equal(got.Dependencies, want.Dependencies) ||
+			!slices.EqualFunc(got.Subtasks, want.Subtasks, func(a, b task.Subtask) bool { return a.Title == b.Title && a.Status == b.Status }) {
+			t.Errorf("task %d after round trip = %+v, want %+v", i+1, got, want)
+		}
+	}
+}
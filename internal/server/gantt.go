@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleGenerateGantt handles the generate_gantt tool
+func (tms *TaskManagerServer) handleGenerateGantt(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("generate_gantt", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("generate_gantt", err), nil
+	}
+
+	if len(project.Tasks) == 0 {
+		return tms.createSuccessResult("No tasks found in project. Use add_task to create tasks."), nil
+	}
+
+	// The Mermaid source as is, so it can be pasted into a mermaid block
+	return tms.createSuccessResult(task.GenerateMermaidGantt(*project)), nil
+}
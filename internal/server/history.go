@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-task-manager-go/internal/task"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleListHistory handles the list_history tool
+func (tms *TaskManagerServer) handleListHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("list_history", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if _, err := tms.safeLoadProject(projectName); err != nil {
+		return tms.createErrorResult("list_history", err), nil
+	}
+
+	versions, err := tms.taskManager.ListHistory(projectName)
+	if err != nil {
+		return tms.createErrorResult("list_history", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":  projectName,
+		"count":    len(versions),
+		"versions": versions,
+	})
+	if err != nil {
+		return tms.createErrorResult("list_history", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleUndoLastChange handles the undo_last_change tool
+func (tms *TaskManagerServer) handleUndoLastChange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("undo_last_change", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if _, err := tms.safeLoadProject(projectName); err != nil {
+		return tms.createErrorResult("undo_last_change", err), nil
+	}
+
+	// The newest version is the one Undo restores
+	versions, err := tms.taskManager.ListHistory(projectName)
+	if err != nil {
+		return tms.createErrorResult("undo_last_change", err), nil
+	}
+
+	if len(versions) == 0 {
+		return tms.createErrorResult("undo_last_change", task.NewError(task.ErrNotFound, "project '%s' has no earlier version to restore", projectName)), nil
+	}
+
+	if err := tms.taskManager.Undo(projectName); err != nil {
+		return tms.createErrorResult("undo_last_change", err), nil
+	}
+
+	return tms.restoredProjectResult("undo_last_change", projectName, versions[0])
+}
+
+// handleRestoreVersion handles the restore_version tool
+func (tms *TaskManagerServer) handleRestoreVersion(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("restore_version", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	version, err := request.RequireString("version")
+	if err != nil {
+		return tms.createErrorResult("restore_version", task.NewError(task.ErrInvalidInput, "missing version: %w", err)), nil
+	}
+
+	if _, err := tms.safeLoadProject(projectName); err != nil {
+		return tms.createErrorResult("restore_version", err), nil
+	}
+
+	versions, err := tms.taskManager.ListHistory(projectName)
+	if err != nil {
+		return tms.createErrorResult("restore_version", err), nil
+	}
+
+	if err := tms.taskManager.RestoreVersion(projectName, version); err != nil {
+		return tms.createErrorResult("restore_version", err), nil
+	}
+
+	restored := task.HistoryVersion{Version: version}
+	for _, v := range versions {
+		if v.Version == version {
+			restored = v
+		}
+	}
+	return tms.restoredProjectResult("restore_version", projectName, restored)
+}
+
+// restoredProjectResult reports the version a project was restored to and
+// what the project now holds
+func (tms *TaskManagerServer) restoredProjectResult(operation string, projectName string, restored task.HistoryVersion) (*mcp.CallToolResult, error) {
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+
+	remaining, err := tms.taskManager.ListHistory(projectName)
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":            projectName,
+		"restored_version":   restored,
+		"task_count":         len(project.Tasks),
+		"remaining_versions": len(remaining),
+		"message":            fmt.Sprintf("Restored project '%s' to the version replaced at %s", projectName, restored.ReplacedAt.Format("2006-01-02 15:04:05")),
+	})
+	if err != nil {
+		return tms.createErrorResult(operation, fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultIdempotencyCacheSize bounds how many idempotency keys are remembered
+const defaultIdempotencyCacheSize = 256
+
+// idempotencyCache remembers the results of recently applied mutations so that
+// a retried call carrying the same idempotency key is not applied twice
+type idempotencyCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+	// inFlight serializes calls carrying the same key, so a retry that races
+	// the original request waits for it instead of applying the mutation again
+	inFlight map[string]*keyLock
+}
+
+// keyLock is the lock of one idempotency key, shared by the calls using it
+type keyLock struct {
+	mutex sync.Mutex
+	users int
+}
+
+// idempotencyEntry is a single remembered result
+type idempotencyEntry struct {
+	key    string
+	result *mcp.CallToolResult
+}
+
+// newIdempotencyCache creates a bounded LRU cache of tool results
+func newIdempotencyCache(capacity int) *idempotencyCache {
+	if capacity <= 0 {
+		capacity = defaultIdempotencyCacheSize
+	}
+	return &idempotencyCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		inFlight: make(map[string]*keyLock),
+	}
+}
+
+// lockKey waits until no other call holds key, then holds it until the
+// returned function is called. Calls with different keys don't wait for each other.
+func (c *idempotencyCache) lockKey(key string) func() {
+	c.mutex.Lock()
+	lock, exists := c.inFlight[key]
+	if !exists {
+		lock = &keyLock{}
+		c.inFlight[key] = lock
+	}
+	lock.users++
+	c.mutex.Unlock()
+
+	lock.mutex.Lock()
+	return func() {
+		lock.mutex.Unlock()
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(c.inFlight, key)
+		}
+	}
+}
+
+// get returns the remembered result for a key, if any
+func (c *idempotencyCache) get(key string) (*mcp.CallToolResult, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*idempotencyEntry).result, true
+}
+
+// put remembers a result for a key, evicting the least recently used key when full
+func (c *idempotencyCache) put(key string, result *mcp.CallToolResult) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		element.Value.(*idempotencyEntry).result = result
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&idempotencyEntry{key: key, result: result})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*idempotencyEntry).key)
+	}
+}
+
+// withIdempotency wraps a mutating tool handler so that calls carrying an
+// idempotency_key already seen for this tool return the prior result instead
+// of re-applying the mutation. Failed calls are not remembered so they can be retried.
+func (tms *TaskManagerServer) withIdempotency(toolName string, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key := mcp.ParseString(request, "idempotency_key", "")
+		if key == "" {
+			return handler(ctx, request)
+		}
+
+		cacheKey := toolName + ":" + key
+		unlock := tms.idempotency.lockKey(cacheKey)
+		defer unlock()
+
+		if result, exists := tms.idempotency.get(cacheKey); exists {
+			return result, nil
+		}
+
+		result, err := handler(ctx, request)
+		if err == nil && result != nil && !result.IsError {
+			tms.idempotency.put(cacheKey, result)
+		}
+		return result, err
+	}
+}
+
+// idempotencyKeyOption declares the optional idempotency_key parameter for mutating tools
+func idempotencyKeyOption() mcp.ToolOption {
+	return mcp.WithString("idempotency_key",
+		mcp.Description("Optional client-generated key; retrying with the same key returns the original result instead of applying the change again"),
+	)
+}
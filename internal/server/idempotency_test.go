@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newTestServer returns a server storing its projects in a temporary directory
+func newTestServer(t *testing.T) *TaskManagerServer {
+	t.Helper()
+	t.Setenv("TASKS_DIR", t.TempDir())
+
+	tms, err := NewTaskManagerServer()
+	if err != nil {
+		t.Fatalf("NewTaskManagerServer: %v", err)
+	}
+	return tms
+}
+
+// callTool builds a tool call request with the given arguments
+func callTool(arguments map[string]any) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = arguments
+	return request
+}
+
+func TestWithIdempotencySameKeyAddsOneTask(t *testing.T) {
+	tms := newTestServer(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	handler := tms.withIdempotency("add_task", tms.handleAddTask)
+	request := callTool(map[string]any{
+		"project_name":    "p",
+		"title":           "Write docs",
+		"description":     "Document the API",
+		"idempotency_key": "key-1",
+	})
+
+	// Race two calls with the same key, then retry once more afterwards
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result, err := handler(context.Background(), request); err != nil || result.IsError {
+				t.Errorf("add_task failed: %v %v", err, result)
+			}
+		}()
+	}
+	wg.Wait()
+	if result, err := handler(context.Background(), request); err != nil || result.IsError {
+		t.Fatalf("add_task retry failed: %v %v", err, result)
+	}
+
+	project, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if len(project.Tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(project.Tasks))
+	}
+}
+
+func TestIdempotencyCacheLockKeyIsPerKey(t *testing.T) {
+	cache := newIdempotencyCache(defaultIdempotencyCacheSize)
+
+	unlockA := cache.lockKey("a")
+	// A different key must not wait for "a"
+	cache.lockKey("b")()
+
+	locked := make(chan struct{})
+	go func() {
+		cache.lockKey("a")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("second holder of key a did not wait")
+	default:
+	}
+
+	unlockA()
+	<-locked
+	if len(cache.inFlight) != 0 {
+		t.Fatalf("inFlight not cleaned up: %d entries", len(cache.inFlight))
+	}
+}
@@ -1,9 +1,12 @@
 package server
 
 import (
+	"container/list"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +22,31 @@ type AutoEvaluationConfig struct {
 	MaxConcurrent     int           `json:"max_concurrent"`
 	SkipReadOnlyTools bool          `json:"skip_read_only_tools"`
 	VerboseLogging    bool          `json:"verbose_logging"`
+	MaxCacheEntries   int           `json:"max_cache_entries"`
+	SummaryMode       string        `json:"summary_mode"`
+	// CompletionRules tune when tasks are auto-completed or flagged for attention
+	CompletionRules task.CompletionRules `json:"completion_rules"`
+}
+
+// defaultMaxCacheEntries bounds how many projects' evaluations are cached
+const defaultMaxCacheEntries = 1000
+
+// SummaryMode values control the human-readable summary appended to non-JSON
+// results; the JSON auto_evaluation field is always added
+const (
+	SummaryAlways  = "always"  // always append the summary
+	SummaryChanges = "changes" // only when updates were applied or tasks need attention
+	SummaryNever   = "never"   // never append the summary
+)
+
+// ValidateSummaryMode checks an auto-evaluation summary mode
+func ValidateSummaryMode(mode string) (string, error) {
+	switch mode {
+	case SummaryAlways, SummaryChanges, SummaryNever:
+		return mode, nil
+	default:
+		return "", task.NewError(task.ErrInvalidInput, "invalid summary mode: %s. Valid options: always, changes, never", mode)
+	}
 }
 
 // DefaultAutoEvaluationConfig returns sensible defaults
@@ -29,9 +57,38 @@ func DefaultAutoEvaluationConfig() AutoEvaluationConfig {
 		MaxConcurrent:     3,
 		SkipReadOnlyTools: true,
 		VerboseLogging:    false,
+		MaxCacheEntries:   defaultMaxCacheEntries,
+		SummaryMode:       SummaryAlways,
+		CompletionRules:   task.DefaultCompletionRules(),
 	}
 }
 
+// Validate reports settings the middleware can't run with: a cache timeout
+// that isn't positive makes the cache cleanup ticker panic, and fewer than one
+// concurrent evaluation would leave every evaluation waiting for a free slot
+func (c AutoEvaluationConfig) Validate() error {
+	var errs []error
+	if c.CacheTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("cache timeout must be greater than zero, got %s", c.CacheTimeout))
+	}
+	if c.MaxConcurrent < 1 {
+		errs = append(errs, fmt.Errorf("max concurrent evaluations must be at least 1, got %d", c.MaxConcurrent))
+	}
+	return errors.Join(errs...)
+}
+
+// withDefaults replaces the settings Validate rejects with their defaults
+func (c AutoEvaluationConfig) withDefaults() AutoEvaluationConfig {
+	defaults := DefaultAutoEvaluationConfig()
+	if c.CacheTimeout <= 0 {
+		c.CacheTimeout = defaults.CacheTimeout
+	}
+	if c.MaxConcurrent < 1 {
+		c.MaxConcurrent = defaults.MaxConcurrent
+	}
+	return c
+}
+
 // EvaluationResult contains the results of automatic task evaluation
 type EvaluationResult struct {
 	ProjectName     string                 `json:"project_name"`
@@ -46,18 +103,24 @@ type EvaluationResult struct {
 type AutoEvaluationMiddleware struct {
 	taskManager    *task.Manager
 	config         AutoEvaluationConfig
-	cache          map[string]*EvaluationResult
-	cacheMutex     sync.RWMutex
+	configMutex    sync.RWMutex             // guards config, which configure_auto_evaluation changes at runtime
+	cache          map[string]*list.Element // project name -> *EvaluationResult in cacheOrder
+	cacheOrder     *list.List               // front is most recently used
+	cacheMutex     sync.Mutex
 	semaphore      chan struct{}
 	readOnlyTools  map[string]bool
+	mutatingTools  map[string]bool
 }
 
 // NewAutoEvaluationMiddleware creates a new middleware instance
 func NewAutoEvaluationMiddleware(taskManager *task.Manager, config AutoEvaluationConfig) *AutoEvaluationMiddleware {
+	// LoadServerConfig rejects these settings; a config built in code falls back to the defaults
+	config = config.withDefaults()
 	middleware := &AutoEvaluationMiddleware{
 		taskManager: taskManager,
 		config:      config,
-		cache:       make(map[string]*EvaluationResult),
+		cache:       make(map[string]*list.Element),
+		cacheOrder:  list.New(),
 		semaphore:   make(chan struct{}, config.MaxConcurrent),
 		readOnlyTools: map[string]bool{
 			"get_next_task":                true,
@@ -65,6 +128,74 @@ func NewAutoEvaluationMiddleware(taskManager *task.Manager, config AutoEvaluatio
 			"get_tasks_needing_attention":  true,
 			"suggest_next_actions":         true,
 			"debug_info":                   true,
+			"recent_activity":              true,
+			"validate_project":             true,
+			"context_primer":               true,
+			"get_task_timeline":            true,
+			"project_overview":             true,
+			"watch_project":                true,
+			"unwatch_project":              true,
+			"complexity_breakdown":         true,
+			"export_checklist":             true,
+			"progress_delta":               true,
+			"get_task":                     true,
+			"get_project_as_tree":          true,
+			"all_tasks":                    true,
+			"find_duplicates":              true,
+			"list_projects":                true,
+			"filter_tasks":                 true,
+			"search_tasks":                 true,
+			"list_history":                 true,
+			"export_project":               true,
+			"generate_gantt":               true,
+			"list_templates":               true,
+			"get_category_progress":        true,
+			"list_subtask_templates":       true,
+			// generate_task_file writes source files but never changes task state
+			"generate_task_file":           true,
+		},
+		mutatingTools: map[string]bool{
+			"create_task_file":          true,
+			"add_task":                  true,
+			"update_task_status":        true,
+			"parse_prd":                 true,
+			"expand_task":               true,
+			"estimate_task_complexity":  true,
+			"auto_update_tasks":         true,
+			"bulk_tag":                  true,
+			"renumber_tasks":            true,
+			"configure_auto_evaluation": true,
+			"normalize_titles":          true,
+			"evaluate_all":              true,
+			"record_progress_snapshot":  true,
+			"set_done_criteria":         true,
+			"acknowledge_done_criterion": true,
+			"reconcile_project":         true,
+			"update_from_prd":           true,
+			"delete_project":            true,
+			"archive_project":           true,
+			"rename_task":               true,
+			"add_task_tags":             true,
+			"remove_task_tags":          true,
+			"add_task_dependency":       true,
+			"remove_task_dependency":    true,
+			"bulk_add_tasks":            true,
+			"update_task_statuses":      true,
+			"move_task":                 true,
+			"undo_last_change":          true,
+			"restore_version":           true,
+			"import_project_json":       true,
+			"set_task_priority":         true,
+			"set_task_category":         true,
+			"reorder_tasks":             true,
+			"add_task_comment":          true,
+			"create_choice":             true,
+			"resolve_choice":            true,
+			"set_project_description":   true,
+			"create_project_from_template": true,
+			"assign_task":               true,
+			"unassign_task":             true,
+			"apply_subtask_template":    true,
 		},
 	}
 
@@ -74,16 +205,54 @@ func NewAutoEvaluationMiddleware(taskManager *task.Manager, config AutoEvaluatio
 	return middleware
 }
 
+// IsReadOnly reports whether a tool is classified as read-only
+func (m *AutoEvaluationMiddleware) IsReadOnly(toolName string) bool {
+	return m.readOnlyTools[toolName]
+}
+
+// IsClassified reports whether a tool is explicitly listed as read-only or mutating
+func (m *AutoEvaluationMiddleware) IsClassified(toolName string) bool {
+	return m.readOnlyTools[toolName] || m.mutatingTools[toolName]
+}
+
+// currentConfig returns a copy of the configuration
+func (m *AutoEvaluationMiddleware) currentConfig() AutoEvaluationConfig {
+	m.configMutex.RLock()
+	defer m.configMutex.RUnlock()
+	return m.config
+}
+
+// updateConfig applies update to the configuration and returns the result
+func (m *AutoEvaluationMiddleware) updateConfig(update func(*AutoEvaluationConfig)) AutoEvaluationConfig {
+	m.configMutex.Lock()
+	defer m.configMutex.Unlock()
+	update(&m.config)
+	return m.config
+}
+
+// UnclassifiedTools returns the tool names that are neither read-only nor mutating
+func (m *AutoEvaluationMiddleware) UnclassifiedTools(toolNames []string) []string {
+	var unclassified []string
+	for _, name := range toolNames {
+		if !m.IsClassified(name) {
+			unclassified = append(unclassified, name)
+		}
+	}
+	return unclassified
+}
+
 // WrapHandler wraps a tool handler with automatic evaluation
 func (m *AutoEvaluationMiddleware) WrapHandler(toolName string, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		config := m.currentConfig()
+
 		// Skip evaluation if disabled
-		if !m.config.Enabled {
+		if !config.Enabled {
 			return handler(ctx, request)
 		}
 
 		// Skip evaluation for read-only tools if configured
-		if m.config.SkipReadOnlyTools && m.readOnlyTools[toolName] {
+		if config.SkipReadOnlyTools && m.readOnlyTools[toolName] {
 			return handler(ctx, request)
 		}
 
@@ -96,9 +265,9 @@ func (m *AutoEvaluationMiddleware) WrapHandler(toolName string, handler func(con
 
 		// Perform automatic evaluation
 		evaluationResult, err := m.evaluateProject(ctx, projectName)
-		if err != nil && m.config.VerboseLogging {
+		if err != nil && config.VerboseLogging {
 			// Log error but don't fail the original request
-			fmt.Printf("Auto-evaluation failed for project %s: %v\n", projectName, err)
+			fmt.Fprintf(os.Stderr, "Auto-evaluation failed for project %s: %v\n", projectName, err)
 		}
 
 		// Execute the original handler
@@ -138,6 +307,33 @@ func (m *AutoEvaluationMiddleware) evaluateProject(ctx context.Context, projectN
 		return cached, nil
 	}
 
+	return m.runEvaluation(ctx, projectName, false)
+}
+
+// EvaluateAll evaluates every given project concurrently, bounded by the
+// evaluation semaphore, bypassing the cache. With dryRun set, status updates
+// are computed but not saved. Results are returned in input order; a project
+// that fails to evaluate has a nil result and its error at the same index.
+func (m *AutoEvaluationMiddleware) EvaluateAll(ctx context.Context, projectNames []string, dryRun bool) ([]*EvaluationResult, []error) {
+	results := make([]*EvaluationResult, len(projectNames))
+	errs := make([]error, len(projectNames))
+
+	var wg sync.WaitGroup
+	for i, projectName := range projectNames {
+		wg.Add(1)
+		go func(i int, projectName string) {
+			defer wg.Done()
+			results[i], errs[i] = m.runEvaluation(ctx, projectName, dryRun)
+		}(i, projectName)
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// runEvaluation applies automatic status updates to a project (saving them
+// unless dryRun is set) and collects tasks needing attention
+func (m *AutoEvaluationMiddleware) runEvaluation(ctx context.Context, projectName string, dryRun bool) (*EvaluationResult, error) {
 	// Acquire semaphore to limit concurrent evaluations
 	select {
 	case m.semaphore <- struct{}{}:
@@ -153,24 +349,25 @@ func (m *AutoEvaluationMiddleware) evaluateProject(ctx context.Context, projectN
 		return nil, fmt.Errorf("project %s does not exist", projectName)
 	}
 
-	// Load project
-	project, err := m.taskManager.LoadProject(projectName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load project %s: %w", projectName, err)
-	}
-
-	// Perform automatic updates
-	updates, hasChanges := task.AutoUpdateTaskStatuses(project)
-	
-	// Save project if changes were made
-	if hasChanges {
-		if err := m.taskManager.SaveProject(project); err != nil {
-			return nil, fmt.Errorf("failed to save project updates: %w", err)
+	// Perform automatic updates, saving the project if changes were made
+	rules := m.currentConfig().CompletionRules
+	var project *task.Project
+	var updates []string
+	err := m.taskManager.UpdateProject(projectName, func(loaded *task.Project) error {
+		project = loaded
+		var hasChanges bool
+		updates, hasChanges = task.AutoUpdateTaskStatuses(project, rules)
+		if !hasChanges || dryRun {
+			return task.SkipSave
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate project %s: %w", projectName, err)
 	}
 
 	// Get tasks needing attention
-	attentionItems := task.GetTasksNeedingAttention(project)
+	attentionItems := task.GetTasksNeedingAttention(project, rules)
 
 	// Create evaluation result
 	result := &EvaluationResult{
@@ -182,19 +379,23 @@ func (m *AutoEvaluationMiddleware) evaluateProject(ctx context.Context, projectN
 		CacheHit:       false,
 	}
 
-	// Cache the result
-	m.cacheResult(projectName, result)
+	// Cache the result; a dry run doesn't reflect the saved state
+	if !dryRun {
+		m.cacheResult(projectName, result)
+	}
 
 	return result, nil
 }
 
 // getCachedResult retrieves cached evaluation result if still valid
 func (m *AutoEvaluationMiddleware) getCachedResult(projectName string) *EvaluationResult {
-	m.cacheMutex.RLock()
-	defer m.cacheMutex.RUnlock()
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
 
-	if cached, exists := m.cache[projectName]; exists {
-		if time.Since(cached.EvaluationTime) < m.config.CacheTimeout {
+	if element, exists := m.cache[projectName]; exists {
+		cached := element.Value.(*EvaluationResult)
+		if time.Since(cached.EvaluationTime) < m.currentConfig().CacheTimeout {
+			m.cacheOrder.MoveToFront(element)
 			// Mark as cache hit
 			cachedCopy := *cached
 			cachedCopy.CacheHit = true
@@ -205,24 +406,46 @@ func (m *AutoEvaluationMiddleware) getCachedResult(projectName string) *Evaluati
 	return nil
 }
 
-// cacheResult stores evaluation result in cache
+// cacheResult stores evaluation result in cache, evicting the least recently
+// used project when the cache holds more than MaxCacheEntries
 func (m *AutoEvaluationMiddleware) cacheResult(projectName string, result *EvaluationResult) {
 	m.cacheMutex.Lock()
 	defer m.cacheMutex.Unlock()
-	m.cache[projectName] = result
+
+	if element, exists := m.cache[projectName]; exists {
+		element.Value = result
+		m.cacheOrder.MoveToFront(element)
+	} else {
+		m.cache[projectName] = m.cacheOrder.PushFront(result)
+	}
+
+	maxEntries := m.currentConfig().MaxCacheEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxCacheEntries
+	}
+	for m.cacheOrder.Len() > maxEntries {
+		m.removeCacheElement(m.cacheOrder.Back())
+	}
+}
+
+// removeCacheElement drops a cache entry. Caller must hold cacheMutex.
+func (m *AutoEvaluationMiddleware) removeCacheElement(element *list.Element) {
+	m.cacheOrder.Remove(element)
+	delete(m.cache, element.Value.(*EvaluationResult).ProjectName)
 }
 
 // cleanupCache periodically removes expired cache entries
 func (m *AutoEvaluationMiddleware) cleanupCache() {
-	ticker := time.NewTicker(m.config.CacheTimeout)
+	ticker := time.NewTicker(m.currentConfig().CacheTimeout)
 	defer ticker.Stop()
 
 	for range ticker.C {
+		cacheTimeout := m.currentConfig().CacheTimeout
 		m.cacheMutex.Lock()
 		now := time.Now()
-		for projectName, result := range m.cache {
-			if now.Sub(result.EvaluationTime) > m.config.CacheTimeout {
-				delete(m.cache, projectName)
+		for _, element := range m.cache {
+			if now.Sub(element.Value.(*EvaluationResult).EvaluationTime) > cacheTimeout {
+				m.removeCacheElement(element)
 			}
 		}
 		m.cacheMutex.Unlock()
@@ -237,9 +460,9 @@ func (m *AutoEvaluationMiddleware) enhanceResultWithEvaluation(originalResult *m
 
 	// Try to parse existing content as JSON and enhance it
 	for i, content := range originalResult.Content {
-		if content.Type == "text" && content.Text != nil {
+		if textContent, ok := mcp.AsTextContent(content); ok {
 			var resultData map[string]interface{}
-			if err := json.Unmarshal([]byte(*content.Text), &resultData); err == nil {
+			if err := json.Unmarshal([]byte(textContent.Text), &resultData); err == nil {
 				// Successfully parsed as JSON, enhance it
 				resultData["auto_evaluation"] = map[string]interface{}{
 					"project_name":     evaluation.ProjectName,
@@ -252,27 +475,18 @@ func (m *AutoEvaluationMiddleware) enhanceResultWithEvaluation(originalResult *m
 
 				// Include attention items if any
 				if len(evaluation.AttentionItems) > 0 {
-					attentionSummary := make([]map[string]interface{}, len(evaluation.AttentionItems))
-					for j, item := range evaluation.AttentionItems {
-						attentionSummary[j] = map[string]interface{}{
-							"task_title": item.Task.Title,
-							"reason":     item.Reason,
-							"type":       string(item.Type),
-						}
-					}
-					resultData["auto_evaluation"].(map[string]interface{})["attention_items"] = attentionSummary
+					resultData["auto_evaluation"].(map[string]interface{})["attention_items"] = summarizeAttention(evaluation.AttentionItems)
 				}
 
 				// Convert back to JSON
 				if enhancedJSON, err := json.Marshal(resultData); err == nil {
-					enhancedText := string(enhancedJSON)
-					originalResult.Content[i].Text = &enhancedText
+					originalResult.Content[i] = mcp.NewTextContent(string(enhancedJSON))
 				}
-			} else {
+			} else if m.shouldAppendSummary(evaluation) {
 				// Not JSON, append evaluation summary as text
 				evaluationSummary := m.formatEvaluationSummary(evaluation)
-				enhancedText := *content.Text + "\n\n" + evaluationSummary
-				originalResult.Content[i].Text = &enhancedText
+				enhancedText := textContent.Text + "\n\n" + evaluationSummary
+				originalResult.Content[i] = mcp.NewTextContent(enhancedText)
 			}
 		}
 	}
@@ -280,6 +494,31 @@ func (m *AutoEvaluationMiddleware) enhanceResultWithEvaluation(originalResult *m
 	return originalResult
 }
 
+// shouldAppendSummary reports whether the summary mode calls for a text summary of evaluation
+func (m *AutoEvaluationMiddleware) shouldAppendSummary(evaluation *EvaluationResult) bool {
+	switch m.currentConfig().SummaryMode {
+	case SummaryNever:
+		return false
+	case SummaryChanges:
+		return len(evaluation.UpdatesApplied) > 0 || len(evaluation.AttentionItems) > 0
+	default:
+		return true
+	}
+}
+
+// summarizeAttention reduces attention items to the fields reported to clients
+func summarizeAttention(items []task.TaskAttention) []map[string]interface{} {
+	summary := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		summary[i] = map[string]interface{}{
+			"task_title": item.Task.Title,
+			"reason":     item.Reason,
+			"type":       string(item.Type),
+		}
+	}
+	return summary
+}
+
 // formatEvaluationSummary creates a human-readable evaluation summary
 func (m *AutoEvaluationMiddleware) formatEvaluationSummary(evaluation *EvaluationResult) string {
 	var summary strings.Builder
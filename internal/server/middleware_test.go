@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+func TestRegisterToolsRejectsUnclassifiedTool(t *testing.T) {
+	tms := newTestServer(t)
+
+	// Forget add_task's classification and register the tools again
+	delete(tms.autoEvalMiddleware.mutatingTools, "add_task")
+	tms.registeredTools = nil
+
+	err := tms.registerTools()
+	if err == nil {
+		t.Fatal("registerTools succeeded with an unclassified tool")
+	}
+	if !strings.Contains(err.Error(), "add_task") {
+		t.Fatalf("error %q does not name the unclassified tool", err)
+	}
+}
+
+func TestEveryRegisteredToolIsClassified(t *testing.T) {
+	tms := newTestServer(t)
+
+	if unclassified := tms.autoEvalMiddleware.UnclassifiedTools(tms.registeredTools); len(unclassified) > 0 {
+		t.Fatalf("unclassified tools: %s", strings.Join(unclassified, ", "))
+	}
+	for name := range tms.autoEvalMiddleware.readOnlyTools {
+		if tms.autoEvalMiddleware.mutatingTools[name] {
+			t.Errorf("tool %s is classified as both read-only and mutating", name)
+		}
+	}
+}
+
+// projectToolsWithAutoEvaluation are the project tools that used to be
+// registered without the auto-evaluation middleware
+var projectToolsWithAutoEvaluation = []string{
+	"create_task_file",
+	"parse_prd",
+	"expand_task",
+	"generate_task_file",
+	"get_task_dependencies",
+	"estimate_task_complexity",
+}
+
+// handleMessage sends a JSON-RPC request to the server's MCP handler and
+// returns the JSON response
+func handleMessage(t *testing.T, tms *TaskManagerServer, method string, params any) string {
+	t.Helper()
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	response, err := json.Marshal(tms.mcpServer.HandleMessage(context.Background(), message))
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	return string(response)
+}
+
+func TestProjectToolsRunAutoEvaluation(t *testing.T) {
+	// Read-only tools skip evaluation by default; evaluate every call here
+	t.Setenv("AUTO_EVAL_SKIP_READ_ONLY", "false")
+	tms := newTestServer(t)
+
+	for _, tool := range projectToolsWithAutoEvaluation {
+		t.Run(tool, func(t *testing.T) {
+			// A task whose subtasks are all done is completed by auto-evaluation
+			// before the tool runs, whatever the tool itself then does
+			if err := tms.taskManager.CreateProject(tool); err != nil {
+				t.Fatalf("CreateProject: %v", err)
+			}
+			ready := task.Task{Title: "Ready", Description: "All steps done", Status: task.StatusInProgress,
+				Subtasks: []task.Subtask{{Title: "Step", Status: task.StatusDone}}}
+			if err := tms.taskManager.AddTasks(tool, []task.Task{ready}); err != nil {
+				t.Fatalf("AddTasks: %v", err)
+			}
+
+			handleMessage(t, tms, "tools/call", map[string]any{
+				"name":      tool,
+				"arguments": map[string]any{"project_name": tool, "task_title": "Missing"},
+			})
+
+			project, err := tms.taskManager.LoadProject(tool)
+			if err != nil {
+				t.Fatalf("LoadProject: %v", err)
+			}
+			if status := project.Tasks[0].Status; status != task.StatusDone {
+				t.Errorf("task status after %s = %s, want done from auto-evaluation", tool, status)
+			}
+		})
+	}
+}
+
+func TestProjectToolsGatedInReadOnlyMode(t *testing.T) {
+	t.Setenv("READ_ONLY", "true")
+	tms := newTestServer(t)
+
+	listed := handleMessage(t, tms, "tools/list", map[string]any{})
+	for _, tool := range projectToolsWithAutoEvaluation {
+		exposed := strings.Contains(listed, `"name":"`+tool+`"`)
+		if want := tms.allowedInReadOnlyMode(tool); exposed != want {
+			t.Errorf("%s listed in read-only mode = %v, want %v", tool, exposed, want)
+		}
+		if !exposed && !slices.Contains(tms.disabledTools, tool) {
+			t.Errorf("%s is hidden but not reported as disabled", tool)
+		}
+	}
+
+	// Only get_task_dependencies neither changes tasks nor writes files
+	if !strings.Contains(listed, `"name":"get_task_dependencies"`) {
+		t.Error("get_task_dependencies is not available in read-only mode")
+	}
+	if strings.Contains(listed, `"name":"parse_prd"`) {
+		t.Error("parse_prd is available in read-only mode")
+	}
+}
+
+func TestEvaluationCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	config := DefaultAutoEvaluationConfig()
+	config.MaxCacheEntries = 2
+	m := NewAutoEvaluationMiddleware(nil, config)
+
+	cache := func(projectName string) {
+		m.cacheResult(projectName, &EvaluationResult{ProjectName: projectName, EvaluationTime: time.Now()})
+	}
+	cached := func(projectName string) bool {
+		result := m.getCachedResult(projectName)
+		if result != nil && (!result.CacheHit || result.ProjectName != projectName) {
+			t.Errorf("cached result for %s = %+v", projectName, result)
+		}
+		return result != nil
+	}
+
+	cache("a")
+	cache("b")
+	// Reading a makes b the least recently used
+	if !cached("a") {
+		t.Fatal("a was not cached")
+	}
+	cache("c")
+	if cached("b") || !cached("a") || !cached("c") {
+		t.Errorf("after caching c: a=%v b=%v c=%v, want b evicted", cached("a"), cached("b"), cached("c"))
+	}
+
+	// Re-caching an existing project doesn't take another slot
+	cache("a")
+	if len(m.cache) != 2 || m.cacheOrder.Len() != 2 {
+		t.Errorf("cache holds %d entries (%d in order), want 2", len(m.cache), m.cacheOrder.Len())
+	}
+
+	// Expired results are not served
+	m.cacheResult("old", &EvaluationResult{ProjectName: "old", EvaluationTime: time.Now().Add(-2 * config.CacheTimeout)})
+	if cached("old") {
+		t.Error("an expired result was served from the cache")
+	}
+}
+
+func TestEvaluationSummaryMode(t *testing.T) {
+	quiet := &EvaluationResult{ProjectName: "p", UpdatesApplied: []string{}, AttentionItems: []task.TaskAttention{}}
+	updated := &EvaluationResult{ProjectName: "p", UpdatesApplied: []string{"Auto-completed task 'Build'"}}
+	attention := &EvaluationResult{ProjectName: "p", AttentionItems: []task.TaskAttention{
+		{Task: &task.Task{Title: "Deploy"}, Reason: "stale", Type: task.AttentionTypeStale},
+	}}
+
+	tests := []struct {
+		mode        string
+		evaluation  *EvaluationResult
+		wantSummary bool
+	}{
+		{SummaryAlways, quiet, true},
+		{SummaryAlways, updated, true},
+		{SummaryChanges, quiet, false},
+		{SummaryChanges, updated, true},
+		{SummaryChanges, attention, true},
+		{SummaryNever, updated, false},
+		{SummaryNever, attention, false},
+	}
+	for _, tt := range tests {
+		config := DefaultAutoEvaluationConfig()
+		config.SummaryMode = tt.mode
+		m := NewAutoEvaluationMiddleware(nil, config)
+
+		text := m.enhanceResultWithEvaluation(mcp.NewToolResultText("Task added"), tt.evaluation).Content[0].(mcp.TextContent).Text
+		if hasSummary := strings.Contains(text, "Auto-Evaluation Summary"); hasSummary != tt.wantSummary {
+			t.Errorf("%s mode, %d updates, %d attention items: summary = %v, want %v\n%s",
+				tt.mode, len(tt.evaluation.UpdatesApplied), len(tt.evaluation.AttentionItems), hasSummary, tt.wantSummary, text)
+		}
+		if !strings.HasPrefix(text, "Task added") {
+			t.Errorf("%s mode: original text lost: %q", tt.mode, text)
+		}
+
+		// JSON results always get the structured evaluation
+		enhanced := m.enhanceResultWithEvaluation(mcp.NewToolResultText(`{"added":true}`), tt.evaluation).Content[0].(mcp.TextContent).Text
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(enhanced), &payload); err != nil || payload["auto_evaluation"] == nil || payload["added"] != true {
+			t.Errorf("%s mode: JSON result = %s (%v), want auto_evaluation added", tt.mode, enhanced, err)
+		}
+	}
+
+	if _, err := ValidateSummaryMode("sometimes"); err == nil {
+		t.Error("ValidateSummaryMode accepted an unknown mode")
+	}
+}
+
+func TestConfigureAutoEvaluationRejectsNonPositiveLimits(t *testing.T) {
+	tms := newTestServer(t)
+	before := tms.autoEvalMiddleware.currentConfig()
+
+	for _, arguments := range []map[string]any{
+		{"max_concurrent": 0.0},
+		{"max_concurrent": -1.0},
+		{"cache_timeout": "0s"},
+		{"cache_timeout": "-5m"},
+		{"enabled": !before.Enabled, "max_concurrent": 0.0},
+	} {
+		r, err := tms.handleConfigureAutoEvaluation(context.Background(), callTool(arguments))
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%v: category = %q, want %q", arguments, category, ErrorCategoryValidation)
+		}
+	}
+	if after := tms.autoEvalMiddleware.currentConfig(); after.MaxConcurrent != before.MaxConcurrent || after.CacheTimeout != before.CacheTimeout || after.Enabled != before.Enabled {
+		t.Errorf("rejected values were applied: timeout %s, %d concurrent, enabled %v", after.CacheTimeout, after.MaxConcurrent, after.Enabled)
+	}
+
+	r, err := tms.handleConfigureAutoEvaluation(context.Background(), callTool(map[string]any{"max_concurrent": 2.0, "cache_timeout": "90s"}))
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("configure_auto_evaluation: %s", text)
+	}
+	if config := tms.autoEvalMiddleware.currentConfig(); config.MaxConcurrent != 2 || config.CacheTimeout != 90*time.Second {
+		t.Errorf("config = timeout %s, %d concurrent; want 90s, 2", config.CacheTimeout, config.MaxConcurrent)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleMoveTask handles the move_task tool
+func (tms *TaskManagerServer) handleMoveTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fromProject, err := request.RequireString("from_project")
+	if err != nil {
+		return tms.createErrorResult("move_task", task.NewError(task.ErrInvalidInput, "missing from_project: %w", err)), nil
+	}
+
+	toProject, err := request.RequireString("to_project")
+	if err != nil {
+		return tms.createErrorResult("move_task", task.NewError(task.ErrInvalidInput, "missing to_project: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("move_task", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	source, err := tms.safeLoadProject(fromProject)
+	if err != nil {
+		return tms.createErrorResult("move_task", err), nil
+	}
+	if _, err := tms.safeLoadProject(toProject); err != nil {
+		return tms.createErrorResult("move_task", err), nil
+	}
+
+	targetTask, _, err := tms.resolveTaskTitle(source, taskTitle, tms.parseBooleanField(request, "partial_match", false))
+	if err != nil {
+		return tms.createErrorResult("move_task", err), nil
+	}
+
+	move, err := tms.taskManager.MoveTask(fromProject, toProject, targetTask.Title)
+	if err != nil {
+		return tms.createErrorResult("move_task", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"move": move,
+		"message": fmt.Sprintf("Moved task '%s' from project '%s' to '%s' as task %d",
+			move.Title, move.FromProject, move.ToProject, move.NewID),
+	})
+	if err != nil {
+		return tms.createErrorResult("move_task", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleProjectOverview handles the project_overview tool
+func (tms *TaskManagerServer) handleProjectOverview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("project_overview", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.loadProjectForRead(request, projectName)
+	if err != nil {
+		return tms.createErrorResult("project_overview", err), nil
+	}
+
+	result := tms.buildProjectOverview(project)
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("project_overview", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// buildProjectOverview assembles the progress figures reported by project_overview
+func (tms *TaskManagerServer) buildProjectOverview(project *task.Project) map[string]interface{} {
+	statusCounts := map[task.TaskStatus]int{
+		task.StatusTodo:       0,
+		task.StatusInProgress: 0,
+		task.StatusDone:       0,
+		task.StatusBlocked:    0,
+	}
+	priorityCounts := map[task.TaskPriority]int{}
+	for _, t := range project.Tasks {
+		statusCounts[t.Status]++
+		priorityCounts[t.Priority]++
+	}
+
+	weights := tms.config.GetPriorityWeights()
+
+	overview := map[string]interface{}{
+		"project":           project.Name,
+		"description":       project.Description,
+		"progress":          project.GetProgressSummary(),
+		"weighted_progress": project.GetWeightedProgress(weights),
+		"priority_weights":  weights,
+		"status_counts":     statusCounts,
+		"priority_counts":   priorityCounts,
+	}
+
+	return overview
+}
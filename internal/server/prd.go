@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// maxPRDSubtasks matches the subtask limit of add_task
+const maxPRDSubtasks = 50
+
+// validatePRDTasks validates the titles of tasks extracted from a PRD. Tasks
+// with invalid or repeated titles are returned as skipped entries with the
+// reason; invalid subtasks are dropped.
+func validatePRDTasks(extracted []task.Task) ([]task.Task, []map[string]string) {
+	var valid []task.Task
+	skipped := []map[string]string{}
+	seen := make(map[string]bool)
+
+	for _, extractedTask := range extracted {
+		title, err := task.ValidateTaskTitle(extractedTask.Title)
+		if err != nil {
+			skipped = append(skipped, map[string]string{"title": extractedTask.Title, "reason": err.Error()})
+			continue
+		}
+		if seen[title] {
+			skipped = append(skipped, map[string]string{"title": title, "reason": "the PRD lists this title more than once"})
+			continue
+		}
+		seen[title] = true
+		extractedTask.Title = title
+
+		var subtasks []task.Subtask
+		for _, subtask := range extractedTask.Subtasks {
+			if subtask.Title, err = task.ValidateTaskTitle(subtask.Title); err == nil {
+				subtasks = append(subtasks, subtask)
+			}
+		}
+		if len(subtasks) > maxPRDSubtasks {
+			subtasks = subtasks[:maxPRDSubtasks]
+		}
+		extractedTask.Subtasks = subtasks
+
+		valid = append(valid, extractedTask)
+	}
+
+	return valid, skipped
+}
+
+// handleUpdateFromPRD handles the update_from_prd tool
+func (tms *TaskManagerServer) handleUpdateFromPRD(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("update_from_prd", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	prdContent, err := request.RequireString("prd_content")
+	if err != nil {
+		return tms.createErrorResult("update_from_prd", task.NewError(task.ErrInvalidInput, "missing prd_content: %w", err)), nil
+	}
+
+	prune := tms.parseBooleanField(request, "prune", false)
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	extracted := task.ParsePRD(prdContent)
+	if len(extracted) == 0 {
+		return tms.createErrorResult("update_from_prd", task.NewError(task.ErrInvalidInput, "no tasks found in PRD: use # or ## headings or bulleted requirements")), nil
+	}
+	prdTasks, skipped := validatePRDTasks(extracted)
+
+	var changes task.PRDChanges
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		changes = project.ApplyPRD(prdTasks, prune)
+		if dryRun || !changes.HasChanges() {
+			return task.SkipSave
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("update_from_prd", err), nil
+	}
+	saved := !dryRun && changes.HasChanges()
+
+	result := map[string]interface{}{
+		"project":   projectName,
+		"dry_run":   dryRun,
+		"prune":     prune,
+		"added":     changes.Added,
+		"updated":   changes.Updated,
+		"removed":   changes.Removed,
+		"unchanged": changes.Unchanged,
+		"skipped":   skipped,
+		"saved":     saved,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("update_from_prd", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
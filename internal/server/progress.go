@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// parseTimeField parses an optional RFC3339 timestamp or YYYY-MM-DD date
+// parameter; dates mean midnight UTC. It returns the zero time when absent.
+func parseTimeField(request mcp.CallToolRequest, name string) (time.Time, error) {
+	value := mcp.ParseString(request, name, "")
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, task.NewError(task.ErrInvalidInput, "invalid %s %q: use an RFC3339 timestamp or YYYY-MM-DD", name, value)
+}
+
+// handleRecordProgressSnapshot handles the record_progress_snapshot tool
+func (tms *TaskManagerServer) handleRecordProgressSnapshot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("record_progress_snapshot", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	snapshot, err := tms.taskManager.RecordSnapshot(projectName)
+	if err != nil {
+		return tms.createErrorResult("record_progress_snapshot", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":    projectName,
+		"time":       snapshot.Time.Format(time.RFC3339),
+		"task_count": len(snapshot.Tasks),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("record_progress_snapshot", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleProgressDelta handles the progress_delta tool
+func (tms *TaskManagerServer) handleProgressDelta(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("progress_delta", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+	if _, err := request.RequireString("since"); err != nil {
+		return tms.createErrorResult("progress_delta", task.NewError(task.ErrInvalidInput, "missing since: %w", err)), nil
+	}
+
+	since, err := parseTimeField(request, "since")
+	if err != nil {
+		return tms.createErrorResult("progress_delta", err), nil
+	}
+	until, err := parseTimeField(request, "until")
+	if err != nil {
+		return tms.createErrorResult("progress_delta", err), nil
+	}
+	if !until.IsZero() && until.Before(since) {
+		return tms.createErrorResult("progress_delta", task.NewError(task.ErrInvalidInput, "until must not be before since")), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("progress_delta", err), nil
+	}
+
+	snapshots, err := tms.taskManager.LoadSnapshots(projectName)
+	if err != nil {
+		return tms.createErrorResult("progress_delta", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":             projectName,
+		"snapshots_available": len(snapshots),
+	}
+
+	if len(snapshots) == 0 {
+		result["note"] = "No progress snapshots recorded for this project yet; use record_progress_snapshot to start tracking progress over time"
+		return tms.marshalProgressDelta(result)
+	}
+
+	// Baseline is the state at the start of the window. Without a snapshot that
+	// old, fall back to the earliest one and say so.
+	from, found := task.SnapshotAt(snapshots, since)
+	if !found {
+		from = snapshots[0]
+		result["note"] = fmt.Sprintf("No snapshot at or before %s; using the earliest snapshot from %s as the baseline",
+			since.Format(time.RFC3339), from.Time.Format(time.RFC3339))
+	}
+
+	// End of the window is the current state unless until was given
+	to := task.NewProgressSnapshot(project, time.Now().UTC())
+	if !until.IsZero() {
+		to, _ = task.SnapshotAt(snapshots, until)
+		if to.Time.Before(from.Time) {
+			to = from
+		}
+	}
+
+	delta := task.DiffSnapshots(from, to)
+	result["delta"] = delta
+	result["summary"] = fmt.Sprintf("%d completed, %d added, %d removed, %d status changes",
+		len(delta.Completed), len(delta.Added), len(delta.Removed), len(delta.StatusChanged))
+
+	return tms.marshalProgressDelta(result)
+}
+
+// marshalProgressDelta encodes a progress_delta result
+func (tms *TaskManagerServer) marshalProgressDelta(result map[string]interface{}) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("progress_delta", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// listProjects lists project names, leaving out projects without tasks when excludeEmpty is set
+func (tms *TaskManagerServer) listProjects(excludeEmpty bool) ([]string, error) {
+	if excludeEmpty {
+		return tms.taskManager.ListNonEmptyProjects()
+	}
+	return tms.taskManager.ListProjects()
+}
+
+// isExcludedEmptyProject reports whether a project is hidden from listings and
+// auto-detection because it has no tasks and ExcludeEmptyProjects is set
+func (tms *TaskManagerServer) isExcludedEmptyProject(projectName string) bool {
+	if !tms.config.ExcludeEmptyProjects {
+		return false
+	}
+	project, err := tms.taskManager.LoadProject(projectName)
+	return err == nil && len(project.Tasks) == 0
+}
+
+// handleListProjects handles the list_projects tool
+func (tms *TaskManagerServer) handleListProjects(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	excludeEmpty := tms.parseBooleanField(request, "exclude_empty", tms.config.ExcludeEmptyProjects)
+	includeTasks := tms.parseBooleanField(request, "include_tasks", false)
+
+	projectNames, err := tms.taskManager.ListProjects()
+	if err != nil {
+		return tms.createErrorResult("list_projects", err), nil
+	}
+
+	// A project that fails to load is reported rather than failing the whole listing
+	projects := make([]task.ProjectSummary, 0, len(projectNames))
+	unreadable := map[string]string{}
+	for _, projectName := range projectNames {
+		project, err := tms.taskManager.LoadProject(projectName)
+		if err != nil {
+			unreadable[projectName] = err.Error()
+			continue
+		}
+		if excludeEmpty && len(project.Tasks) == 0 {
+			continue
+		}
+		projects = append(projects, project.ToSummary(includeTasks))
+	}
+
+	result := map[string]interface{}{
+		"projects":      projects,
+		"count":         len(projects),
+		"exclude_empty": excludeEmpty,
+	}
+	if len(unreadable) > 0 {
+		result["unreadable_projects"] = unreadable
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("list_projects", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleDeleteProject handles the delete_project tool
+func (tms *TaskManagerServer) handleDeleteProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("delete_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if !tms.parseBooleanField(request, "confirm", false) {
+		return tms.createErrorResult("delete_project", task.NewError(task.ErrInvalidInput,
+			"deleting project '%s' is permanent: pass confirm=true to delete it, or use archive_project to keep a copy", projectName)), nil
+	}
+
+	if err := tms.taskManager.DeleteProject(projectName); err != nil {
+		return tms.createErrorResult("delete_project", err), nil
+	}
+	tms.allTasks.invalidate(task.Project{Name: projectName})
+
+	result := map[string]interface{}{
+		"project": projectName,
+		"deleted": true,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("delete_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleArchiveProject handles the archive_project tool
+func (tms *TaskManagerServer) handleArchiveProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("archive_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	archivePath, err := tms.taskManager.ArchiveProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("archive_project", err), nil
+	}
+	tms.allTasks.invalidate(task.Project{Name: projectName})
+
+	result := map[string]interface{}{
+		"project":      projectName,
+		"archived":     true,
+		"archive_path": archivePath,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("archive_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleSetProjectDescription handles the set_project_description tool
+func (tms *TaskManagerServer) handleSetProjectDescription(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("set_project_description", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	description, err := request.RequireString("description")
+	if err != nil {
+		return tms.createErrorResult("set_project_description", task.NewError(task.ErrInvalidInput, "missing description: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("set_project_description", err), nil
+	}
+	previous := project.Description
+
+	if err := tms.taskManager.SetProjectDescription(projectName, description); err != nil {
+		return tms.createErrorResult("set_project_description", err), nil
+	}
+
+	description, _ = task.ValidateProjectDescription(description)
+	result := map[string]interface{}{
+		"project":              projectName,
+		"description":          description,
+		"previous_description": previous,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("set_project_description", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
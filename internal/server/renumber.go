@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-task-manager-go/internal/task"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleRenumberTasks handles the renumber_tasks tool
+func (tms *TaskManagerServer) handleRenumberTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("renumber_tasks", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	var project *task.Project
+	var changes []task.TaskIDChange
+	err = tms.updateProject(projectName, func(loaded *task.Project) error {
+		project = loaded
+		changes = project.RenumberTasks()
+		if dryRun || len(changes) == 0 {
+			return task.SkipSave
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("renumber_tasks", err), nil
+	}
+	saved := !dryRun && len(changes) > 0
+
+	result := map[string]interface{}{
+		"project":       projectName,
+		"dry_run":       dryRun,
+		"task_count":    len(project.Tasks),
+		"changed_count": len(changes),
+		"changes":       changes,
+		"saved":         saved,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("renumber_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleReorderTasks handles the reorder_tasks tool
+func (tms *TaskManagerServer) handleReorderTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("reorder_tasks", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	rawOrder, ok := request.GetArguments()["order"].([]interface{})
+	if !ok || len(rawOrder) == 0 {
+		return tms.createErrorResult("reorder_tasks", task.NewError(task.ErrInvalidInput, "order must be a non-empty array of task IDs or titles")), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("reorder_tasks", err), nil
+	}
+
+	// Entries are task IDs or exact task titles
+	order := make([]int, len(rawOrder))
+	for i, entry := range rawOrder {
+		switch value := entry.(type) {
+		case float64:
+			if value != float64(int(value)) {
+				return tms.createErrorResult("reorder_tasks", task.NewError(task.ErrInvalidInput, "order entry %d: task ID must be a whole number (got %g)", i+1, value)), nil
+			}
+			order[i] = int(value)
+		case string:
+			target, _, err := task.ResolveTaskTitle(project, value, false)
+			if err != nil {
+				return tms.createErrorResult("reorder_tasks", fmt.Errorf("order entry %d: %w", i+1, err)), nil
+			}
+			order[i] = target.ID
+		default:
+			return tms.createErrorResult("reorder_tasks", task.NewError(task.ErrInvalidInput, "order entry %d must be a task ID or title", i+1)), nil
+		}
+	}
+
+	if err := tms.taskManager.ReorderTasks(projectName, order); err != nil {
+		return tms.createErrorResult("reorder_tasks", err), nil
+	}
+
+	project, err = tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("reorder_tasks", err), nil
+	}
+
+	tasks := make([]map[string]interface{}, len(project.Tasks))
+	for i, t := range project.Tasks {
+		tasks[i] = map[string]interface{}{
+			"id":    t.ID,
+			"title": t.Title,
+		}
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project": projectName,
+		"tasks":   tasks,
+	})
+	if err != nil {
+		return tms.createErrorResult("reorder_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// SuggestionWeights are the points suggest_next_actions adds to a task's score
+// for each property; negative values are penalties
+type SuggestionWeights struct {
+	PriorityP0     int `json:"priority_p0"`
+	PriorityP1     int `json:"priority_p1"`
+	PriorityP2     int `json:"priority_p2"`
+	PriorityP3     int `json:"priority_p3"`
+	Ready          int `json:"ready"`
+	Blocked        int `json:"blocked"`
+	InProgress     int `json:"in_progress"`
+	PendingChoices int `json:"pending_choices"`
+	HighComplexity int `json:"high_complexity"`
+	HasSubtasks    int `json:"has_subtasks"`
+}
+
+// DefaultSuggestionWeights returns the built-in scoring weights
+func DefaultSuggestionWeights() SuggestionWeights {
+	return SuggestionWeights{
+		PriorityP0:     100,
+		PriorityP1:     75,
+		PriorityP2:     50,
+		PriorityP3:     25,
+		Ready:          50,
+		Blocked:        -25,
+		InProgress:     30,
+		PendingChoices: 20,
+		HighComplexity: -10,
+		HasSubtasks:    10,
+	}
+}
+
+// fields maps weight names, as used in config, to the weights they set
+func (w *SuggestionWeights) fields() map[string]*int {
+	return map[string]*int{
+		"priority_p0":     &w.PriorityP0,
+		"priority_p1":     &w.PriorityP1,
+		"priority_p2":     &w.PriorityP2,
+		"priority_p3":     &w.PriorityP3,
+		"ready":           &w.Ready,
+		"blocked":         &w.Blocked,
+		"in_progress":     &w.InProgress,
+		"pending_choices": &w.PendingChoices,
+		"high_complexity": &w.HighComplexity,
+		"has_subtasks":    &w.HasSubtasks,
+	}
+}
+
+// set changes a single weight by name
+func (w *SuggestionWeights) set(name string, value int) error {
+	field, exists := w.fields()[name]
+	if !exists {
+		names := make([]string, 0, len(w.fields()))
+		for known := range w.fields() {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown suggestion weight %q (valid: %s)", name, strings.Join(names, ", "))
+	}
+	*field = value
+	return nil
+}
+
+// priority returns the weight for a task priority
+func (w SuggestionWeights) priority(priority task.TaskPriority) int {
+	switch priority {
+	case task.PriorityP0:
+		return w.PriorityP0
+	case task.PriorityP1:
+		return w.PriorityP1
+	case task.PriorityP2:
+		return w.PriorityP2
+	case task.PriorityP3:
+		return w.PriorityP3
+	}
+	return 0
+}
+
+// parseSuggestionWeights parses a "ready=80,priority_p0=60" style list of weight overrides
+func parseSuggestionWeights(value string) (map[string]int, error) {
+	overrides := make(map[string]int)
+	defaults := DefaultSuggestionWeights()
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid suggestion weight %q (expected ready=50)", pair)
+		}
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for %s: %q", name, parts[1])
+		}
+		if err := defaults.set(name, weight); err != nil {
+			return nil, err
+		}
+		overrides[name] = weight
+	}
+	return overrides, nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// Result limits for search_tasks
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 200
+)
+
+// projectSearchResults groups the search results of one project
+type projectSearchResults struct {
+	Project string              `json:"project"`
+	Tasks   []task.SearchResult `json:"tasks"`
+}
+
+// handleSearchTasks handles the search_tasks tool
+func (tms *TaskManagerServer) handleSearchTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return tms.createErrorResult("search_tasks", task.NewError(task.ErrInvalidInput, "missing query: %w", err)), nil
+	}
+
+	var projects []string
+	if projectName := mcp.ParseString(request, "project_name", ""); projectName != "" {
+		if err := tms.validateProjectName(projectName); err != nil {
+			return tms.createErrorResult("search_tasks", err), nil
+		}
+		projects = []string{projectName}
+	}
+
+	fields, err := tms.parseStringArray(request, "fields")
+	if err != nil {
+		return tms.createErrorResult("search_tasks", err), nil
+	}
+	for i := range fields {
+		fields[i] = strings.ToLower(fields[i])
+	}
+
+	limit := tms.parseNumberField(request, "limit", defaultSearchLimit)
+	if limit < 1 || limit > maxSearchLimit {
+		return tms.createErrorResult("search_tasks", task.NewError(task.ErrInvalidInput, "limit must be between 1 and %d, got %d", maxSearchLimit, limit)), nil
+	}
+
+	results, err := tms.taskManager.SearchTasks(query, projects, fields)
+	if err != nil {
+		return tms.createErrorResult("search_tasks", err), nil
+	}
+
+	total := len(results)
+	results = results[:min(limit, total)]
+
+	// Group by project, keeping the ranking within each project
+	groups := []projectSearchResults{}
+	groupIndex := make(map[string]int)
+	for _, result := range results {
+		index, exists := groupIndex[result.Project]
+		if !exists {
+			index = len(groups)
+			groupIndex[result.Project] = index
+			groups = append(groups, projectSearchResults{Project: result.Project})
+		}
+		groups[index].Tasks = append(groups[index].Tasks, result)
+	}
+
+	response := map[string]interface{}{
+		"query":     strings.TrimSpace(query),
+		"total":     total,
+		"count":     len(results),
+		"truncated": len(results) < total,
+		"projects":  groups,
+	}
+
+	resultJSON, err := json.Marshal(response)
+	if err != nil {
+		return tms.createErrorResult("search_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
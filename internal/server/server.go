@@ -3,11 +3,16 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,9 +23,20 @@ import (
 
 // TaskManagerServer wraps the MCP server with task management capabilities
 type TaskManagerServer struct {
+	config             ServerConfig
 	mcpServer          *server.MCPServer
 	taskManager        *task.Manager
 	autoEvalMiddleware *AutoEvaluationMiddleware
+	idempotency        *idempotencyCache
+	registeredTools    []string
+	disabledTools      []string
+	watchers           *projectWatchers
+	allTasks           *allTasksCache
+	tasksDir           tasksDirResolution
+
+	// network is the SSE or HTTP server being served, stopped by Shutdown
+	network    networkServer
+	serveMutex sync.Mutex
 }
 
 // NewTaskManagerServer creates a new task manager MCP server
@@ -31,68 +47,79 @@ func NewTaskManagerServer() (*TaskManagerServer, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	// Drop a client's project watches when its session ends
+	watchers := newProjectWatchers(config.MaxWatchSubscribers)
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		watchers.removeSession(session.SessionID())
+	})
+
+	serverOptions := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithRecovery(),
+		server.WithHooks(hooks),
+	}
+	if config.ReadOnly {
+		serverOptions = append(serverOptions, server.WithInstructions(readOnlyInstructions))
+	}
+
 	// Create the MCP server
 	mcpServer := server.NewMCPServer(
 		"Task Manager Go",
 		"1.0.0",
-		server.WithToolCapabilities(true),
-		server.WithRecovery(),
+		serverOptions...,
 	)
+	watchers.mcpServer = mcpServer
 
 	// Determine tasks directory
-	tasksDir := config.TasksDir
-	if tasksDir == "" {
-		tasksDir = os.Getenv("TASKS_DIR")
+	tasksDir, err := resolveTasksDir(config, detectProjectRoot)
+	if err != nil {
+		return nil, err
 	}
-	if tasksDir == "" {
-		// Auto-detect project root and use tasks subdirectory
-		projectRoot, err := detectProjectRoot()
-		if err != nil {
-			// Fall back to a safe directory in user's home
-			if homeDir, homeErr := os.UserHomeDir(); homeErr == nil {
-				tasksDir = filepath.Join(homeDir, ".mcp-task-manager", "tasks")
-			} else {
-				// Final fallback - use temp directory
-				tasksDir = filepath.Join(os.TempDir(), "mcp-task-manager", "tasks")
-			}
-		} else {
-			tasksDir = filepath.Join(projectRoot, "tasks")
-		}
+	for _, warning := range tasksDir.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
 	}
 
-	// Ensure the path is absolute and safe
-	if !filepath.IsAbs(tasksDir) {
-		// If it's still relative, make it relative to user's home directory
-		if homeDir, err := os.UserHomeDir(); err == nil {
-			tasksDir = filepath.Join(homeDir, ".mcp-task-manager", tasksDir)
-		} else {
-			// Last resort - use temp directory
-			tasksDir = filepath.Join(os.TempDir(), "mcp-task-manager", tasksDir)
+	labels := task.DefaultMarkdownLabels()
+	for name, value := range config.MarkdownLabels {
+		if err := labels.Set(name, value); err != nil {
+			return nil, err
 		}
 	}
 
-	// Safety check: never allow creating directories in system root or other unsafe locations
-	if tasksDir == "/" || tasksDir == "/tasks" || strings.HasPrefix(tasksDir, "/bin") || strings.HasPrefix(tasksDir, "/usr") || strings.HasPrefix(tasksDir, "/etc") {
-		// Force safe fallback
-		if homeDir, err := os.UserHomeDir(); err == nil {
-			tasksDir = filepath.Join(homeDir, ".mcp-task-manager", "tasks")
-		} else {
-			tasksDir = filepath.Join(os.TempDir(), "mcp-task-manager", "tasks")
-		}
+	taskManager, err := task.NewManagerWithConfig(tasksDir.Dir, task.ManagerConfig{
+		MaxFileSize:  config.MaxFileSize,
+		Namespace:    config.Namespace,
+		HeadingLevel: config.HeadingLevel,
+		Labels:       labels,
+		LockTimeout:  config.LockTimeout,
+		HistoryLimit: config.HistoryLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tasks directory %s (from %s): %w", tasksDir.Dir, tasksDir.Source, err)
 	}
+	taskManager.OnProjectSaved(watchers.projectSaved)
 
-	taskManager, err := task.NewManager(tasksDir)
-	if err != nil {
-		return nil, err
+	allTasks := &allTasksCache{}
+	taskManager.OnProjectSaved(allTasks.invalidate)
+
+	// Auto-evaluation may save status updates, which read-only mode must not do
+	if config.ReadOnly {
+		config.AutoEvaluation.Enabled = false
 	}
 
 	// Create auto-evaluation middleware with loaded config
 	autoEvalMiddleware := NewAutoEvaluationMiddleware(taskManager, config.AutoEvaluation)
 
 	tms := &TaskManagerServer{
+		config:             config,
 		mcpServer:          mcpServer,
 		taskManager:        taskManager,
 		autoEvalMiddleware: autoEvalMiddleware,
+		idempotency:        newIdempotencyCache(defaultIdempotencyCacheSize),
+		watchers:           watchers,
+		allTasks:           allTasks,
+		tasksDir:           tasksDir,
 	}
 
 	// Register all tools
@@ -103,25 +130,137 @@ func NewTaskManagerServer() (*TaskManagerServer, error) {
 	return tms, nil
 }
 
-// ServeStdio starts the server with stdio transport
+// ServeStdio starts the server with stdio transport, serving until stdin is
+// closed or ctx is cancelled
 func (tms *TaskManagerServer) ServeStdio(ctx context.Context) error {
-	return server.ServeStdio(tms.mcpServer)
+	defer tms.Shutdown(context.Background())
+
+	err := server.NewStdioServer(tms.mcpServer).Listen(ctx, os.Stdin, os.Stdout)
+	if ctx.Err() != nil {
+		// Stopped by cancellation rather than failure
+		return nil
+	}
+	return err
 }
 
-// ServeSSE starts the server with SSE transport
+// ServeSSE starts the server with SSE transport, serving until ctx is cancelled
 func (tms *TaskManagerServer) ServeSSE(ctx context.Context) error {
-	host := os.Getenv("HOST")
-	if host == "" {
-		host = "0.0.0.0"
+	httpServer, err := tms.newHTTPServer()
+	if err != nil {
+		return err
+	}
+
+	sseServer := server.NewSSEServer(tms.mcpServer, server.WithHTTPServer(httpServer))
+	httpServer.Handler = sseServer
+	return tms.serveNetwork(ctx, sseServer, httpServer)
+}
+
+// httpEndpointPath is where the streamable HTTP transport serves MCP requests
+const httpEndpointPath = "/mcp"
+
+// ServeHTTP starts the server with the streamable HTTP transport, serving
+// JSON-RPC requests at /mcp, until ctx is cancelled
+func (tms *TaskManagerServer) ServeHTTP(ctx context.Context) error {
+	httpServer, err := tms.newHTTPServer()
+	if err != nil {
+		return err
+	}
+
+	streamableServer := server.NewStreamableHTTPServer(tms.mcpServer, server.WithStreamableHTTPServer(httpServer))
+	mux := http.NewServeMux()
+	mux.Handle(httpEndpointPath, streamableServer)
+	httpServer.Handler = mux
+	return tms.serveNetwork(ctx, streamableServer, httpServer)
+}
+
+// shutdownTimeout bounds how long in-flight requests get to finish once the
+// server is asked to stop
+const shutdownTimeout = 5 * time.Second
+
+// networkServer is an MCP transport served over HTTP, such as SSE
+type networkServer interface {
+	Shutdown(ctx context.Context) error
+}
+
+// newHTTPServer validates the configured host and port and returns an HTTP
+// server to listen on them
+func (tms *TaskManagerServer) newHTTPServer() (*http.Server, error) {
+	if err := validateHost(tms.config.Host); err != nil {
+		return nil, err
+	}
+	if err := validatePort(tms.config.Port); err != nil {
+		return nil, err
+	}
+	return &http.Server{Addr: net.JoinHostPort(strings.Trim(tms.config.Host, "[]"), tms.config.Port)}, nil
+}
+
+// serveNetwork serves a network transport until it fails or ctx is
+// cancelled, then shuts the server down
+func (tms *TaskManagerServer) serveNetwork(ctx context.Context, transport networkServer, httpServer *http.Server) error {
+	tms.serveMutex.Lock()
+	tms.network = transport
+	tms.serveMutex.Unlock()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		tms.Shutdown(context.Background())
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := tms.Shutdown(shutdownCtx)
+		<-errCh
+		return err
+	}
+}
+
+// Shutdown stops the SSE or HTTP server, if one is serving, waiting for
+// in-flight requests until ctx is done, and drops cached projects. Project
+// files are written before each tool call returns and their locks are held
+// only while writing, so nothing else needs flushing.
+func (tms *TaskManagerServer) Shutdown(ctx context.Context) error {
+	tms.serveMutex.Lock()
+	transport := tms.network
+	tms.network = nil
+	tms.serveMutex.Unlock()
+
+	var err error
+	if transport != nil {
+		if err = transport.Shutdown(ctx); err != nil {
+			err = fmt.Errorf("failed to shut down server: %w", err)
+		}
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8050"
+	tms.taskManager.ClearCache()
+	return err
+}
+
+// SetTransport overrides the configured transport, host and port with any
+// non-empty values (e.g. from command-line flags) and validates the result
+func (tms *TaskManagerServer) SetTransport(transport, host, port string) error {
+	if transport != "" {
+		tms.config.Transport = transport
+	}
+	if host != "" {
+		tms.config.Host = host
 	}
+	if port != "" {
+		tms.config.Port = port
+	}
+	return tms.config.ValidateTransport()
+}
 
-	sseServer := server.NewSSEServer(tms.mcpServer)
-	return sseServer.Start(host + ":" + port)
+// Transport returns the configured transport (stdio, sse or streamable-http)
+func (tms *TaskManagerServer) Transport() string {
+	return tms.config.Transport
 }
 
 // registerTools registers all MCP tools
@@ -133,8 +272,82 @@ func (tms *TaskManagerServer) registerTools() error {
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&createTaskFileTool, tms.withIdempotency("create_task_file", tms.handleCreateTaskFile))
+
+	// List templates tool
+	listTemplatesTool := mcp.NewTool("list_templates",
+		mcp.WithDescription("List the project templates create_project_from_template accepts, with their descriptions and task counts"),
+	)
+	tms.addTool(&listTemplatesTool, tms.handleListTemplates)
+
+	// Create project from template tool
+	createProjectFromTemplateTool := mcp.NewTool("create_project_from_template",
+		mcp.WithDescription("Create a new project pre-populated with the standard tasks, subtasks and dependencies of a template, such as web-app, cli-tool or library. Use list_templates to see the templates. Fails if the project already exists."),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the new project"),
+		),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("Name of the template, as listed by list_templates"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&createProjectFromTemplateTool, tms.withIdempotency("create_project_from_template", tms.handleCreateProjectFromTemplate))
+
+	// List projects tool
+	listProjectsTool := mcp.NewTool("list_projects",
+		mcp.WithDescription("List the projects in the tasks directory with their task, completed task and pending choice counts; projects that fail to load are reported under unreadable_projects"),
+		mcp.WithBoolean("exclude_empty",
+			mcp.Description("If true, leave out projects without any tasks (default: EXCLUDE_EMPTY_PROJECTS, normally false)"),
+		),
+		mcp.WithBoolean("include_tasks",
+			mcp.Description("If true, include a summary of every task in each project (default: false)"),
+		),
+	)
+	tms.addTool(&listProjectsTool, tms.handleListProjects)
+
+	// Delete project tool
+	deleteProjectTool := mcp.NewTool("delete_project",
+		mcp.WithDescription("Permanently delete a project's task file and progress snapshots. Requires confirm=true; use archive_project to keep a copy"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Must be true to delete the project (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&deleteProjectTool, tms.withIdempotency("delete_project", tms.handleDeleteProject))
+
+	// Archive project tool
+	archiveProjectTool := mcp.NewTool("archive_project",
+		mcp.WithDescription("Move a project's task file into the archive subdirectory of the tasks directory, hiding it from project listings"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&archiveProjectTool, tms.withIdempotency("archive_project", tms.handleArchiveProject))
+
+	// Set project description tool
+	setProjectDescriptionTool := mcp.NewTool("set_project_description",
+		mcp.WithDescription("Set the description written under a project's title, such as its goal or scope"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("description",
+			mcp.Required(),
+			mcp.Description("New description (max 5000 characters; lines cannot start with '#'); empty to remove it"),
+		),
+		idempotencyKeyOption(),
 	)
-	tms.mcpServer.AddTool(createTaskFileTool, tms.handleCreateTaskFile)
+	tms.addTool(&setProjectDescriptionTool, tms.withIdempotency("set_project_description", tms.handleSetProjectDescription))
 
 	// Add task tool
 	addTaskTool := mcp.NewTool("add_task",
@@ -155,15 +368,46 @@ func (tms *TaskManagerServer) registerTools() error {
 			mcp.Description("Optional list of subtasks"),
 			mcp.Items(map[string]any{"type": "string"}),
 		),
+		mcp.WithNumber("estimated_hours",
+			mcp.Description("Optional estimated hours to complete the task (0-1000, rounded to whole hours)"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Task priority (default: P2)"),
+			mcp.Enum("P0", "P1", "P2", "P3"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Optional category (e.g., 'MVP' or '[MVP]'): [MVP], [AI], [UX], [INFRA] or [GENERAL]"),
+		),
+		mcp.WithString("complexity",
+			mcp.Description("Optional complexity"),
+			mcp.Enum("low", "medium", "high"),
+		),
 		mcp.WithBoolean("batch_mode",
-			mcp.Description("If true, don't read existing tasks (for bulk additions)"),
+			mcp.Description("If true, append the task to the project file without parsing the whole project (for bulk additions; the file's progress overview is refreshed on the next full save)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&addTaskTool, tms.withIdempotency("add_task", tms.handleAddTask))
+
+	// Bulk add tasks tool
+	bulkAddTasksTool := mcp.NewTool("bulk_add_tasks",
+		mcp.WithDescription("Add several tasks to a project in one save. Every task is validated first and the whole batch is rejected if any title is invalid or already taken"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithArray("tasks",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Tasks to add (max %d), each with a title, description and optional subtasks, priority (P0-P3) and category", maxBulkAddTasks)),
+			mcp.Items(bulkAddTaskSchema),
 		),
+		idempotencyKeyOption(),
 	)
-	tms.addTool(&addTaskTool, tms.handleAddTask)
+	tms.addTool(&bulkAddTasksTool, tms.withIdempotency("bulk_add_tasks", tms.handleBulkAddTasks))
 
 	// Update task status tool
 	updateTaskStatusTool := mcp.NewTool("update_task_status",
-		mcp.WithDescription("Update the status of a task or subtask"),
+		mcp.WithDescription("Update the status of a task or subtask. Completing the last open subtask completes its task, and reopening a subtask of a done task moves the task back to in_progress. A blocked task can record why it is blocked; the reason is cleared when it leaves the blocked status"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
@@ -179,8 +423,36 @@ func (tms *TaskManagerServer) registerTools() error {
 			mcp.Description("New status (todo/in_progress/done/blocked)"),
 			mcp.Enum("todo", "in_progress", "done", "blocked"),
 		),
+		mcp.WithString("blocked_reason",
+			mcp.Description("Why the task is blocked; only allowed when setting a task, not a subtask, to blocked. Without it, an already blocked task keeps its reason"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Mark a task done even though it has incomplete subtasks, completing them too (only needed when the server requires confirmation)"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&updateTaskStatusTool, tms.withIdempotency("update_task_status", tms.handleUpdateTaskStatus))
+
+	// Bulk status update tool
+	updateTaskStatusesTool := mcp.NewTool("update_task_statuses",
+		mcp.WithDescription("Update the status of several tasks or subtasks in one save, with the same auto-completion as update_task_status. Each entry is reported as updated, not_found, conflict or invalid; a failing entry doesn't stop the others"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithArray("updates",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Status updates to apply in order (max %d), each with a task_title, optional subtask_title and status", maxBulkStatusUpdates)),
+			mcp.Items(statusUpdateSchema),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Mark tasks done even though they have incomplete subtasks, completing them too (only needed when the server requires confirmation)"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
 	)
-	tms.addTool(&updateTaskStatusTool, tms.handleUpdateTaskStatus)
+	tms.addTool(&updateTaskStatusesTool, tms.withIdempotency("update_task_statuses", tms.handleUpdateTaskStatuses))
 
 	// Get next task tool
 	getNextTaskTool := mcp.NewTool("get_next_task",
@@ -189,22 +461,51 @@ func (tms *TaskManagerServer) registerTools() error {
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
+		mcp.WithBoolean("auto_start",
+			mcp.Description("If true, mark the returned task/subtask as in_progress when it is still todo (default: false)"),
+		),
+		autoCreateOption(),
 	)
 	tms.addTool(&getNextTaskTool, tms.handleGetNextTask)
 
 	// Parse PRD tool
 	parsePRDTool := mcp.NewTool("parse_prd",
-		mcp.WithDescription("Parse a PRD and create tasks from it"),
+		mcp.WithDescription("Parse a PRD and create tasks from it: top-level bullets and # or ## headings become tasks, nested bullets become subtasks, and inline (P0)-(P3) and [MVP]-style hints set priority and category. Creates the project if needed"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("prd_content",
+			mcp.Required(),
+			mcp.Description("Content of the PRD to parse (markdown)"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, show the tasks that would be created without creating them (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&parsePRDTool, tms.withIdempotency("parse_prd", tms.handleParsePRD))
+
+	// Update from PRD tool
+	updateFromPRDTool := mcp.NewTool("update_from_prd",
+		mcp.WithDescription("Reconcile a project with a revised PRD: tasks are matched by title, matching tasks take the PRD's description, priority, category and new subtasks, and new tasks are added. Statuses are kept. Returns a change report"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
 		mcp.WithString("prd_content",
 			mcp.Required(),
-			mcp.Description("Content of the PRD to parse"),
+			mcp.Description("Content of the revised PRD (markdown, same format as parse_prd)"),
+		),
+		mcp.WithBoolean("prune",
+			mcp.Description("If true, remove tasks and subtasks that are not in the PRD (default: false)"),
 		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report the changes without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
 	)
-	tms.mcpServer.AddTool(parsePRDTool, tms.handleParsePRD)
+	tms.addTool(&updateFromPRDTool, tms.withIdempotency("update_from_prd", tms.handleUpdateFromPRD))
 
 	// Expand task tool
 	expandTaskTool := mcp.NewTool("expand_task",
@@ -225,8 +526,36 @@ func (tms *TaskManagerServer) registerTools() error {
 		mcp.WithString("reasoning",
 			mcp.Description("Optional reasoning for the task breakdown"),
 		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&expandTaskTool, tms.withIdempotency("expand_task", tms.handleExpandTask))
+
+	// List subtask templates tool
+	listSubtaskTemplatesTool := mcp.NewTool("list_subtask_templates",
+		mcp.WithDescription("List the subtask templates apply_subtask_template accepts: named checklists such as code-review, with their subtasks"),
+	)
+	tms.addTool(&listSubtaskTemplatesTool, tms.handleListSubtaskTemplates)
+
+	// Apply subtask template tool
+	applySubtaskTemplateTool := mcp.NewTool("apply_subtask_template",
+		mcp.WithDescription("Append the subtasks of a named checklist, such as code-review, to a task, so many tasks share the same definition of done. Subtasks the task already has (ignoring case) are skipped, so applying a template twice adds nothing."),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("Name of the subtask template, as listed by list_subtask_templates"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
 	)
-	tms.mcpServer.AddTool(expandTaskTool, tms.handleExpandTask)
+	tms.addTool(&applySubtaskTemplateTool, tms.withIdempotency("apply_subtask_template", tms.handleApplySubtaskTemplate))
 
 	// Generate task file tool
 	generateTaskFileTool := mcp.NewTool("generate_task_file",
@@ -247,107 +576,922 @@ func (tms *TaskManagerServer) registerTools() error {
 		mcp.WithString("template_content",
 			mcp.Description("Optional template content provided by LLM"),
 		),
+		mcp.WithBoolean("strict",
+			mcp.Description("If true, require an explicit file_type instead of inferring one from the task (default: server setting, normally false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&generateTaskFileTool, tms.withIdempotency("generate_task_file", tms.handleGenerateTaskFile))
+
+	// Get task dependencies tool
+	getTaskDependenciesTool := mcp.NewTool("get_task_dependencies",
+		mcp.WithDescription("Get dependency information for tasks in a project"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Description("Optional specific task to get dependencies for"),
+		),
+		mcp.WithBoolean("include_dependents",
+			mcp.Description("Include tasks that depend on this task (default: false)"),
+		),
+	)
+	tms.addTool(&getTaskDependenciesTool, tms.handleGetTaskDependencies)
+
+	// Add task dependency tool
+	addTaskDependencyTool := mcp.NewTool("add_task_dependency",
+		mcp.WithDescription("Make a task depend on another task. Rejected if it would create a circular dependency"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the dependent task"),
+		),
+		mcp.WithString("depends_on_title",
+			mcp.Required(),
+			mcp.Description("Title of the task it depends on"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&addTaskDependencyTool, tms.withIdempotency("add_task_dependency", tms.handleAddTaskDependency))
+
+	// Remove task dependency tool
+	removeTaskDependencyTool := mcp.NewTool("remove_task_dependency",
+		mcp.WithDescription("Remove a task's dependency on another task; does nothing if the dependency isn't present"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the dependent task"),
+		),
+		mcp.WithString("depends_on_title",
+			mcp.Required(),
+			mcp.Description("Title of the task it depends on"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&removeTaskDependencyTool, tms.withIdempotency("remove_task_dependency", tms.handleRemoveTaskDependency))
+
+	// Estimate task complexity tool
+	estimateTaskComplexityTool := mcp.NewTool("estimate_task_complexity",
+		mcp.WithDescription("Store LLM-provided complexity analysis for a task"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task to analyze"),
+		),
+		mcp.WithString("complexity",
+			mcp.Required(),
+			mcp.Description("Complexity level (low, medium, high)"),
+			mcp.Enum("low", "medium", "high"),
+		),
+		mcp.WithNumber("estimated_hours",
+			mcp.Description("Estimated hours to complete the task"),
+		),
+		mcp.WithString("reasoning",
+			mcp.Description("LLM's reasoning for the complexity assessment"),
+		),
+		mcp.WithArray("suggested_subtasks",
+			mcp.Description("Optional array of suggested subtasks for complex tasks"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithBoolean("auto_create_subtasks",
+			mcp.Description("Whether to automatically create suggested subtasks (default: false)"),
+		),
+		mcp.WithString("min_complexity_for_subtasks",
+			mcp.Description("Lowest complexity at which suggested subtasks are auto-created (default: the server's subtask_complexity_gate, normally medium)"),
+			mcp.Enum("low", "medium", "high"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&estimateTaskComplexityTool, tms.withIdempotency("estimate_task_complexity", tms.handleEstimateTaskComplexity))
+
+	// Complexity breakdown tool
+	complexityBreakdownTool := mcp.NewTool("complexity_breakdown",
+		mcp.WithDescription("Report the distribution of task complexity and list high complexity tasks with few or no subtasks, which are candidates for expand_task"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithNumber("min_subtasks",
+			mcp.Description("High complexity tasks with fewer subtasks than this are candidates (default: 2)"),
+		),
+		mcp.WithBoolean("include_completed",
+			mcp.Description("Include completed tasks as candidates (default: false)"),
+		),
+	)
+	tms.addTool(&complexityBreakdownTool, tms.handleComplexityBreakdown)
+
+	// Category progress tool
+	getCategoryProgressTool := mcp.NewTool("get_category_progress",
+		mcp.WithDescription("Report how many tasks each category has and how many of them are completed, e.g. to compare MVP and INFRA progress. Tasks without a category are counted under [GENERAL]."),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+	)
+	tms.addTool(&getCategoryProgressTool, tms.handleGetCategoryProgress)
+
+	// Find duplicates tool
+	findDuplicatesTool := mcp.NewTool("find_duplicates",
+		mcp.WithDescription("Find groups of suspected duplicate tasks in a project: identical titles or descriptions (ignoring case and punctuation) and, optionally, near-identical titles"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("fuzzy",
+			mcp.Description("Also report tasks with near-identical titles (default: true)"),
+		),
+		mcp.WithNumber("threshold",
+			mcp.Description("Title similarity from 0 to 1 at which fuzzy matches are reported (default: 0.85)"),
+		),
+	)
+	tms.addTool(&findDuplicatesTool, tms.handleFindDuplicates)
+
+	// Record progress snapshot tool
+	recordProgressSnapshotTool := mcp.NewTool("record_progress_snapshot",
+		mcp.WithDescription("Record the current status of every task in a project so progress_delta can later report what changed since"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&recordProgressSnapshotTool, tms.withIdempotency("record_progress_snapshot", tms.handleRecordProgressSnapshot))
+
+	// Progress delta tool
+	progressDeltaTool := mcp.NewTool("progress_delta",
+		mcp.WithDescription("Report tasks completed, added, removed and status-changed between two times, using recorded progress snapshots (e.g. a 'what happened this week' report)"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("since",
+			mcp.Required(),
+			mcp.Description("Start of the window (RFC3339 timestamp or YYYY-MM-DD); the latest snapshot at or before it is the baseline"),
+		),
+		mcp.WithString("until",
+			mcp.Description("End of the window (RFC3339 timestamp or YYYY-MM-DD); defaults to the project's current state"),
+		),
+	)
+	tms.addTool(&progressDeltaTool, tms.handleProgressDelta)
+
+	// Suggest next actions tool
+	suggestNextActionsTool := mcp.NewTool("suggest_next_actions",
+		mcp.WithDescription("Analyze project state and suggest next actions based on priorities and dependencies"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("focus_area",
+			mcp.Description("Optional focus area: a category (e.g., 'MVP', 'AI', 'UX', 'INFRA') or a task tag; brackets and case are ignored"),
+		),
+		mcp.WithString("focus_assignee",
+			mcp.Description("Only suggest tasks assigned to this person or agent (case is ignored)"),
+		),
+		mcp.WithNumber("max_suggestions",
+			mcp.Description("Maximum number of suggestions to return (default: 5)"),
+		),
+		mcp.WithBoolean("include_blocked",
+			mcp.Description("Include blocked tasks in analysis (default: false)"),
+		),
+		mcp.WithBoolean("include_resolved_choices",
+			mcp.Description("Include each suggestion's resolved choices with their selected option and reasoning (default: false)"),
+		),
+		autoCreateOption(),
+	)
+	tms.addTool(&suggestNextActionsTool, tms.handleSuggestNextActions)
+
+	// Auto-update task statuses tool
+	autoUpdateTasksTool := mcp.NewTool("auto_update_tasks",
+		mcp.WithDescription("Automatically update task statuses based on completion rules (e.g., mark tasks done when all subtasks are complete)"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, show what would be updated without making changes (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&autoUpdateTasksTool, tms.withIdempotency("auto_update_tasks", tms.handleAutoUpdateTasks))
+
+	// Evaluate all projects tool
+	evaluateAllTool := mcp.NewTool("evaluate_all",
+		mcp.WithDescription("Maintenance sweep: apply automatic status updates and collect tasks needing attention across every project"),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report the updates that would be applied without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&evaluateAllTool, tms.withIdempotency("evaluate_all", tms.handleEvaluateAll))
+
+	// Reconcile project tool, e.g. after checking off subtasks by hand in the markdown file
+	reconcileProjectTool := mcp.NewTool("reconcile_project",
+		mcp.WithDescription("Apply the full set of automatic status updates to a project, e.g. after editing its markdown file by hand, and report every change made"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report the updates that would be applied without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	// Registered without the auto-evaluation wrapper, which would apply the updates first
+	tms.registerTool(reconcileProjectTool, tms.withIdempotency("reconcile_project", tms.handleReconcileProject))
+
+	// Get tasks needing attention tool
+	getTasksNeedingAttentionTool := mcp.NewTool("get_tasks_needing_attention",
+		mcp.WithDescription("Get tasks that might need manual review (overdue, stale, etc.)"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("attention_type",
+			mcp.Description("Filter by attention type (completion, stale, overdue, blocked)"),
+		),
+		autoCreateOption(),
+	)
+	tms.addTool(&getTasksNeedingAttentionTool, tms.handleGetTasksNeedingAttention)
+
+	// Bulk tag tool
+	bulkTagTool := mcp.NewTool("bulk_tag",
+		mcp.WithDescription("Add or remove a tag across many tasks selected by filter or by title, in a single save"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("tag",
+			mcp.Required(),
+			mcp.Description("Tag to add or remove"),
+		),
+		mcp.WithString("action",
+			mcp.Description("Whether to add or remove the tag (default: add)"),
+			mcp.Enum("add", "remove"),
+		),
+		mcp.WithArray("task_titles",
+			mcp.Description("Explicit list of task titles to tag (takes precedence over filters)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("status",
+			mcp.Description("Select tasks with this status"),
+			mcp.Enum("todo", "in_progress", "done", "blocked"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Select tasks in this category (e.g., 'MVP' or '[MVP]')"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Select tasks with this priority (P0-P3)"),
+		),
+		mcp.WithString("complexity",
+			mcp.Description("Select tasks with this complexity (low, medium, high)"),
+		),
+		mcp.WithNumber("min_progress",
+			mcp.Description("Select tasks with at least this percentage of subtasks done (0-100; a task without subtasks is 100 when done, else 0)"),
+		),
+		mcp.WithNumber("max_progress",
+			mcp.Description("Select tasks with at most this percentage of subtasks done (0-100), e.g. 99 with min_progress 1 for partially done work"),
+		),
+		mcp.WithBoolean("all_tasks",
+			mcp.Description("Select every task when no titles or filters are given (default: false)"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report which tasks would change without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&bulkTagTool, tms.withIdempotency("bulk_tag", tms.handleBulkTag))
+
+	// Add task tags tool
+	addTaskTagsTool := mcp.NewTool("add_task_tags",
+		mcp.WithDescription("Add one or more tags to a task. Tags are free-form labels, independent of the task's category"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithArray("tags",
+			mcp.Required(),
+			mcp.Description("Tags to add (e.g., ['backend', 'urgent']); tags already present are ignored"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&addTaskTagsTool, tms.withIdempotency("add_task_tags", tms.handleAddTaskTags))
+
+	// Remove task tags tool
+	removeTaskTagsTool := mcp.NewTool("remove_task_tags",
+		mcp.WithDescription("Remove one or more tags from a task"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithArray("tags",
+			mcp.Required(),
+			mcp.Description("Tags to remove; tags the task doesn't carry are ignored"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&removeTaskTagsTool, tms.withIdempotency("remove_task_tags", tms.handleRemoveTaskTags))
+
+	// Filter tasks tool
+	filterTasksTool := mcp.NewTool("filter_tasks",
+		mcp.WithDescription("List a project's tasks matching tags and/or status, category, priority, complexity, assignee and progress filters"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Only tasks carrying these tags (see tag_match)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("tag_match",
+			mcp.Description("Whether a task needs any or all of the tags (default: any)"),
+			mcp.Enum("any", "all"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Only tasks with this status"),
+			mcp.Enum("todo", "in_progress", "done", "blocked"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Only tasks in this category (e.g., 'MVP' or '[MVP]')"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Only tasks with this priority (P0-P3)"),
+		),
+		mcp.WithString("complexity",
+			mcp.Description("Only tasks with this complexity (low, medium, high)"),
+		),
+		mcp.WithString("assignee",
+			mcp.Description("Only tasks assigned to this person or agent (case is ignored)"),
+		),
+		mcp.WithNumber("min_progress",
+			mcp.Description("Only tasks with at least this percentage of subtasks done (0-100; a task without subtasks is 100 when done, else 0)"),
+		),
+		mcp.WithNumber("max_progress",
+			mcp.Description("Only tasks with at most this percentage of subtasks done (0-100)"),
+		),
+		autoCreateOption(),
+	)
+	tms.addTool(&filterTasksTool, tms.handleFilterTasks)
+
+	// Search tasks tool
+	searchTasksTool := mcp.NewTool("search_tasks",
+		mcp.WithDescription("Find tasks by keyword: case-insensitive substring search over task titles, descriptions and subtask titles, grouped by project. Exact title matches are listed first"),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Text to search for"),
+		),
+		mcp.WithString("project_name",
+			mcp.Description("Only search this project (default: every project)"),
+		),
+		mcp.WithArray("fields",
+			mcp.Description("Only match these fields: title, description, subtasks (default: all)"),
+			mcp.Items(map[string]any{"type": "string", "enum": []string{"title", "description", "subtasks"}}),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of tasks to return (default: 20, max: 200)"),
+		),
+	)
+	tms.addTool(&searchTasksTool, tms.handleSearchTasks)
+
+	// Renumber tasks tool
+	renumberTasksTool := mcp.NewTool("renumber_tasks",
+		mcp.WithDescription("Reassign sequential task IDs (1..N) in file order and rewrite dependency references to match. Task IDs change, so use dry_run first to preview"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report the ID changes without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&renumberTasksTool, tms.withIdempotency("renumber_tasks", tms.handleRenumberTasks))
+
+	// Reorder tasks tool
+	reorderTasksTool := mcp.NewTool("reorder_tasks",
+		mcp.WithDescription("Change the order of a project's tasks, which is the order they are listed in and get_next_task picks from. Task IDs and dependencies are unchanged"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithArray("order",
+			mcp.Required(),
+			mcp.Description("Every task of the project exactly once, in the new order, as task IDs or exact titles"),
+			mcp.Items(map[string]any{"anyOf": []map[string]any{{"type": "integer"}, {"type": "string"}}}),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&reorderTasksTool, tms.withIdempotency("reorder_tasks", tms.handleReorderTasks))
+
+	// Normalize titles tool
+	normalizeTitlesTool := mcp.NewTool("normalize_titles",
+		mcp.WithDescription("Find task and subtask titles written to the project file with extra whitespace around them (e.g. by hand edits) and rewrite the file without it"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("If true, report the title changes without saving (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&normalizeTitlesTool, tms.withIdempotency("normalize_titles", tms.handleNormalizeTitles))
+
+	// Rename task tool
+	renameTaskTool := mcp.NewTool("rename_task",
+		mcp.WithDescription("Rename a task. Dependencies refer to task IDs, so they follow the renamed task; descriptions and choices mentioning the old title are left unchanged"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Current title of the task"),
+		),
+		mcp.WithString("new_title",
+			mcp.Required(),
+			mcp.Description("New title, which must not be used by another task"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&renameTaskTool, tms.withIdempotency("rename_task", tms.handleRenameTask))
+
+	// Set task priority tool
+	setTaskPriorityTool := mcp.NewTool("set_task_priority",
+		mcp.WithDescription("Change a task's priority. Priority weighs into the order of suggest_next_actions"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("priority",
+			mcp.Required(),
+			mcp.Description("New priority"),
+			mcp.Enum("P0", "P1", "P2", "P3"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&setTaskPriorityTool, tms.withIdempotency("set_task_priority", tms.handleSetTaskPriority))
+
+	// Set task category tool
+	setTaskCategoryTool := mcp.NewTool("set_task_category",
+		mcp.WithDescription("Change a task's category. [GENERAL] leaves the task uncategorized"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("category",
+			mcp.Required(),
+			mcp.Description("New category (e.g., 'MVP' or '[MVP]'): [MVP], [AI], [UX], [INFRA] or [GENERAL]"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&setTaskCategoryTool, tms.withIdempotency("set_task_category", tms.handleSetTaskCategory))
+
+	// Assign task tool
+	assignTaskTool := mcp.NewTool("assign_task",
+		mcp.WithDescription("Assign a task to a person or agent, replacing any previous assignee. Use filter_tasks or suggest_next_actions with the assignee to partition work"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("assignee",
+			mcp.Required(),
+			mcp.Description("Name of the person or agent"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&assignTaskTool, tms.withIdempotency("assign_task", tms.handleAssignTask))
+
+	// Unassign task tool
+	unassignTaskTool := mcp.NewTool("unassign_task",
+		mcp.WithDescription("Remove a task's assignee"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&unassignTaskTool, tms.withIdempotency("unassign_task", tms.handleUnassignTask))
+
+	// Move task tool
+	moveTaskTool := mcp.NewTool("move_task",
+		mcp.WithDescription("Move a task with its subtasks to the end of another project, where it gets a new ID. Dependencies between the task and tasks left in the source project are removed and reported"),
+		mcp.WithString("from_project",
+			mcp.Required(),
+			mcp.Description("Name of the project the task is in"),
+		),
+		mcp.WithString("to_project",
+			mcp.Required(),
+			mcp.Description("Name of the project to move the task to, which must not have a task with the same title"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task to move"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&moveTaskTool, tms.withIdempotency("move_task", tms.handleMoveTask))
+
+	// History tools
+	listHistoryTool := mcp.NewTool("list_history",
+		mcp.WithDescription("List the previous versions of a project kept for undo, newest first. A version is recorded before every change to the project file"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+	)
+	tms.addTool(&listHistoryTool, tms.handleListHistory)
+
+	undoLastChangeTool := mcp.NewTool("undo_last_change",
+		mcp.WithDescription("Undo the last change to a project by restoring the version it replaced. Repeated calls step further back through the history"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&undoLastChangeTool, tms.withIdempotency("undo_last_change", tms.handleUndoLastChange))
+
+	restoreVersionTool := mcp.NewTool("restore_version",
+		mcp.WithDescription("Restore a project to a version listed by list_history. The current content is kept in the history, so the restore can be undone"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("version",
+			mcp.Required(),
+			mcp.Description("Version to restore, as returned by list_history"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&restoreVersionTool, tms.withIdempotency("restore_version", tms.handleRestoreVersion))
+
+	// Project overview tool
+	projectOverviewTool := mcp.NewTool("project_overview",
+		mcp.WithDescription("Get an overview of a project's progress, including progress weighted by task priority"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		autoCreateOption(),
+	)
+	tms.addTool(&projectOverviewTool, tms.handleProjectOverview)
+
+	// Project tree tool
+	getProjectAsTreeTool := mcp.NewTool("get_project_as_tree",
+		mcp.WithDescription("Get the project as a nested tree (project → tasks → subtasks → choices) with progress on every node, for UIs to render directly"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+	)
+	tms.addTool(&getProjectAsTreeTool, tms.handleGetProjectAsTree)
+
+	// Get task timeline tool
+	getTaskTimelineTool := mcp.NewTool("get_task_timeline",
+		mcp.WithDescription("Get a chronological timeline of a task's history (creation, updates, completion, choices)"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+	)
+	tms.addTool(&getTaskTimelineTool, tms.handleGetTaskTimeline)
+
+	// Get task tool
+	getTaskTool := mcp.NewTool("get_task",
+		mcp.WithDescription("Get the full details of a task: description, status, tags, definition of done, subtasks and choices"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithBoolean("include_resolved_choices",
+			mcp.Description("Include resolved choices with their selected option and reasoning, not just pending ones (default: true)"),
+		),
+		partialMatchOption(),
+	)
+	tms.addTool(&getTaskTool, tms.handleGetTask)
+
+	// Set done criteria tool
+	setDoneCriteriaTool := mcp.NewTool("set_done_criteria",
+		mcp.WithDescription("Set a task's definition of done: acceptance criteria that must hold for the task to be complete, separate from its subtasks. Replaces any existing criteria."),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithArray("criteria",
+			mcp.Required(),
+			mcp.Description("Acceptance criteria, one sentence each; an empty array clears them"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&setDoneCriteriaTool, tms.withIdempotency("set_done_criteria", tms.handleSetDoneCriteria))
+
+	// Acknowledge done criterion tool
+	acknowledgeDoneCriterionTool := mcp.NewTool("acknowledge_done_criterion",
+		mcp.WithDescription("Mark one of a task's definition of done criteria as met (or not met); met criteria count towards the task's completion"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("criterion",
+			mcp.Required(),
+			mcp.Description("Exact text of the criterion"),
+		),
+		mcp.WithBoolean("acknowledged",
+			mcp.Description("Whether the criterion is met (default: true)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&acknowledgeDoneCriterionTool, tms.withIdempotency("acknowledge_done_criterion", tms.handleAcknowledgeDoneCriterion))
+
+	// Add task comment tool
+	addTaskCommentTool := mcp.NewTool("add_task_comment",
+		mcp.WithDescription("Add a dated note to a task, such as progress made or the reasoning behind a change. Notes are kept in the task's Notes section and returned by get_task"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+		mcp.WithString("task_title",
+			mcp.Required(),
+			mcp.Description("Title of the task"),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text of the note (max 2000 characters; line breaks become spaces)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Optional name of who wrote the note"),
+		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
 	)
-	tms.mcpServer.AddTool(generateTaskFileTool, tms.handleGenerateTaskFile)
+	tms.addTool(&addTaskCommentTool, tms.withIdempotency("add_task_comment", tms.handleAddTaskComment))
 
-	// Get task dependencies tool
-	getTaskDependenciesTool := mcp.NewTool("get_task_dependencies",
-		mcp.WithDescription("Get dependency information for tasks in a project"),
+	// Create choice tool
+	createChoiceTool := mcp.NewTool("create_choice",
+		mcp.WithDescription("Add a pending decision to a task: a question with the options to choose from. Returns the choice ID to pass to resolve_choice"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
 		mcp.WithString("task_title",
-			mcp.Description("Optional specific task to get dependencies for"),
+			mcp.Required(),
+			mcp.Description("Title of the task"),
 		),
-		mcp.WithBoolean("include_dependents",
-			mcp.Description("Include tasks that depend on this task (default: false)"),
+		mcp.WithString("question",
+			mcp.Required(),
+			mcp.Description("The decision to make"),
+		),
+		mcp.WithArray("options",
+			mcp.Required(),
+			mcp.Description("At least two options to choose from"),
+			mcp.Items(map[string]any{"type": "string"}),
 		),
+		partialMatchOption(),
+		idempotencyKeyOption(),
 	)
-	tms.mcpServer.AddTool(getTaskDependenciesTool, tms.handleGetTaskDependencies)
+	tms.addTool(&createChoiceTool, tms.withIdempotency("create_choice", tms.handleCreateChoice))
 
-	// Estimate task complexity tool
-	estimateTaskComplexityTool := mcp.NewTool("estimate_task_complexity",
-		mcp.WithDescription("Store LLM-provided complexity analysis for a task"),
+	// Resolve choice tool
+	resolveChoiceTool := mcp.NewTool("resolve_choice",
+		mcp.WithDescription("Resolve a pending decision on a task or one of its subtasks by selecting one of its options. Choice IDs are listed by get_task"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
 		mcp.WithString("task_title",
 			mcp.Required(),
-			mcp.Description("Title of the task to analyze"),
+			mcp.Description("Title of the task"),
 		),
-		mcp.WithString("complexity",
+		mcp.WithString("choice_id",
 			mcp.Required(),
-			mcp.Description("Complexity level (low, medium, high)"),
-			mcp.Enum("low", "medium", "high"),
+			mcp.Description("ID of the choice"),
 		),
-		mcp.WithNumber("estimated_hours",
-			mcp.Description("Estimated hours to complete the task"),
+		mcp.WithString("selected_option",
+			mcp.Required(),
+			mcp.Description("The option chosen, exactly as listed in the choice's options"),
 		),
 		mcp.WithString("reasoning",
-			mcp.Description("LLM's reasoning for the complexity assessment"),
+			mcp.Description("Optional reasoning behind the decision"),
 		),
-		mcp.WithArray("suggested_subtasks",
-			mcp.Description("Optional array of suggested subtasks for complex tasks"),
-			mcp.Items(map[string]any{"type": "string"}),
+		partialMatchOption(),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&resolveChoiceTool, tms.withIdempotency("resolve_choice", tms.handleResolveChoice))
+
+	// Context primer tool
+	contextPrimerTool := mcp.NewTool("context_primer",
+		mcp.WithDescription("Get a compact text summary of all projects and their next actions, suitable for priming an LLM context at session start"),
+		mcp.WithNumber("max_bytes",
+			mcp.Description("Maximum size of the summary in bytes (default: server setting, normally 4000)"),
 		),
-		mcp.WithBoolean("auto_create_subtasks",
-			mcp.Description("Whether to automatically create suggested subtasks (default: false)"),
+		mcp.WithNumber("actions_per_project",
+			mcp.Description("Number of next actions to list per project (default: 3)"),
 		),
 	)
-	tms.mcpServer.AddTool(estimateTaskComplexityTool, tms.handleEstimateTaskComplexity)
+	tms.addTool(&contextPrimerTool, tms.handleContextPrimer)
 
-	// Suggest next actions tool
-	suggestNextActionsTool := mcp.NewTool("suggest_next_actions",
-		mcp.WithDescription("Analyze project state and suggest next actions based on priorities and dependencies"),
+	// Validate project tool
+	validateProjectTool := mcp.NewTool("validate_project",
+		mcp.WithDescription("Check a project's task file for structural problems (malformed choices, duplicate titles, missing dependencies)"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
-		mcp.WithString("focus_area",
-			mcp.Description("Optional focus area (e.g., 'MVP', 'AI', 'UX', 'INFRA')"),
+	)
+	tms.addTool(&validateProjectTool, tms.handleValidateProject)
+
+	// Recent activity tool
+	recentActivityTool := mcp.NewTool("recent_activity",
+		mcp.WithDescription("List the most recently updated tasks and subtasks, newest first"),
+		mcp.WithString("project_name",
+			mcp.Description("Optional project name (all projects if omitted)"),
 		),
-		mcp.WithNumber("max_suggestions",
-			mcp.Description("Maximum number of suggestions to return (default: 5)"),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of items to return (default: 10)"),
 		),
-		mcp.WithBoolean("include_blocked",
-			mcp.Description("Include blocked tasks in analysis (default: false)"),
+	)
+	tms.addTool(&recentActivityTool, tms.handleRecentActivity)
+
+	// All tasks tool
+	allTasksTool := mcp.NewTool("all_tasks",
+		mcp.WithDescription("List tasks from every project in one view, each with its project name, status, priority and progress, with optional filters and pagination"),
+		mcp.WithString("status",
+			mcp.Description("Only tasks with this status (todo, in_progress, done, blocked)"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Only tasks with this priority (P0-P3)"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Only tasks in this category (e.g., 'MVP' or '[MVP]')"),
+		),
+		mcp.WithString("complexity",
+			mcp.Description("Only tasks with this complexity (low, medium, high)"),
+		),
+		mcp.WithNumber("min_progress",
+			mcp.Description("Only tasks with at least this percentage of subtasks done (0-100; a task without subtasks is 100 when done, else 0)"),
+		),
+		mcp.WithNumber("max_progress",
+			mcp.Description("Only tasks with at most this percentage of subtasks done (0-100), e.g. 99 with min_progress 1 for partially done work"),
+		),
+		mcp.WithString("tag",
+			mcp.Description("Only tasks carrying this tag"),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of matching tasks to skip (default: 0)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of tasks to return (default: 50, max: 500)"),
 		),
 	)
-	tms.addTool(&suggestNextActionsTool, tms.handleSuggestNextActions)
+	tms.addTool(&allTasksTool, tms.handleAllTasks)
 
-	// Auto-update task statuses tool
-	autoUpdateTasksTool := mcp.NewTool("auto_update_tasks",
-		mcp.WithDescription("Automatically update task statuses based on completion rules (e.g., mark tasks done when all subtasks are complete)"),
+	// Export checklist tool
+	exportChecklistTool := mcp.NewTool("export_checklist",
+		mcp.WithDescription("Export a project's tasks and subtasks as a GitHub-flavored markdown checklist, ready to paste into a PR or issue"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
-		mcp.WithBoolean("dry_run",
-			mcp.Description("If true, show what would be updated without making changes (default: false)"),
+	)
+	tms.addTool(&exportChecklistTool, tms.handleExportChecklist)
+
+	// Generate Gantt tool
+	generateGanttTool := mcp.NewTool("generate_gantt",
+		mcp.WithDescription("Generate a Mermaid Gantt chart of a project's tasks. Tasks run one after another, each starting after its dependencies, with bars as long as their estimated hours (one day without an estimate). Returns the Mermaid source"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
 		),
 	)
-	tms.addTool(&autoUpdateTasksTool, tms.handleAutoUpdateTasks)
+	tms.addTool(&generateGanttTool, tms.handleGenerateGantt)
 
-	// Get tasks needing attention tool
-	getTasksNeedingAttentionTool := mcp.NewTool("get_tasks_needing_attention",
-		mcp.WithDescription("Get tasks that might need manual review (overdue, stale, etc.)"),
+	// Export project tool
+	exportProjectTool := mcp.NewTool("export_project",
+		mcp.WithDescription("Export a project for other tools. The json format holds every field, including IDs, timestamps, choices, dependencies and complexity; csv has one row per task for spreadsheets; html is a standalone progress report with a per-category breakdown and subtask checklists. Returns the export, or writes it to output_path"),
 		mcp.WithString("project_name",
 			mcp.Required(),
 			mcp.Description("Name of the project"),
 		),
-		mcp.WithString("attention_type",
-			mcp.Description("Filter by attention type (completion, stale, overdue, blocked)"),
+		mcp.WithString("format",
+			mcp.Description("Export format (default: json)"),
+			mcp.Enum(exportFormatJSON, exportFormatCSV, exportFormatHTML),
+		),
+		mcp.WithBoolean("flatten_subtasks",
+			mcp.Description("For csv, add a row per subtask after its task, linked by a parent_id column (default: false)"),
+		),
+		mcp.WithString("output_path",
+			mcp.Description("Optional file to write the export to, relative to the project root (e.g. report.html). Absolute paths and paths outside the project root are rejected"),
 		),
 	)
-	tms.addTool(&getTasksNeedingAttentionTool, tms.handleGetTasksNeedingAttention)
+	tms.addTool(&exportProjectTool, tms.handleExportProject)
+
+	// Import project JSON tool
+	importProjectJSONTool := mcp.NewTool("import_project_json",
+		mcp.WithDescription("Create a project from the JSON written by export_project and save it as markdown. Task IDs and dependencies are kept; details the markdown format doesn't record, such as choices on subtasks, are not"),
+		mcp.WithString("json",
+			mcp.Description("The exported project JSON"),
+		),
+		mcp.WithString("input_path",
+			mcp.Description("File holding the exported project JSON, instead of json; relative to the project root. Absolute paths and paths outside the project root are rejected"),
+		),
+		mcp.WithString("project_name",
+			mcp.Description("Name to import the project as (default: the name in the JSON)"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace an existing project of the same name; its previous content is kept in the history (default: false)"),
+		),
+		idempotencyKeyOption(),
+	)
+	tms.addTool(&importProjectJSONTool, tms.withIdempotency("import_project_json", tms.handleImportProjectJSON))
+
+	// Watch project tool
+	watchProjectTool := mcp.NewTool("watch_project",
+		mcp.WithDescription("Subscribe to progress updates for a project. While subscribed, the server sends a notifications/project_progress notification whenever a save changes task statuses, without polling"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+	)
+	tms.addTool(&watchProjectTool, tms.handleWatchProject)
+
+	// Unwatch project tool
+	unwatchProjectTool := mcp.NewTool("unwatch_project",
+		mcp.WithDescription("Stop receiving progress updates for a project"),
+		mcp.WithString("project_name",
+			mcp.Required(),
+			mcp.Description("Name of the project"),
+		),
+	)
+	tms.addTool(&unwatchProjectTool, tms.handleUnwatchProject)
 
 	// Debug info tool
 	debugInfoTool := mcp.NewTool("debug_info",
-		mcp.WithDescription("Get debug information about the task manager configuration"),
+		mcp.WithDescription("Get debug information about the task manager configuration, including how the tasks directory was chosen, any warnings about it and whether it is writable"),
 	)
-	tms.mcpServer.AddTool(debugInfoTool, tms.handleDebugInfo)
+	tms.registerTool(debugInfoTool, tms.handleDebugInfo)
 
 	// Auto-evaluation config tool
 	autoEvalConfigTool := mcp.NewTool("configure_auto_evaluation",
@@ -367,11 +1511,27 @@ func (tms *TaskManagerServer) registerTools() error {
 		mcp.WithBoolean("verbose_logging",
 			mcp.Description("Enable verbose logging"),
 		),
+		mcp.WithNumber("max_cache_entries",
+			mcp.Description("Maximum number of projects whose evaluations are cached (least recently used are evicted)"),
+		),
+		mcp.WithString("summary_mode",
+			mcp.Description("When to append the human-readable evaluation summary to text results: always, changes (only when updates were applied or tasks need attention) or never"),
+			mcp.Enum(SummaryAlways, SummaryChanges, SummaryNever),
+		),
 		mcp.WithBoolean("get_current",
 			mcp.Description("Get current configuration without changes"),
 		),
 	)
-	tms.mcpServer.AddTool(autoEvalConfigTool, tms.handleConfigureAutoEvaluation)
+	tms.registerTool(autoEvalConfigTool, tms.handleConfigureAutoEvaluation)
+
+	// Every tool must be classified so new tools can't silently trigger (or skip) auto-evaluation
+	if unclassified := tms.autoEvalMiddleware.UnclassifiedTools(tms.registeredTools); len(unclassified) > 0 {
+		return fmt.Errorf("tools missing read-only/mutating classification in auto-evaluation middleware: %s", strings.Join(unclassified, ", "))
+	}
+
+	if tms.config.ReadOnly {
+		fmt.Fprintf(os.Stderr, "Read-only mode: %d tools that modify data are disabled (%s)\n", len(tms.disabledTools), strings.Join(tms.disabledTools, ", "))
+	}
 
 	return nil
 }
@@ -383,7 +1543,7 @@ func (tms *TaskManagerServer) handleCreateTaskFile(ctx context.Context, request
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("create_task_file", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("create_task_file", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	// Validate project name
@@ -406,22 +1566,25 @@ func (tms *TaskManagerServer) handleCreateTaskFile(ctx context.Context, request
 	return tms.createSuccessResult(fmt.Sprintf("Created new task file for project '%s' at: %s", projectName, filePath)), nil
 }
 
+// maxTaskSubtasks bounds the subtasks given to a new task
+const maxTaskSubtasks = 50
+
 // handleAddTask handles the add_task tool
 func (tms *TaskManagerServer) handleAddTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("add_task", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("add_task", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	title, err := request.RequireString("title")
 	if err != nil {
-		return tms.createErrorResult("add_task", fmt.Errorf("missing title: %w", err)), nil
+		return tms.createErrorResult("add_task", task.NewError(task.ErrInvalidInput, "missing title: %w", err)), nil
 	}
 
 	description, err := request.RequireString("description")
 	if err != nil {
-		return tms.createErrorResult("add_task", fmt.Errorf("missing description: %w", err)), nil
+		return tms.createErrorResult("add_task", task.NewError(task.ErrInvalidInput, "missing description: %w", err)), nil
 	}
 
 	// Validate inputs
@@ -429,7 +1592,8 @@ func (tms *TaskManagerServer) handleAddTask(ctx context.Context, request mcp.Cal
 		return tms.createErrorResult("add_task", err), nil
 	}
 
-	if err := tms.validateTaskTitle(title); err != nil {
+	title, err = tms.validateTaskTitle(title)
+	if err != nil {
 		return tms.createErrorResult("add_task", err), nil
 	}
 
@@ -444,48 +1608,97 @@ func (tms *TaskManagerServer) handleAddTask(ctx context.Context, request mcp.Cal
 	}
 
 	// Validate subtask count
-	if len(subtasks) > 50 {
-		return tms.createErrorResult("add_task", fmt.Errorf("too many subtasks (max 50, got %d)", len(subtasks))), nil
+	if len(subtasks) > maxTaskSubtasks {
+		return tms.createErrorResult("add_task", task.NewError(task.ErrInvalidInput, "too many subtasks (max %d, got %d)", maxTaskSubtasks, len(subtasks))), nil
 	}
 
-	// Load project safely
-	project, err := tms.safeLoadProject(projectName)
+	estimatedHours, err := tms.parseEstimatedHours(request)
 	if err != nil {
 		return tms.createErrorResult("add_task", err), nil
 	}
 
-	// Check for duplicate task titles
-	for _, existingTask := range project.Tasks {
-		if existingTask.Title == title {
-			return tms.createErrorResult("add_task", fmt.Errorf("task with title '%s' already exists", title)), nil
+	priority := task.DefaultTaskPriority()
+	if value := mcp.ParseString(request, "priority", ""); value != "" {
+		if priority, err = task.ValidateTaskPriority(strings.ToUpper(value)); err != nil {
+			return tms.createErrorResult("add_task", err), nil
+		}
+	}
+
+	var category task.TaskCategory
+	if value := mcp.ParseString(request, "category", ""); value != "" {
+		if category, err = parseCategoryParam(value); err != nil {
+			return tms.createErrorResult("add_task", err), nil
+		}
+		// [GENERAL] is how a task without a category is written
+		if category == task.CategoryGeneral {
+			category = ""
+		}
+	}
+
+	var complexity task.TaskComplexity
+	if value := mcp.ParseString(request, "complexity", ""); value != "" {
+		if complexity, err = task.ValidateTaskComplexity(strings.ToLower(value)); err != nil {
+			return tms.createErrorResult("add_task", err), nil
+		}
+	}
+
+	// Batch mode appends to the file without loading the project; AppendTask
+	// checks for duplicate titles itself
+	batchMode := tms.parseBooleanField(request, "batch_mode", false)
+	if batchMode {
+		if !tms.taskManager.ProjectExists(projectName) {
+			return tms.createErrorResult("add_task", task.NewError(task.ErrNotFound, "project '%s' does not exist. Use create_task_file to create it first", projectName)), nil
+		}
+	} else {
+		// Load project safely
+		project, err := tms.safeLoadProject(projectName)
+		if err != nil {
+			return tms.createErrorResult("add_task", err), nil
+		}
+
+		// Check for duplicate task titles
+		for _, existingTask := range project.Tasks {
+			if existingTask.Title == title {
+				return tms.createErrorResult("add_task", task.NewError(task.ErrConflict, "task with title '%s' already exists", title)), nil
+			}
 		}
 	}
 
 	// Create task
 	newTask := task.Task{
-		Title:       title,
-		Description: description,
-		Status:      task.DefaultTaskStatus(),
-		Priority:    task.DefaultTaskPriority(),
+		Title:          title,
+		Description:    description,
+		Status:         task.DefaultTaskStatus(),
+		Priority:       priority,
+		Category:       category,
+		Complexity:     complexity,
+		EstimatedHours: estimatedHours,
 	}
 
 	// Add subtasks with validation
 	for i, subtaskTitle := range subtasks {
-		if err := task.ValidateTaskTitle(subtaskTitle); err != nil {
+		subtaskTitle, err := task.ValidateTaskTitle(subtaskTitle)
+		if err != nil {
 			return tms.createErrorResult("add_task", fmt.Errorf("invalid subtask %d: %w", i+1, err)), nil
 		}
 
 		subtask := task.Subtask{
 			Title:     subtaskTitle,
 			Status:    task.DefaultTaskStatus(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
 		}
 		newTask.Subtasks = append(newTask.Subtasks, subtask)
 	}
 
 	// Add task to project
-	if err := tms.taskManager.AddTask(projectName, newTask); err != nil {
+	if batchMode {
+		if _, err := tms.taskManager.AppendTask(projectName, newTask); err != nil {
+			return tms.createErrorResult("add_task", err), nil
+		}
+		// Appends don't notify save listeners, so drop cached cross-project tasks here
+		tms.allTasks.invalidate(task.Project{Name: projectName})
+	} else if err := tms.taskManager.AddTask(projectName, newTask); err != nil {
 		return tms.createErrorResult("add_task", err), nil
 	}
 
@@ -503,12 +1716,12 @@ func (tms *TaskManagerServer) handleUpdateTaskStatus(ctx context.Context, reques
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("update_task_status", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("update_task_status", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	taskTitle, err := request.RequireString("task_title")
 	if err != nil {
-		return tms.createErrorResult("update_task_status", fmt.Errorf("missing task_title: %w", err)), nil
+		return tms.createErrorResult("update_task_status", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
 	}
 
 	// Validate inputs
@@ -516,7 +1729,8 @@ func (tms *TaskManagerServer) handleUpdateTaskStatus(ctx context.Context, reques
 		return tms.createErrorResult("update_task_status", err), nil
 	}
 
-	if err := tms.validateTaskTitle(taskTitle); err != nil {
+	taskTitle, err = tms.validateTaskTitle(taskTitle)
+	if err != nil {
 		return tms.createErrorResult("update_task_status", err), nil
 	}
 
@@ -529,75 +1743,44 @@ func (tms *TaskManagerServer) handleUpdateTaskStatus(ctx context.Context, reques
 
 	subtaskTitle := mcp.ParseString(request, "subtask_title", "")
 	if subtaskTitle != "" {
-		if err := tms.validateTaskTitle(subtaskTitle); err != nil {
+		subtaskTitle, err = tms.validateTaskTitle(subtaskTitle)
+		if err != nil {
 			return tms.createErrorResult("update_task_status", fmt.Errorf("invalid subtask title: %w", err)), nil
 		}
 	}
 
-	// Load project safely
-	project, err := tms.safeLoadProject(projectName)
-	if err != nil {
-		return tms.createErrorResult("update_task_status", err), nil
-	}
-
-	// Find and update task/subtask
-	targetTask, _, err := tms.findTaskByTitle(project, taskTitle)
-	if err != nil {
-		return tms.createErrorResult("update_task_status", err), nil
+	// Only tasks record why they are blocked
+	var blockedReason string
+	if value := mcp.ParseString(request, "blocked_reason", ""); value != "" {
+		if status != task.StatusBlocked || subtaskTitle != "" {
+			return tms.createErrorResult("update_task_status", task.NewError(task.ErrInvalidInput, "blocked_reason is only allowed when setting a task to blocked")), nil
+		}
+		if blockedReason, err = task.ValidateBlockedReason(value); err != nil {
+			return tms.createErrorResult("update_task_status", err), nil
+		}
 	}
 
+	// Find and update task/subtask, on a reloaded project if another call saved it meanwhile
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	force := tms.parseBooleanField(request, "force", false)
 	var additionalUpdates []string
-
-	if subtaskTitle == "" {
-		// Update main task status
-		if status == task.StatusDone {
-			// When marking a task as done, check if we should auto-complete subtasks
-			if len(targetTask.Subtasks) > 0 {
-				// Auto-complete all subtasks when main task is marked done
-				for i := range targetTask.Subtasks {
-					if targetTask.Subtasks[i].Status != task.StatusDone {
-						targetTask.Subtasks[i].Status = task.StatusDone
-						targetTask.Subtasks[i].UpdatedAt = time.Now()
-						additionalUpdates = append(additionalUpdates,
-							fmt.Sprintf("Auto-completed subtask '%s'", targetTask.Subtasks[i].Title))
-					}
-				}
-			}
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err := tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
 		}
-		targetTask.Status = status
-		targetTask.UpdatedAt = time.Now()
-	} else {
-		// Find and update subtask
-		subtaskFound := false
-		for i := range targetTask.Subtasks {
-			if targetTask.Subtasks[i].Title == subtaskTitle {
-				targetTask.Subtasks[i].Status = status
-				targetTask.Subtasks[i].UpdatedAt = time.Now()
-				targetTask.UpdatedAt = time.Now()
-
-				// If this was the last subtask to be completed, check if main task should be auto-completed
-				if status == task.StatusDone && targetTask.Status != task.StatusDone {
-					if targetTask.CanBeMarkedComplete() {
-						targetTask.Status = task.StatusDone
-						targetTask.UpdatedAt = time.Now()
-						additionalUpdates = append(additionalUpdates,
-							fmt.Sprintf("Auto-completed main task '%s' (all subtasks done)", targetTask.Title))
-					}
-				}
+		taskTitle = targetTask.Title
 
-				subtaskFound = true
-				break
-			}
+		additionalUpdates, err = targetTask.ApplyStatus(subtaskTitle, status, tms.requireSubtasksDone(force))
+		if err != nil {
+			return err
 		}
-
-		if !subtaskFound {
-			return tms.createErrorResult("update_task_status",
-				fmt.Errorf("subtask '%s' not found in task '%s'", subtaskTitle, taskTitle)), nil
+		if blockedReason != "" {
+			targetTask.BlockedReason = blockedReason
 		}
-	}
-
-	// Save project
-	if err := tms.safeSaveProject(project); err != nil {
+		return nil
+	})
+	if err != nil {
 		return tms.createErrorResult("update_task_status", err), nil
 	}
 
@@ -610,6 +1793,9 @@ func (tms *TaskManagerServer) handleUpdateTaskStatus(ctx context.Context, reques
 	}
 
 	message := fmt.Sprintf("Updated %s '%s' status to %s", target, targetName, status)
+	if blockedReason != "" {
+		message += fmt.Sprintf(" (blocked reason: %s)", blockedReason)
+	}
 	if len(additionalUpdates) > 0 {
 		message += "\nAdditional updates:\n- " + strings.Join(additionalUpdates, "\n- ")
 	}
@@ -617,12 +1803,53 @@ func (tms *TaskManagerServer) handleUpdateTaskStatus(ctx context.Context, reques
 	return tms.createSuccessResult(message), nil
 }
 
+// requireSubtasksDone reports whether marking a task done must fail while it
+// has unfinished subtasks, as in confirm mode unless force is set
+func (tms *TaskManagerServer) requireSubtasksDone(force bool) bool {
+	return !force && tms.config.IncompleteSubtasksMode == incompleteSubtasksConfirm
+}
+
+// resolveProjectRootPath resolves a relative path against the detected
+// project root, or the working directory when no root is found
+func resolveProjectRootPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(projectRootOrWorkingDir(), path)
+}
+
+// resolvePathInProjectRoot resolves a path against the project root like
+// resolveProjectRootPath, but rejects absolute paths and paths that lead
+// outside the root, so tools can't read or write arbitrary files
+func resolvePathInProjectRoot(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", task.NewError(task.ErrInvalidInput, "path %s must be relative to the project root", path)
+	}
+	projectRoot := projectRootOrWorkingDir()
+	fullPath := filepath.Join(projectRoot, path)
+	rel, err := filepath.Rel(projectRoot, fullPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", task.NewError(task.ErrInvalidInput, "path %s is outside the project root", path)
+	}
+	return fullPath, nil
+}
+
+// projectRootOrWorkingDir returns the detected project root, or the working
+// directory when no root is found
+func projectRootOrWorkingDir() string {
+	projectRoot, err := detectProjectRoot()
+	if err != nil {
+		projectRoot, _ = os.Getwd()
+	}
+	return projectRoot
+}
+
 // handleGetNextTask handles the get_next_task tool
 func (tms *TaskManagerServer) handleGetNextTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("get_next_task", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("get_next_task", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	// Validate project name
@@ -631,7 +1858,7 @@ func (tms *TaskManagerServer) handleGetNextTask(ctx context.Context, request mcp
 	}
 
 	// Load project to ensure it exists
-	project, err := tms.safeLoadProject(projectName)
+	project, err := tms.loadProjectForRead(request, projectName)
 	if err != nil {
 		return tms.createErrorResult("get_next_task", err), nil
 	}
@@ -642,25 +1869,37 @@ func (tms *TaskManagerServer) handleGetNextTask(ctx context.Context, request mcp
 	}
 
 	// Get next task
-	task, subtask, err := tms.taskManager.GetNextTask(projectName)
+	nextTask, subtask, err := tms.taskManager.GetNextTask(projectName)
 	if err != nil {
-		if err.Error() == "all tasks completed" {
-			return tms.createSuccessResult("🎉 All tasks are completed!"), nil
+		switch {
+		case errors.Is(err, task.ErrAllCompleted):
+			return tms.createCompletionResult(project), nil
+		case errors.Is(err, task.ErrNoReadyTasks):
+			return tms.createSuccessResult("No tasks are ready to start. All remaining tasks are waiting on dependencies; use get_task_dependencies to see what is blocking them."), nil
 		}
 		return tms.createErrorResult("get_next_task", err), nil
 	}
 
+	// Optionally record that work on the item has started
+	autoStarted := false
+	if tms.parseBooleanField(request, "auto_start", false) {
+		autoStarted, err = tms.startNextWork(projectName, nextTask, subtask)
+		if err != nil {
+			return tms.createErrorResult("get_next_task", err), nil
+		}
+	}
+
 	// Build detailed result
 	result := map[string]interface{}{
 		"project":         projectName,
-		"task_id":         task.ID,
-		"task":            task.Title,
-		"description":     task.Description,
-		"category":        task.Category,
-		"priority":        task.Priority,
-		"status":          task.Status,
-		"complexity":      task.Complexity,
-		"estimated_hours": task.EstimatedHours,
+		"task_id":         nextTask.ID,
+		"task":            nextTask.Title,
+		"description":     nextTask.Description,
+		"category":        nextTask.Category,
+		"priority":        nextTask.Priority,
+		"status":          nextTask.Status,
+		"complexity":      nextTask.Complexity,
+		"estimated_hours": nextTask.EstimatedHours,
 	}
 
 	if subtask != nil {
@@ -672,12 +1911,18 @@ func (tms *TaskManagerServer) handleGetNextTask(ctx context.Context, request mcp
 	}
 
 	// Add progress information using enhanced methods
-	completed, total, percentage := task.GetSubtaskProgress()
+	completed, total, _ := nextTask.GetSubtaskProgress()
 	result["subtasks_total"] = total
 	result["subtasks_completed"] = completed
-	result["progress_percent"] = int(percentage)
-	result["is_fully_completed"] = task.IsFullyCompleted()
-	result["can_be_marked_complete"] = task.CanBeMarkedComplete()
+	if len(nextTask.DoneCriteria) > 0 {
+		met, criteriaTotal, _ := nextTask.GetCriteriaProgress()
+		result["done_criteria_total"] = criteriaTotal
+		result["done_criteria_met"] = met
+	}
+	result["progress_percent"] = int(nextTask.GetTaskCompletion(tms.config.DoneCriteriaWeight))
+	result["is_fully_completed"] = nextTask.IsFullyCompleted()
+	result["can_be_marked_complete"] = nextTask.CanBeMarkedComplete()
+	result["auto_started"] = autoStarted
 
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
@@ -687,33 +1932,192 @@ func (tms *TaskManagerServer) handleGetNextTask(ctx context.Context, request mcp
 	return tms.createSuccessResult(string(resultJSON)), nil
 }
 
+// createCompletionResult reports a finished project with its final stats
+func (tms *TaskManagerServer) createCompletionResult(project *task.Project) *mcp.CallToolResult {
+	result := map[string]interface{}{
+		"project":   project.Name,
+		"completed": true,
+		"message":   tms.config.CompletionMessage,
+		"stats":     project.GetCompletionStats(),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createSuccessResult(tms.config.CompletionMessage)
+	}
+	return tms.createSuccessResult(string(resultJSON))
+}
+
+// startNextWork moves the task (and subtask, if any) returned by get_next_task
+// from todo to in_progress and saves the project. Items that are already in
+// progress are left untouched. The nextTask/subtask copies are updated to match.
+func (tms *TaskManagerServer) startNextWork(projectName string, nextTask *task.Task, subtask *task.Subtask) (bool, error) {
+	changed := false
+	err := tms.updateProject(projectName, func(project *task.Project) error {
+		var target *task.Task
+		for i := range project.Tasks {
+			if project.Tasks[i].ID == nextTask.ID {
+				target = &project.Tasks[i]
+				break
+			}
+		}
+		if target == nil {
+			return task.NewError(task.ErrNotFound, "task '%s' not found in project '%s'", nextTask.Title, project.Name)
+		}
+
+		changed = false
+		if subtask != nil {
+			for i := range target.Subtasks {
+				if target.Subtasks[i].Title == subtask.Title && target.Subtasks[i].Status == task.StatusTodo {
+					target.Subtasks[i].SetStatus(task.StatusInProgress)
+					*subtask = target.Subtasks[i]
+					changed = true
+					break
+				}
+			}
+		}
+
+		// Starting a subtask also starts its parent
+		if target.Status == task.StatusTodo {
+			target.SetStatus(task.StatusInProgress)
+			changed = true
+		}
+
+		if !changed {
+			return task.SkipSave
+		}
+		nextTask.Status = target.Status
+		nextTask.UpdatedAt = target.UpdatedAt
+		return nil
+	})
+	return changed, err
+}
+
 // handleParsePRD handles the parse_prd tool
 func (tms *TaskManagerServer) handleParsePRD(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("parse_prd", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	prdContent, err := request.RequireString("prd_content")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("parse_prd", task.NewError(task.ErrInvalidInput, "missing prd_content: %w", err)), nil
 	}
 
-	// For now, return a placeholder response
-	// This will be implemented in the PRD parsing phase
-	return mcp.NewToolResultText(fmt.Sprintf("PRD parsing for project '%s' is not yet implemented. Content length: %d characters", projectName, len(prdContent))), nil
+	if err := tms.validateProjectName(projectName); err != nil {
+		return tms.createErrorResult("parse_prd", err), nil
+	}
+
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	extracted := task.ParsePRD(prdContent)
+	if len(extracted) == 0 {
+		return tms.createErrorResult("parse_prd", task.NewError(task.ErrInvalidInput, "no tasks found in PRD: use # or ## headings or bulleted requirements")), nil
+	}
+
+	// Titles already in the project are skipped rather than duplicated
+	existingTitles := make(map[string]bool)
+	projectExists := tms.taskManager.ProjectExists(projectName)
+	if projectExists {
+		project, err := tms.safeLoadProject(projectName)
+		if err != nil {
+			return tms.createErrorResult("parse_prd", err), nil
+		}
+		for _, existingTask := range project.Tasks {
+			existingTitles[existingTask.Title] = true
+		}
+	}
+
+	candidates, skipped := validatePRDTasks(extracted)
+	var accepted []task.Task
+	for _, extractedTask := range candidates {
+		if existingTitles[extractedTask.Title] {
+			skipped = append(skipped, map[string]string{"title": extractedTask.Title, "reason": "a task with this title already exists"})
+			continue
+		}
+		extractedTask.Status = task.DefaultTaskStatus()
+		if extractedTask.Priority == "" {
+			extractedTask.Priority = task.DefaultTaskPriority()
+		}
+		accepted = append(accepted, extractedTask)
+	}
+
+	if !dryRun {
+		if !projectExists {
+			if err := tms.taskManager.CreateProject(projectName); err != nil && !errors.Is(err, task.ErrConflict) {
+				return tms.createErrorResult("parse_prd", err), nil
+			}
+		}
+		now := time.Now().UTC()
+		for _, newTask := range accepted {
+			for i := range newTask.Subtasks {
+				newTask.Subtasks[i].CreatedAt = now
+				newTask.Subtasks[i].UpdatedAt = now
+			}
+		}
+		// One load and save for the whole PRD, so a failure leaves none of it added
+		if len(accepted) > 0 {
+			if err := tms.taskManager.AddTasks(projectName, accepted); err != nil {
+				return tms.createErrorResult("parse_prd", err), nil
+			}
+		}
+	}
+
+	subtaskCount := 0
+	tasks := make([]map[string]interface{}, 0, len(accepted))
+	for _, newTask := range accepted {
+		subtaskTitles := make([]string, len(newTask.Subtasks))
+		for i, subtask := range newTask.Subtasks {
+			subtaskTitles[i] = subtask.Title
+		}
+		subtaskCount += len(subtaskTitles)
+
+		entry := map[string]interface{}{
+			"title":    newTask.Title,
+			"priority": newTask.Priority,
+			"subtasks": subtaskTitles,
+		}
+		if newTask.Description != "" {
+			entry["description"] = newTask.Description
+		}
+		if newTask.Category != "" {
+			entry["category"] = newTask.Category
+		}
+		tasks = append(tasks, entry)
+	}
+
+	result := map[string]interface{}{
+		"project":          projectName,
+		"dry_run":          dryRun,
+		"project_created":  !projectExists && !dryRun,
+		"tasks_created":    len(accepted),
+		"subtasks_created": subtaskCount,
+		"tasks":            tasks,
+		"skipped":          skipped,
+	}
+	if dryRun {
+		result["message"] = "Dry run - no tasks were created"
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("parse_prd", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
 }
 
 // handleExpandTask handles the expand_task tool
 func (tms *TaskManagerServer) handleExpandTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("expand_task", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	taskTitle, err := request.RequireString("task_title")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("expand_task", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
 	}
 
 	// Parse new subtasks array
@@ -721,70 +2125,48 @@ func (tms *TaskManagerServer) handleExpandTask(ctx context.Context, request mcp.
 	if subtasksRaw := request.GetArguments()["new_subtasks"]; subtasksRaw != nil {
 		if subtasksList, ok := subtasksRaw.([]interface{}); ok {
 			for _, st := range subtasksList {
-				if stStr, ok := st.(string); ok {
-					newSubtasks = append(newSubtasks, stStr)
-				}
-			}
-		}
-	}
-
-	if len(newSubtasks) == 0 {
-		return mcp.NewToolResultError("At least one new subtask is required"), nil
-	}
-
-	reasoning := mcp.ParseString(request, "reasoning", "")
-
-	// Load the project
-	project, err := tms.taskManager.LoadProject(projectName)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to load project: %v", err)), nil
-	}
-
-	// Find the task to expand
-	taskFound := false
-	for i := range project.Tasks {
-		if project.Tasks[i].Title == taskTitle {
-			taskFound = true
-
-			// Add new subtasks
-			for _, subtaskTitle := range newSubtasks {
-				newSubtask := task.Subtask{
-					Title:     subtaskTitle,
-					Status:    task.DefaultTaskStatus(),
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
-				}
-				project.Tasks[i].Subtasks = append(project.Tasks[i].Subtasks, newSubtask)
-			}
-
-			// Update task timestamp
-			project.Tasks[i].UpdatedAt = time.Now()
-
-			// Add reasoning as a choice if provided
-			if reasoning != "" {
-				choice := task.Choice{
-					ID:         task.GenerateChoiceID(),
-					Question:   "Task breakdown reasoning",
-					Options:    []string{"Accepted breakdown"},
-					Selected:   "Accepted breakdown",
-					Reasoning:  reasoning,
-					CreatedAt:  time.Now(),
-					ResolvedAt: &[]time.Time{time.Now()}[0],
+				if stStr, ok := st.(string); ok {
+					newSubtasks = append(newSubtasks, stStr)
 				}
-				project.Tasks[i].Choices = append(project.Tasks[i].Choices, choice)
 			}
-
-			break
 		}
 	}
 
-	if !taskFound {
-		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskTitle)), nil
+	if len(newSubtasks) == 0 {
+		return tms.createErrorResult("expand_task", task.NewError(task.ErrInvalidInput, "at least one new subtask is required")), nil
 	}
 
-	// Save the updated project
-	if err := tms.taskManager.SaveProject(project); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to save project: %v", err)), nil
+	reasoning := mcp.ParseString(request, "reasoning", "")
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	err = tms.taskManager.UpdateProject(projectName, func(project *task.Project) error {
+		// Find the task to expand
+		targetTask, _, err := tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+		taskTitle = targetTask.Title
+
+		// Add new subtasks
+		appendSubtasks(targetTask, newSubtasks)
+
+		// Add reasoning as a choice if provided
+		if reasoning != "" {
+			choice := task.Choice{
+				ID:         task.GenerateChoiceID(),
+				Question:   "Task breakdown reasoning",
+				Options:    []string{"Accepted breakdown"},
+				Selected:   "Accepted breakdown",
+				Reasoning:  reasoning,
+				CreatedAt:  time.Now().UTC(),
+				ResolvedAt: &[]time.Time{time.Now().UTC()}[0],
+			}
+			targetTask.Choices = append(targetTask.Choices, choice)
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("expand_task", err), nil
 	}
 
 	result := fmt.Sprintf("Expanded task '%s' with %d new subtasks", taskTitle, len(newSubtasks))
@@ -795,12 +2177,28 @@ func (tms *TaskManagerServer) handleExpandTask(ctx context.Context, request mcp.
 	return mcp.NewToolResultText(result), nil
 }
 
+// appendSubtasks adds a todo subtask to the task for each title and updates
+// the task's timestamp
+func appendSubtasks(targetTask *task.Task, titles []string) {
+	now := time.Now().UTC()
+	for _, subtaskTitle := range titles {
+		newSubtask := task.Subtask{
+			Title:     subtaskTitle,
+			Status:    task.DefaultTaskStatus(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		targetTask.Subtasks = append(targetTask.Subtasks, newSubtask)
+	}
+	targetTask.UpdatedAt = now
+}
+
 // handleGenerateTaskFile handles the generate_task_file tool
 func (tms *TaskManagerServer) handleGenerateTaskFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Task title is required
 	taskTitle, err := request.RequireString("task_title")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("generate_task_file", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
 	}
 
 	// Project name is optional - auto-detect if not provided
@@ -808,7 +2206,7 @@ func (tms *TaskManagerServer) handleGenerateTaskFile(ctx context.Context, reques
 	if projectName == "" {
 		detectedProject, err := tms.detectCurrentProject()
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to auto-detect project: %v", err)), nil
+			return tms.createErrorResult("generate_task_file", fmt.Errorf("failed to auto-detect project: %w", err)), nil
 		}
 		projectName = detectedProject
 	}
@@ -821,17 +2219,24 @@ func (tms *TaskManagerServer) handleGenerateTaskFile(ctx context.Context, reques
 
 	templateContent := mcp.ParseString(request, "template_content", "")
 
+	// In strict mode the client must say which kind of file it wants
+	strict := tms.parseBooleanField(request, "strict", tms.config.StrictFileTypes)
+	if strict && strings.TrimSpace(fileType) == "" {
+		return tms.createErrorResult("generate_task_file",
+			task.NewError(task.ErrInvalidInput, "file_type is required in strict mode (e.g., 'go', 'js', 'py', 'md')")), nil
+	}
+
 	// Ensure project exists, create if it doesn't
 	if !tms.taskManager.ProjectExists(projectName) {
 		if err := tms.taskManager.CreateProject(projectName); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create project '%s': %v", projectName, err)), nil
+			return tms.createErrorResult("generate_task_file", fmt.Errorf("failed to create project '%s': %w", projectName, err)), nil
 		}
 	}
 
 	// Load the project to get task details
 	project, err := tms.taskManager.LoadProject(projectName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to load project: %v", err)), nil
+		return tms.createErrorResult("generate_task_file", err), nil
 	}
 
 	// Find the task
@@ -844,7 +2249,7 @@ func (tms *TaskManagerServer) handleGenerateTaskFile(ctx context.Context, reques
 	}
 
 	if targetTask == nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskTitle)), nil
+		return tms.createErrorResult("generate_task_file", task.NewError(task.ErrNotFound, "task '%s' not found", taskTitle)), nil
 	}
 
 	// Auto-detect file type if not provided
@@ -874,28 +2279,17 @@ func (tms *TaskManagerServer) handleGenerateTaskFile(ctx context.Context, reques
 	}
 
 	// Determine the full path - use project root context instead of just project name
-	var fullPath string
-	if filepath.IsAbs(filePath) {
-		fullPath = filePath
-	} else {
-		// Get project root and create file relative to it
-		projectRoot, err := detectProjectRoot()
-		if err != nil {
-			// Fall back to current directory
-			projectRoot, _ = os.Getwd()
-		}
-		fullPath = filepath.Join(projectRoot, filePath)
-	}
+	fullPath := resolveProjectRootPath(filePath)
 
 	// Ensure directory exists
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create directory: %v", err)), nil
+		return tms.createErrorResult("generate_task_file", fmt.Errorf("failed to create directory: %w", err)), nil
 	}
 
 	// Write the file
 	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to write file: %v", err)), nil
+		return tms.createErrorResult("generate_task_file", fmt.Errorf("failed to write file: %w", err)), nil
 	}
 
 	result := fmt.Sprintf("Generated file '%s' for task '%s' in project '%s'", fullPath, taskTitle, projectName)
@@ -927,6 +2321,12 @@ func (tms *TaskManagerServer) generateBasicTemplate(fileType string, t *task.Tas
 	if t.Priority != "" {
 		content.WriteString(fmt.Sprintf("%s Priority: %s\n", commentPrefix, t.Priority))
 	}
+	if len(t.DoneCriteria) > 0 {
+		content.WriteString(fmt.Sprintf("%s Definition of Done:\n", commentPrefix))
+		for _, criterion := range t.DoneCriteria {
+			content.WriteString(fmt.Sprintf("%s   - %s\n", commentPrefix, criterion))
+		}
+	}
 	content.WriteString(fmt.Sprintf("%s Generated: %s\n", commentPrefix, time.Now().Format("2006-01-02 15:04:05")))
 
 	if fileType == "html" || fileType == "xml" {
@@ -986,7 +2386,7 @@ func (tms *TaskManagerServer) generateBasicTemplate(fileType string, t *task.Tas
 func (tms *TaskManagerServer) handleGetTaskDependencies(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("get_task_dependencies", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	taskTitle := mcp.ParseString(request, "task_title", "")
@@ -1002,7 +2402,7 @@ func (tms *TaskManagerServer) handleGetTaskDependencies(ctx context.Context, req
 	// Load the project
 	project, err := tms.taskManager.LoadProject(projectName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to load project: %v", err)), nil
+		return tms.createErrorResult("get_task_dependencies", err), nil
 	}
 
 	if taskTitle != "" {
@@ -1026,7 +2426,7 @@ func (tms *TaskManagerServer) getSpecificTaskDependencies(project *task.Project,
 	}
 
 	if targetTask == nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskTitle)), nil
+		return tms.createErrorResult("get_task_dependencies", task.NewError(task.ErrNotFound, "task '%s' not found", taskTitle)), nil
 	}
 
 	result := map[string]interface{}{
@@ -1132,16 +2532,9 @@ func (tms *TaskManagerServer) getAllTaskDependencies(project *task.Project) (*mc
 func (tms *TaskManagerServer) detectCircularDependencies(project *task.Project) []string {
 	var circular []string
 
-	// Create a map for quick task lookup
-	taskMap := make(map[int]*task.Task)
-	for i := range project.Tasks {
-		taskMap[project.Tasks[i].ID] = &project.Tasks[i]
-	}
-
 	// Check each task for circular dependencies using DFS
 	for _, t := range project.Tasks {
-		visited := make(map[int]bool)
-		if tms.hasCycle(t.ID, taskMap, visited, make(map[int]bool)) {
+		if task.InDependencyCycle(project, t.ID) {
 			circular = append(circular, t.Title)
 		}
 	}
@@ -1149,59 +2542,33 @@ func (tms *TaskManagerServer) detectCircularDependencies(project *task.Project)
 	return circular
 }
 
-// hasCycle checks if there's a cycle starting from the given task ID
-func (tms *TaskManagerServer) hasCycle(taskID int, taskMap map[int]*task.Task, visited, recStack map[int]bool) bool {
-	visited[taskID] = true
-	recStack[taskID] = true
-
-	task, exists := taskMap[taskID]
-	if !exists {
-		return false
-	}
-
-	for _, depID := range task.Dependencies {
-		if !visited[depID] {
-			if tms.hasCycle(depID, taskMap, visited, recStack) {
-				return true
-			}
-		} else if recStack[depID] {
-			return true
-		}
-	}
-
-	recStack[taskID] = false
-	return false
-}
-
 // handleEstimateTaskComplexity handles the estimate_task_complexity tool
 func (tms *TaskManagerServer) handleEstimateTaskComplexity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("estimate_task_complexity", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	taskTitle, err := request.RequireString("task_title")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("estimate_task_complexity", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
 	}
 
 	complexityStr, err := request.RequireString("complexity")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("estimate_task_complexity", task.NewError(task.ErrInvalidInput, "missing complexity: %w", err)), nil
 	}
 
 	// Validate complexity
 	complexity, err := task.ValidateTaskComplexity(complexityStr)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("estimate_task_complexity", err), nil
 	}
 
 	// Parse optional parameters
-	var estimatedHours int
-	if hoursRaw := request.GetArguments()["estimated_hours"]; hoursRaw != nil {
-		if hours, ok := hoursRaw.(float64); ok {
-			estimatedHours = int(hours)
-		}
+	estimatedHours, err := tms.parseEstimatedHours(request)
+	if err != nil {
+		return tms.createErrorResult("estimate_task_complexity", err), nil
 	}
 
 	reasoning := mcp.ParseString(request, "reasoning", "")
@@ -1226,61 +2593,62 @@ func (tms *TaskManagerServer) handleEstimateTaskComplexity(ctx context.Context,
 		}
 	}
 
-	// Load the project
-	project, err := tms.taskManager.LoadProject(projectName)
+	subtaskGate, err := tms.subtaskComplexityGate(request)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to load project: %v", err)), nil
-	}
-
-	// Find the task to update
-	taskFound := false
-	for i := range project.Tasks {
-		if project.Tasks[i].Title == taskTitle {
-			taskFound = true
-
-			// Update task complexity information
-			project.Tasks[i].Complexity = complexity
-			project.Tasks[i].EstimatedHours = estimatedHours
-			project.Tasks[i].UpdatedAt = time.Now()
-
-			// Add complexity analysis as a choice for tracking
-			if reasoning != "" {
-				choice := task.Choice{
-					ID:         task.GenerateChoiceID(),
-					Question:   "Complexity Analysis",
-					Options:    []string{fmt.Sprintf("Complexity: %s (%d hours)", complexity, estimatedHours)},
-					Selected:   fmt.Sprintf("Complexity: %s (%d hours)", complexity, estimatedHours),
-					Reasoning:  reasoning,
-					CreatedAt:  time.Now(),
-					ResolvedAt: &[]time.Time{time.Now()}[0],
+		return tms.createErrorResult("estimate_task_complexity", err), nil
+	}
+	createSubtasks := autoCreateSubtasks && len(suggestedSubtasks) > 0 && complexity.AtLeast(subtaskGate)
+
+	err = tms.taskManager.UpdateProject(projectName, func(project *task.Project) error {
+		// Find the task to update
+		taskFound := false
+		for i := range project.Tasks {
+			if project.Tasks[i].Title == taskTitle {
+				taskFound = true
+
+				// Update task complexity information
+				project.Tasks[i].Complexity = complexity
+				project.Tasks[i].EstimatedHours = estimatedHours
+				project.Tasks[i].UpdatedAt = time.Now().UTC()
+
+				// Add complexity analysis as a choice for tracking
+				if reasoning != "" {
+					choice := task.Choice{
+						ID:         task.GenerateChoiceID(),
+						Question:   "Complexity Analysis",
+						Options:    []string{fmt.Sprintf("Complexity: %s (%d hours)", complexity, estimatedHours)},
+						Selected:   fmt.Sprintf("Complexity: %s (%d hours)", complexity, estimatedHours),
+						Reasoning:  reasoning,
+						CreatedAt:  time.Now().UTC(),
+						ResolvedAt: &[]time.Time{time.Now().UTC()}[0],
+					}
+					project.Tasks[i].Choices = append(project.Tasks[i].Choices, choice)
 				}
-				project.Tasks[i].Choices = append(project.Tasks[i].Choices, choice)
-			}
 
-			// Auto-create subtasks if requested and complexity is high
-			if autoCreateSubtasks && len(suggestedSubtasks) > 0 && (complexity == task.ComplexityHigh || complexity == task.ComplexityMedium) {
-				for _, subtaskTitle := range suggestedSubtasks {
-					newSubtask := task.Subtask{
-						Title:     subtaskTitle,
-						Status:    task.DefaultTaskStatus(),
-						CreatedAt: time.Now(),
-						UpdatedAt: time.Now(),
+				// Auto-create subtasks if requested and complexity passes the gate
+				if createSubtasks {
+					for _, subtaskTitle := range suggestedSubtasks {
+						newSubtask := task.Subtask{
+							Title:     subtaskTitle,
+							Status:    task.DefaultTaskStatus(),
+							CreatedAt: time.Now().UTC(),
+							UpdatedAt: time.Now().UTC(),
+						}
+						project.Tasks[i].Subtasks = append(project.Tasks[i].Subtasks, newSubtask)
 					}
-					project.Tasks[i].Subtasks = append(project.Tasks[i].Subtasks, newSubtask)
 				}
-			}
 
-			break
+				break
+			}
 		}
-	}
-
-	if !taskFound {
-		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskTitle)), nil
-	}
 
-	// Save the updated project
-	if err := tms.taskManager.SaveProject(project); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to save project: %v", err)), nil
+		if !taskFound {
+			return task.NewError(task.ErrNotFound, "task '%s' not found", taskTitle)
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("estimate_task_complexity", err), nil
 	}
 
 	// Build result message
@@ -1288,8 +2656,11 @@ func (tms *TaskManagerServer) handleEstimateTaskComplexity(ctx context.Context,
 	if estimatedHours > 0 {
 		result += fmt.Sprintf(" (%d hours)", estimatedHours)
 	}
-	if autoCreateSubtasks && len(suggestedSubtasks) > 0 {
+	if createSubtasks {
 		result += fmt.Sprintf(", created %d subtasks", len(suggestedSubtasks))
+	} else if autoCreateSubtasks && len(suggestedSubtasks) > 0 {
+		result += fmt.Sprintf(", skipped creating %d subtasks: %s complexity is below the %s gate",
+			len(suggestedSubtasks), complexity, subtaskGate)
 	}
 
 	return mcp.NewToolResultText(result), nil
@@ -1299,10 +2670,11 @@ func (tms *TaskManagerServer) handleEstimateTaskComplexity(ctx context.Context,
 func (tms *TaskManagerServer) handleSuggestNextActions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return tms.createErrorResult("suggest_next_actions", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	focusArea := mcp.ParseString(request, "focus_area", "")
+	focusAssignee := strings.TrimSpace(mcp.ParseString(request, "focus_assignee", ""))
 
 	// Parse max_suggestions
 	maxSuggestions := 5
@@ -1320,14 +2692,24 @@ func (tms *TaskManagerServer) handleSuggestNextActions(ctx context.Context, requ
 		}
 	}
 
+	includeResolvedChoices := tms.parseBooleanField(request, "include_resolved_choices", false)
+
 	// Load the project
-	project, err := tms.taskManager.LoadProject(projectName)
+	project, err := tms.loadProjectForRead(request, projectName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to load project: %v", err)), nil
+		return tms.createErrorResult("suggest_next_actions", err), nil
 	}
 
 	// Analyze project and generate suggestions
-	suggestions := tms.analyzeProjectAndSuggest(project, focusArea, maxSuggestions, includeBlocked)
+	suggestions := tms.analyzeProjectAndSuggest(project, focusArea, focusAssignee, maxSuggestions, includeBlocked, includeResolvedChoices)
+
+	// Fall back to unfiltered suggestions when the focus area matched nothing,
+	// rather than returning an unexplained empty list
+	focusAreaMatched := true
+	if focusArea != "" && len(suggestions) == 0 {
+		focusAreaMatched = false
+		suggestions = tms.analyzeProjectAndSuggest(project, "", focusAssignee, maxSuggestions, includeBlocked, includeResolvedChoices)
+	}
 
 	// Get comprehensive progress summary including subtasks
 	progressSummary := project.GetProgressSummary()
@@ -1340,13 +2722,21 @@ func (tms *TaskManagerServer) handleSuggestNextActions(ctx context.Context, requ
 		"suggestions": suggestions,
 		"summary":     progressSummary,
 	}
+	if focusAssignee != "" {
+		result["focus_assignee"] = focusAssignee
+	}
+
+	if !focusAreaMatched {
+		result["focus_area_matched"] = false
+		result["note"] = fmt.Sprintf("No actionable tasks matched focus area '%s'; showing top suggestions across all areas instead", focusArea)
+	}
 
 	resultJSON, _ := json.Marshal(result)
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
 // analyzeProjectAndSuggest analyzes the project state and generates suggestions
-func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, focusArea string, maxSuggestions int, includeBlocked bool) []map[string]interface{} {
+func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, focusArea, focusAssignee string, maxSuggestions int, includeBlocked, includeResolvedChoices bool) []map[string]interface{} {
 	var suggestions []map[string]interface{}
 
 	// Create task map for dependency lookup
@@ -1355,6 +2745,8 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 		taskMap[project.Tasks[i].ID] = &project.Tasks[i]
 	}
 
+	weights := tms.config.GetSuggestionWeights()
+
 	// Analyze each task
 	for _, t := range project.Tasks {
 		// Skip completed tasks
@@ -1368,7 +2760,12 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 		}
 
 		// Filter by focus area if specified
-		if focusArea != "" && string(t.Category) != focusArea {
+		if !matchesFocusArea(&t, focusArea) {
+			continue
+		}
+
+		// Filter by assignee if specified
+		if focusAssignee != "" && !strings.EqualFold(t.Assignee, focusAssignee) {
 			continue
 		}
 
@@ -1376,7 +2773,7 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 		isReady := tms.isTaskReady(&t, taskMap)
 
 		// Calculate suggestion score
-		score := tms.calculateTaskScore(&t, isReady)
+		score := tms.calculateTaskScore(&t, isReady, weights)
 
 		// Create suggestion
 		suggestion := map[string]interface{}{
@@ -1391,6 +2788,12 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 			"score":           score,
 			"reason":          tms.generateSuggestionReason(&t, isReady),
 		}
+		if t.Assignee != "" {
+			suggestion["assignee"] = t.Assignee
+		}
+		if t.BlockedReason != "" {
+			suggestion["blocked_reason"] = t.BlockedReason
+		}
 
 		// Add subtask information
 		if len(t.Subtasks) > 0 {
@@ -1420,6 +2823,13 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 			suggestion["pending_choices"] = pendingChoices
 		}
 
+		// Add past decisions when requested
+		if includeResolvedChoices {
+			if resolved := t.ResolvedChoices(); len(resolved) > 0 {
+				suggestion["resolved_choices"] = resolved
+			}
+		}
+
 		suggestions = append(suggestions, suggestion)
 	}
 
@@ -1440,6 +2850,27 @@ func (tms *TaskManagerServer) analyzeProjectAndSuggest(project *task.Project, fo
 	return suggestions
 }
 
+// matchesFocusArea reports whether a task belongs to the requested focus area,
+// either its category or one of its tags. Categories match case-insensitively
+// with or without brackets, so "MVP", "mvp" and "[MVP]" are equivalent. An
+// empty focus area matches everything.
+func matchesFocusArea(t *task.Task, focusArea string) bool {
+	focus := normalizeFocusArea(focusArea)
+	if focus == "" {
+		return true
+	}
+
+	return normalizeFocusArea(string(t.EffectiveCategory())) == focus || t.HasTag(focus)
+}
+
+// normalizeFocusArea strips surrounding brackets and whitespace and lowercases the value
+func normalizeFocusArea(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
 // isTaskReady checks if a task is ready to be worked on (all dependencies completed)
 func (tms *TaskManagerServer) isTaskReady(t *task.Task, taskMap map[int]*task.Task) bool {
 	for _, depID := range t.Dependencies {
@@ -1453,46 +2884,35 @@ func (tms *TaskManagerServer) isTaskReady(t *task.Task, taskMap map[int]*task.Ta
 }
 
 // calculateTaskScore calculates a priority score for task suggestions
-func (tms *TaskManagerServer) calculateTaskScore(t *task.Task, isReady bool) int {
-	score := 0
-
+func (tms *TaskManagerServer) calculateTaskScore(t *task.Task, isReady bool, weights SuggestionWeights) int {
 	// Base score from priority
-	switch t.Priority {
-	case task.PriorityP0:
-		score += 100
-	case task.PriorityP1:
-		score += 75
-	case task.PriorityP2:
-		score += 50
-	case task.PriorityP3:
-		score += 25
-	}
+	score := weights.priority(t.Priority)
 
-	// Bonus for ready tasks
+	// Bonus for ready tasks, penalty for tasks waiting on dependencies
 	if isReady {
-		score += 50
+		score += weights.Ready
 	} else {
-		score -= 25 // Penalty for blocked tasks
+		score += weights.Blocked
 	}
 
 	// Bonus for tasks in progress
 	if t.Status == task.StatusInProgress {
-		score += 30
+		score += weights.InProgress
 	}
 
 	// Bonus for tasks with pending choices (need attention)
 	if t.HasPendingChoices() {
-		score += 20
+		score += weights.PendingChoices
 	}
 
 	// Penalty for high complexity (might want to break down first)
 	if t.Complexity == task.ComplexityHigh {
-		score -= 10
+		score += weights.HighComplexity
 	}
 
 	// Bonus for tasks with subtasks (shows planning)
 	if len(t.Subtasks) > 0 {
-		score += 10
+		score += weights.HasSubtasks
 	}
 
 	return score
@@ -1549,12 +2969,13 @@ func (tms *TaskManagerServer) validateProjectName(projectName string) error {
 	return nil
 }
 
-// validateTaskTitle validates task title
-func (tms *TaskManagerServer) validateTaskTitle(title string) error {
-	if err := task.ValidateTaskTitle(title); err != nil {
-		return fmt.Errorf("invalid task title: %w", err)
+// validateTaskTitle validates task title and returns it trimmed
+func (tms *TaskManagerServer) validateTaskTitle(title string) (string, error) {
+	title, err := task.ValidateTaskTitle(title)
+	if err != nil {
+		return "", fmt.Errorf("invalid task title: %w", err)
 	}
-	return nil
+	return title, nil
 }
 
 // validateTaskDescription validates task description
@@ -1572,7 +2993,7 @@ func (tms *TaskManagerServer) safeLoadProject(projectName string) (*task.Project
 	}
 
 	if !tms.taskManager.ProjectExists(projectName) {
-		return nil, fmt.Errorf("project '%s' does not exist. Use create_task_file to create it first", projectName)
+		return nil, task.NewError(task.ErrNotFound, "project '%s' does not exist. Use create_task_file to create it first", projectName)
 	}
 
 	project, err := tms.taskManager.LoadProject(projectName)
@@ -1583,40 +3004,68 @@ func (tms *TaskManagerServer) safeLoadProject(projectName string) (*task.Project
 	return project, nil
 }
 
-// safeSaveProject safely saves a project with proper error handling
-func (tms *TaskManagerServer) safeSaveProject(project *task.Project) error {
-	if project == nil {
-		return fmt.Errorf("cannot save nil project")
+// loadProjectForRead loads a project for a read tool. When auto_create is enabled
+// (per call, or by default through AUTO_CREATE_PROJECTS) a missing project is
+// created empty instead of returning a not-found error.
+func (tms *TaskManagerServer) loadProjectForRead(request mcp.CallToolRequest, projectName string) (*task.Project, error) {
+	autoCreate := tms.parseBooleanField(request, "auto_create", tms.config.AutoCreateProjects) && !tms.config.ReadOnly
+
+	if autoCreate && tms.validateProjectName(projectName) == nil && !tms.taskManager.ProjectExists(projectName) {
+		// Another call may have created it in the meantime, which is fine
+		if err := tms.taskManager.CreateProject(projectName); err != nil && !errors.Is(err, task.ErrConflict) {
+			return nil, fmt.Errorf("failed to auto-create project '%s': %w", projectName, err)
+		}
 	}
 
-	if err := tms.validateProjectName(project.Name); err != nil {
+	return tms.safeLoadProject(projectName)
+}
+
+// autoCreateOption declares the optional auto_create parameter for read tools
+func autoCreateOption() mcp.ToolOption {
+	return mcp.WithBoolean("auto_create",
+		mcp.Description("If true, create an empty project when it does not exist instead of returning an error (default: AUTO_CREATE_PROJECTS, normally false)"),
+	)
+}
+
+// updateProject applies modify to a freshly loaded project and saves it,
+// reloading and applying it again when the project was saved in between
+func (tms *TaskManagerServer) updateProject(projectName string, modify func(project *task.Project) error) error {
+	if err := tms.validateProjectName(projectName); err != nil {
 		return err
 	}
 
-	if err := tms.taskManager.SaveProject(project); err != nil {
-		return fmt.Errorf("failed to save project '%s': %w", project.Name, err)
+	if !tms.taskManager.ProjectExists(projectName) {
+		return task.NewError(task.ErrNotFound, "project '%s' does not exist. Use create_task_file to create it first", projectName)
 	}
 
-	return nil
+	return tms.taskManager.UpdateProject(projectName, modify)
 }
 
 // findTaskByTitle finds a task by title with proper error handling
 func (tms *TaskManagerServer) findTaskByTitle(project *task.Project, taskTitle string) (*task.Task, int, error) {
+	return tms.resolveTaskTitle(project, taskTitle, false)
+}
+
+// resolveTaskTitle finds a task by exact title or, when partial is set, by a
+// unique case-insensitive substring of its title
+func (tms *TaskManagerServer) resolveTaskTitle(project *task.Project, taskTitle string, partial bool) (*task.Task, int, error) {
 	if project == nil {
 		return nil, -1, fmt.Errorf("project is nil")
 	}
 
-	if err := tms.validateTaskTitle(taskTitle); err != nil {
+	taskTitle, err := tms.validateTaskTitle(taskTitle)
+	if err != nil {
 		return nil, -1, err
 	}
 
-	for i := range project.Tasks {
-		if project.Tasks[i].Title == taskTitle {
-			return &project.Tasks[i], i, nil
-		}
-	}
+	return task.ResolveTaskTitle(project, taskTitle, partial)
+}
 
-	return nil, -1, fmt.Errorf("task '%s' not found in project '%s'", taskTitle, project.Name)
+// partialMatchOption declares the optional partial_match parameter for title-based tools
+func partialMatchOption() mcp.ToolOption {
+	return mcp.WithBoolean("partial_match",
+		mcp.Description("If true, task_title may be a unique substring of the task title; ambiguous matches return the candidates (default: false)"),
+	)
 }
 
 // parseSubtasks safely parses subtasks array from request
@@ -1626,17 +3075,17 @@ func (tms *TaskManagerServer) parseSubtasks(request mcp.CallToolRequest, fieldNa
 	if subtasksRaw := request.GetArguments()[fieldName]; subtasksRaw != nil {
 		subtasksList, ok := subtasksRaw.([]interface{})
 		if !ok {
-			return nil, fmt.Errorf("field '%s' must be an array", fieldName)
+			return nil, task.NewError(task.ErrInvalidInput, "field '%s' must be an array", fieldName)
 		}
 
 		for i, st := range subtasksList {
 			stStr, ok := st.(string)
 			if !ok {
-				return nil, fmt.Errorf("subtask at index %d must be a string", i)
+				return nil, task.NewError(task.ErrInvalidInput, "subtask at index %d must be a string", i)
 			}
 
 			if strings.TrimSpace(stStr) == "" {
-				return nil, fmt.Errorf("subtask at index %d cannot be empty", i)
+				return nil, task.NewError(task.ErrInvalidInput, "subtask at index %d cannot be empty", i)
 			}
 
 			subtasks = append(subtasks, strings.TrimSpace(stStr))
@@ -1646,6 +3095,31 @@ func (tms *TaskManagerServer) parseSubtasks(request mcp.CallToolRequest, fieldNa
 	return subtasks, nil
 }
 
+// parseStringArray safely parses an optional array of non-empty strings from request
+func (tms *TaskManagerServer) parseStringArray(request mcp.CallToolRequest, fieldName string) ([]string, error) {
+	var values []string
+
+	if raw := request.GetArguments()[fieldName]; raw != nil {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, task.NewError(task.ErrInvalidInput, "field '%s' must be an array", fieldName)
+		}
+
+		for i, item := range list {
+			value, ok := item.(string)
+			if !ok {
+				return nil, task.NewError(task.ErrInvalidInput, "%s[%d] must be a string", fieldName, i)
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, task.NewError(task.ErrInvalidInput, "%s[%d] cannot be empty", fieldName, i)
+			}
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+
+	return values, nil
+}
+
 // parseBooleanField safely parses boolean field from request
 func (tms *TaskManagerServer) parseBooleanField(request mcp.CallToolRequest, fieldName string, defaultValue bool) bool {
 	if fieldRaw := request.GetArguments()[fieldName]; fieldRaw != nil {
@@ -1666,15 +3140,81 @@ func (tms *TaskManagerServer) parseNumberField(request mcp.CallToolRequest, fiel
 	return defaultValue
 }
 
-// logError logs errors for debugging (in a real implementation, you might want structured logging)
+// parseEstimatedHours parses the optional estimated_hours field. Fractional
+// hours are rounded to the nearest hour, with any positive estimate counting
+// as at least one hour; negative or unreasonably large values are rejected.
+func (tms *TaskManagerServer) parseEstimatedHours(request mcp.CallToolRequest) (int, error) {
+	raw := request.GetArguments()["estimated_hours"]
+	if raw == nil {
+		return 0, nil
+	}
+
+	value, ok := raw.(float64)
+	if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, task.NewError(task.ErrInvalidInput, "estimated_hours must be a number")
+	}
+	if value < 0 {
+		return 0, task.NewError(task.ErrInvalidInput, "estimated_hours cannot be negative (got %g)", value)
+	}
+
+	hours := int(math.Round(value))
+	if value > 0 && hours == 0 {
+		hours = 1
+	}
+	if !task.IsValidEstimatedHours(hours) {
+		return 0, task.NewError(task.ErrInvalidInput, "estimated_hours too large (max 1000, got %g)", value)
+	}
+
+	return hours, nil
+}
+
+// logError logs errors for debugging. It writes to stderr because stdout carries
+// the JSON-RPC stream under the stdio transport.
 func (tms *TaskManagerServer) logError(operation string, err error) {
-	fmt.Printf("ERROR [%s]: %v\n", operation, err)
+	fmt.Fprintf(os.Stderr, "ERROR [%s]: %v\n", operation, err)
+}
+
+// Error categories reported in structured error results
+const (
+	ErrorCategoryValidation = "validation"
+	ErrorCategoryNotFound   = "not_found"
+	ErrorCategoryConflict   = "conflict"
+	ErrorCategoryInternal   = "internal"
+)
+
+// classifyError maps an error to a category clients can act on programmatically
+func classifyError(err error) string {
+	switch {
+	case errors.Is(err, task.ErrInvalidInput):
+		return ErrorCategoryValidation
+	case errors.Is(err, task.ErrNotFound):
+		return ErrorCategoryNotFound
+	case errors.Is(err, task.ErrConflict):
+		return ErrorCategoryConflict
+	default:
+		return ErrorCategoryInternal
+	}
 }
 
-// createErrorResult creates a standardized error result
+// createErrorResult creates a standardized error result. The text is a JSON
+// payload carrying the message plus a category (validation, not_found,
+// conflict or internal) so clients can decide whether to fix input or retry.
 func (tms *TaskManagerServer) createErrorResult(operation string, err error) *mcp.CallToolResult {
 	tms.logError(operation, err)
-	return mcp.NewToolResultError(fmt.Sprintf("%s failed: %v", operation, err))
+
+	category := classifyError(err)
+	payload := map[string]interface{}{
+		"error":     fmt.Sprintf("%s failed: %v", operation, err),
+		"operation": operation,
+		"category":  category,
+		"retryable": category == ErrorCategoryInternal,
+	}
+
+	payloadJSON, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s failed: %v", operation, err))
+	}
+	return mcp.NewToolResultError(string(payloadJSON))
 }
 
 // createSuccessResult creates a standardized success result
@@ -1686,13 +3226,40 @@ func (tms *TaskManagerServer) createSuccessResult(message string) *mcp.CallToolR
 func (tms *TaskManagerServer) addSimpleTool(name, description string, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), params ...mcp.ToolOption) {
 	tool := mcp.NewTool(name, append([]mcp.ToolOption{mcp.WithDescription(description)}, params...)...)
 	wrappedHandler := tms.autoEvalMiddleware.WrapHandler(name, handler)
-	tms.mcpServer.AddTool(tool, wrappedHandler)
+	tms.registerTool(tool, wrappedHandler)
 }
 
 // addTool wraps tool registration with auto-evaluation middleware
 func (tms *TaskManagerServer) addTool(tool *mcp.Tool, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
 	wrappedHandler := tms.autoEvalMiddleware.WrapHandler(tool.Name, handler)
-	tms.mcpServer.AddTool(*tool, wrappedHandler)
+	tms.registerTool(*tool, wrappedHandler)
+}
+
+// registerTool adds a tool to the MCP server and records its name so that
+// every registered tool can be checked for an auto-evaluation classification.
+// In read-only mode, tools that would write to disk are left out.
+func (tms *TaskManagerServer) registerTool(tool mcp.Tool, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tms.registeredTools = append(tms.registeredTools, tool.Name)
+
+	if tms.config.ReadOnly && !tms.allowedInReadOnlyMode(tool.Name) {
+		tms.disabledTools = append(tms.disabledTools, tool.Name)
+		return
+	}
+
+	tms.mcpServer.AddTool(tool, handler)
+}
+
+// readOnlyInstructions tells clients why tools that modify data are missing
+const readOnlyInstructions = "This task manager is running in read-only mode (READ_ONLY=true). Only tools that read project state are available; tools that create or modify tasks are disabled and calls to them are rejected."
+
+// toolsWritingFiles don't change task state but still write to disk
+var toolsWritingFiles = map[string]bool{
+	"generate_task_file": true,
+}
+
+// allowedInReadOnlyMode reports whether a tool may be exposed in read-only mode
+func (tms *TaskManagerServer) allowedInReadOnlyMode(toolName string) bool {
+	return tms.autoEvalMiddleware.IsReadOnly(toolName) && !toolsWritingFiles[toolName]
 }
 
 // Helper for common parameter patterns
@@ -1720,12 +3287,12 @@ func (tms *TaskManagerServer) detectCurrentProject() (string, error) {
 	currentDirName := filepath.Base(cwd)
 
 	// Check if a project with the current directory name exists
-	if tms.taskManager.ProjectExists(currentDirName) {
+	if tms.taskManager.ProjectExists(currentDirName) && !tms.isExcludedEmptyProject(currentDirName) {
 		return currentDirName, nil
 	}
 
 	// Try to find any existing projects
-	projects, err := tms.taskManager.ListProjects()
+	projects, err := tms.listProjects(tms.config.ExcludeEmptyProjects)
 	if err == nil && len(projects) > 0 {
 		// Return the most recently used project (first in list)
 		return projects[0], nil
@@ -1957,7 +3524,7 @@ func (tms *TaskManagerServer) handleAutoUpdateTasks(ctx context.Context, request
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("auto_update_tasks", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("auto_update_tasks", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	// Validate project name
@@ -1968,20 +3535,27 @@ func (tms *TaskManagerServer) handleAutoUpdateTasks(ctx context.Context, request
 	// Parse dry_run parameter
 	dryRun := tms.parseBooleanField(request, "dry_run", false)
 
-	// Load project safely
-	project, err := tms.safeLoadProject(projectName)
+	// Perform auto-updates, saving them unless this is a dry run
+	var taskCount int
+	var updates []string
+	hasChanges := false
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		taskCount = len(project.Tasks)
+		updates, hasChanges = task.AutoUpdateTaskStatuses(project, tms.autoEvalMiddleware.currentConfig().CompletionRules)
+		if dryRun || !hasChanges {
+			return task.SkipSave
+		}
+		return nil
+	})
 	if err != nil {
 		return tms.createErrorResult("auto_update_tasks", err), nil
 	}
 
 	// Check if project has any tasks
-	if len(project.Tasks) == 0 {
+	if taskCount == 0 {
 		return tms.createSuccessResult("No tasks found in project to update."), nil
 	}
 
-	// Perform auto-updates
-	updates, hasChanges := task.AutoUpdateTaskStatuses(project)
-
 	if !hasChanges {
 		return tms.createSuccessResult("No automatic updates needed. All tasks are up to date."), nil
 	}
@@ -1995,10 +3569,6 @@ func (tms *TaskManagerServer) handleAutoUpdateTasks(ctx context.Context, request
 	}
 
 	if !dryRun {
-		// Save the updated project
-		if err := tms.safeSaveProject(project); err != nil {
-			return tms.createErrorResult("auto_update_tasks", err), nil
-		}
 		result["saved"] = true
 	} else {
 		result["saved"] = false
@@ -2018,7 +3588,7 @@ func (tms *TaskManagerServer) handleGetTasksNeedingAttention(ctx context.Context
 	// Validate required parameters
 	projectName, err := request.RequireString("project_name")
 	if err != nil {
-		return tms.createErrorResult("get_tasks_needing_attention", fmt.Errorf("missing project_name: %w", err)), nil
+		return tms.createErrorResult("get_tasks_needing_attention", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
 	}
 
 	// Validate project name
@@ -2029,13 +3599,13 @@ func (tms *TaskManagerServer) handleGetTasksNeedingAttention(ctx context.Context
 	attentionTypeFilter := mcp.ParseString(request, "attention_type", "")
 
 	// Load project safely
-	project, err := tms.safeLoadProject(projectName)
+	project, err := tms.loadProjectForRead(request, projectName)
 	if err != nil {
 		return tms.createErrorResult("get_tasks_needing_attention", err), nil
 	}
 
 	// Get tasks needing attention
-	attention := task.GetTasksNeedingAttention(project)
+	attention := task.GetTasksNeedingAttention(project, tms.autoEvalMiddleware.currentConfig().CompletionRules)
 
 	// Filter by attention type if specified
 	if attentionTypeFilter != "" {
@@ -2067,6 +3637,10 @@ func (tms *TaskManagerServer) handleGetTasksNeedingAttention(ctx context.Context
 			"severity":    att.Severity,
 		}
 
+		if att.Type == task.AttentionTypeBlocked && att.Task.BlockedReason != "" {
+			item["blocked_reason"] = att.Task.BlockedReason
+		}
+
 		if att.Subtask != nil {
 			item["subtask_title"] = att.Subtask.Title
 			item["subtask_status"] = att.Subtask.Status
@@ -2090,6 +3664,120 @@ func (tms *TaskManagerServer) handleGetTasksNeedingAttention(ctx context.Context
 	return tms.createSuccessResult(string(resultJSON)), nil
 }
 
+// handleGetTaskTimeline handles the get_task_timeline tool
+func (tms *TaskManagerServer) handleGetTaskTimeline(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("get_task_timeline", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("get_task_timeline", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("get_task_timeline", err), nil
+	}
+
+	targetTask, _, err := tms.findTaskByTitle(project, taskTitle)
+	if err != nil {
+		return tms.createErrorResult("get_task_timeline", err), nil
+	}
+
+	events := targetTask.Timeline()
+	result := map[string]interface{}{
+		"project":     projectName,
+		"task_id":     targetTask.ID,
+		"task":        targetTask.Title,
+		"status":      targetTask.Status,
+		"event_count": len(events),
+		"events":      events,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("get_task_timeline", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleValidateProject handles the validate_project tool
+func (tms *TaskManagerServer) handleValidateProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("validate_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("validate_project", err), nil
+	}
+
+	issues := task.ValidateProject(project)
+	if issues == nil {
+		issues = []task.ValidationIssue{}
+	}
+
+	result := map[string]interface{}{
+		"project":     projectName,
+		"valid":       len(issues) == 0,
+		"issue_count": len(issues),
+		"issues":      issues,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("validate_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleRecentActivity handles the recent_activity tool
+func (tms *TaskManagerServer) handleRecentActivity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName := mcp.ParseString(request, "project_name", "")
+	limit := tms.parseNumberField(request, "limit", 10)
+	if limit <= 0 {
+		return tms.createErrorResult("recent_activity", task.NewError(task.ErrInvalidInput, "limit must be positive, got %d", limit)), nil
+	}
+
+	var projectNames []string
+	if projectName != "" {
+		if _, err := tms.safeLoadProject(projectName); err != nil {
+			return tms.createErrorResult("recent_activity", err), nil
+		}
+		projectNames = []string{projectName}
+	} else {
+		projects, err := tms.taskManager.ListProjects()
+		if err != nil {
+			return tms.createErrorResult("recent_activity", err), nil
+		}
+		projectNames = projects
+	}
+
+	items, err := tms.taskManager.RecentActivity(projectNames, limit)
+	if err != nil {
+		return tms.createErrorResult("recent_activity", err), nil
+	}
+
+	result := map[string]interface{}{
+		"projects": projectNames,
+		"limit":    limit,
+		"count":    len(items),
+		"items":    items,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("recent_activity", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
 // handleDebugInfo handles the debug_info tool
 func (tms *TaskManagerServer) handleDebugInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	cwd, _ := os.Getwd()
@@ -2110,6 +3798,10 @@ func (tms *TaskManagerServer) handleDebugInfo(ctx context.Context, request mcp.C
 		"path_info": map[string]interface{}{
 			"tasks_dir_is_absolute": filepath.IsAbs(tms.taskManager.GetTasksDir()),
 		},
+		"tasks_directory_resolution": tms.tasksDir,
+		"read_only":                  tms.config.ReadOnly,
+		"disabled_tools":             tms.disabledTools,
+		"project_cache":              tms.taskManager.CacheStats(),
 	}
 
 	if projectRootErr != nil {
@@ -2119,11 +3811,17 @@ func (tms *TaskManagerServer) handleDebugInfo(ctx context.Context, request mcp.C
 	// Check if tasks directory exists and is writable
 	tasksDir := tms.taskManager.GetTasksDir()
 	if stat, err := os.Stat(tasksDir); err == nil {
-		debugInfo["tasks_directory_status"] = map[string]interface{}{
+		status := map[string]interface{}{
 			"exists":      true,
 			"is_dir":      stat.IsDir(),
 			"permissions": stat.Mode().String(),
+			"writable":    true,
+		}
+		if err := tms.taskManager.Validate(); err != nil {
+			status["writable"] = false
+			status["error"] = err.Error()
 		}
+		debugInfo["tasks_directory_status"] = status
 	} else {
 		debugInfo["tasks_directory_status"] = map[string]interface{}{
 			"exists": false,
@@ -2145,71 +3843,103 @@ func (tms *TaskManagerServer) handleConfigureAutoEvaluation(ctx context.Context,
 
 	// If get_current is true, just return current configuration
 	if getCurrent, ok := args["get_current"].(bool); ok && getCurrent {
-		currentConfig := map[string]interface{}{
-			"enabled":              tms.autoEvalMiddleware.config.Enabled,
-			"cache_timeout":        tms.autoEvalMiddleware.config.CacheTimeout.String(),
-			"max_concurrent":       tms.autoEvalMiddleware.config.MaxConcurrent,
-			"skip_read_only_tools": tms.autoEvalMiddleware.config.SkipReadOnlyTools,
-			"verbose_logging":      tms.autoEvalMiddleware.config.VerboseLogging,
-		}
-
 		resultJSON, _ := json.Marshal(map[string]interface{}{
-			"current_config": currentConfig,
+			"current_config": autoEvaluationConfigSummary(tms.autoEvalMiddleware.currentConfig()),
 			"message":        "Current auto-evaluation configuration",
 		})
 		return tms.createSuccessResult(string(resultJSON)), nil
 	}
 
-	// Update configuration based on provided parameters
+	// Validate every parameter before applying any, so a rejected call changes nothing
 	var updates []string
+	var changes []func(*AutoEvaluationConfig)
 
 	if enabled, ok := args["enabled"].(bool); ok {
-		tms.autoEvalMiddleware.config.Enabled = enabled
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.Enabled = enabled })
 		updates = append(updates, fmt.Sprintf("Enabled: %v", enabled))
 	}
 
 	if cacheTimeoutStr, ok := args["cache_timeout"].(string); ok {
-		if duration, err := time.ParseDuration(cacheTimeoutStr); err == nil {
-			tms.autoEvalMiddleware.config.CacheTimeout = duration
-			updates = append(updates, fmt.Sprintf("Cache timeout: %s", duration))
-		} else {
+		duration, err := time.ParseDuration(cacheTimeoutStr)
+		if err != nil {
+			return tms.createErrorResult("configure_auto_evaluation",
+				task.NewError(task.ErrInvalidInput, "invalid cache_timeout format: %s", cacheTimeoutStr)), nil
+		}
+		if duration <= 0 {
 			return tms.createErrorResult("configure_auto_evaluation",
-				fmt.Errorf("invalid cache_timeout format: %s", cacheTimeoutStr)), nil
+				task.NewError(task.ErrInvalidInput, "cache_timeout must be greater than zero")), nil
 		}
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.CacheTimeout = duration })
+		updates = append(updates, fmt.Sprintf("Cache timeout: %s", duration))
 	}
 
 	if maxConcurrent, ok := args["max_concurrent"].(float64); ok {
-		tms.autoEvalMiddleware.config.MaxConcurrent = int(maxConcurrent)
+		if maxConcurrent < 1 {
+			return tms.createErrorResult("configure_auto_evaluation",
+				task.NewError(task.ErrInvalidInput, "max_concurrent must be at least 1")), nil
+		}
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.MaxConcurrent = int(maxConcurrent) })
 		updates = append(updates, fmt.Sprintf("Max concurrent: %d", int(maxConcurrent)))
 	}
 
 	if skipReadOnly, ok := args["skip_read_only_tools"].(bool); ok {
-		tms.autoEvalMiddleware.config.SkipReadOnlyTools = skipReadOnly
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.SkipReadOnlyTools = skipReadOnly })
 		updates = append(updates, fmt.Sprintf("Skip read-only tools: %v", skipReadOnly))
 	}
 
 	if verbose, ok := args["verbose_logging"].(bool); ok {
-		tms.autoEvalMiddleware.config.VerboseLogging = verbose
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.VerboseLogging = verbose })
 		updates = append(updates, fmt.Sprintf("Verbose logging: %v", verbose))
 	}
 
+	if maxEntries, ok := args["max_cache_entries"].(float64); ok {
+		if maxEntries < 1 {
+			return tms.createErrorResult("configure_auto_evaluation",
+				task.NewError(task.ErrInvalidInput, "max_cache_entries must be at least 1")), nil
+		}
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.MaxCacheEntries = int(maxEntries) })
+		updates = append(updates, fmt.Sprintf("Max cache entries: %d", int(maxEntries)))
+	}
+
+	if modeStr, ok := args["summary_mode"].(string); ok {
+		mode, err := ValidateSummaryMode(modeStr)
+		if err != nil {
+			return tms.createErrorResult("configure_auto_evaluation", err), nil
+		}
+		changes = append(changes, func(c *AutoEvaluationConfig) { c.SummaryMode = mode })
+		updates = append(updates, fmt.Sprintf("Summary mode: %s", mode))
+	}
+
 	if len(updates) == 0 {
 		return tms.createErrorResult("configure_auto_evaluation",
-			fmt.Errorf("no configuration parameters provided")), nil
+			task.NewError(task.ErrInvalidInput, "no configuration parameters provided")), nil
 	}
 
+	config := tms.autoEvalMiddleware.updateConfig(func(c *AutoEvaluationConfig) {
+		for _, change := range changes {
+			change(c)
+		}
+	})
+
 	result := map[string]interface{}{
-		"message": "Auto-evaluation configuration updated",
-		"updates": updates,
-		"current_config": map[string]interface{}{
-			"enabled":              tms.autoEvalMiddleware.config.Enabled,
-			"cache_timeout":        tms.autoEvalMiddleware.config.CacheTimeout.String(),
-			"max_concurrent":       tms.autoEvalMiddleware.config.MaxConcurrent,
-			"skip_read_only_tools": tms.autoEvalMiddleware.config.SkipReadOnlyTools,
-			"verbose_logging":      tms.autoEvalMiddleware.config.VerboseLogging,
-		},
+		"message":        "Auto-evaluation configuration updated",
+		"updates":        updates,
+		"current_config": autoEvaluationConfigSummary(config),
 	}
 
 	resultJSON, _ := json.Marshal(result)
 	return tms.createSuccessResult(string(resultJSON)), nil
 }
+
+// autoEvaluationConfigSummary returns the settings configure_auto_evaluation reports
+func autoEvaluationConfigSummary(config AutoEvaluationConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":              config.Enabled,
+		"cache_timeout":        config.CacheTimeout.String(),
+		"max_concurrent":       config.MaxConcurrent,
+		"skip_read_only_tools": config.SkipReadOnlyTools,
+		"verbose_logging":      config.VerboseLogging,
+		"max_cache_entries":    config.MaxCacheEntries,
+		"summary_mode":         config.SummaryMode,
+	}
+}
@@ -0,0 +1,2374 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// newServerProject creates a project holding the given tasks on the test server
+func newServerProject(t *testing.T, tms *TaskManagerServer, name string, tasks ...task.Task) {
+	t.Helper()
+	if err := tms.taskManager.CreateProject(name); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if len(tasks) == 0 {
+		return
+	}
+	if err := tms.taskManager.AddTasks(name, tasks); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+}
+
+// resultText returns the text of a tool result, failing the test if the call
+// returned an error
+func resultText(t *testing.T, result *mcp.CallToolResult, err error) string {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("tool call returned error: %v", err)
+	}
+	if result == nil || len(result.Content) == 0 {
+		t.Fatalf("tool call returned no content")
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatalf("tool result is not text: %#v", result.Content[0])
+	}
+	return text.Text
+}
+
+// decodeResult decodes the JSON text of a successful tool result into v
+func decodeResult(t *testing.T, result *mcp.CallToolResult, err error, v any) {
+	t.Helper()
+	text := resultText(t, result, err)
+	if result.IsError {
+		t.Fatalf("tool call failed: %s", text)
+	}
+	if err := json.Unmarshal([]byte(text), v); err != nil {
+		t.Fatalf("decode result %q: %v", text, err)
+	}
+}
+
+// errorCategory returns the category of a failed tool result's error payload
+func errorCategory(t *testing.T, result *mcp.CallToolResult, err error) string {
+	t.Helper()
+	text := resultText(t, result, err)
+	if !result.IsError {
+		t.Fatalf("tool call succeeded, want an error: %s", text)
+	}
+	var payload struct {
+		Category string `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(text), &payload); err != nil {
+		t.Fatalf("decode error payload %q: %v", text, err)
+	}
+	return payload.Category
+}
+
+// reloadProject loads a project from its file, bypassing the cache
+func reloadProject(t *testing.T, tms *TaskManagerServer, name string) *task.Project {
+	t.Helper()
+	tms.taskManager.InvalidateCache(name)
+	project, err := tms.taskManager.LoadProject(name)
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	return project
+}
+
+func TestGetNextTaskAutoStartPersistsSubtaskStatus(t *testing.T) {
+	tms := newTestServer(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := tms.taskManager.AddTasks("p", []task.Task{{
+		Title:       "Ship it",
+		Description: "Release the build",
+		Subtasks:    []task.Subtask{{Title: "Tag release", Status: task.StatusTodo}},
+	}}); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+
+	result, err := tms.handleGetNextTask(context.Background(), callTool(map[string]any{
+		"project_name": "p",
+		"auto_start":   true,
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("get_next_task failed: %v %v", err, result)
+	}
+
+	// Re-parse the file rather than reading the cached project
+	tms.taskManager.InvalidateCache("p")
+	project, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := project.Tasks[0].Subtasks[0].Status; got != task.StatusInProgress {
+		t.Fatalf("subtask status after reload = %s, want in_progress", got)
+	}
+}
+
+func TestUpdateTaskStatusConcurrentCallsAllApply(t *testing.T) {
+	const n = 10
+	tms := newTestServer(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	tasks := make([]task.Task, n)
+	for i := range tasks {
+		tasks[i] = task.Task{Title: fmt.Sprintf("Task %d", i+1), Description: "Do the work"}
+	}
+	if err := tms.taskManager.AddTasks("p", tasks); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, tk := range tasks {
+		wg.Add(1)
+		go func(title string) {
+			defer wg.Done()
+			result, err := tms.handleUpdateTaskStatus(context.Background(), callTool(map[string]any{
+				"project_name": "p",
+				"task_title":   title,
+				"status":       "done",
+			}))
+			if err != nil || result.IsError {
+				t.Errorf("update_task_status(%s) failed: %v %v", title, err, result)
+			}
+		}(tk.Title)
+	}
+	wg.Wait()
+
+	tms.taskManager.InvalidateCache("p")
+	project, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	for _, tk := range project.Tasks {
+		if tk.Status != task.StatusDone {
+			t.Errorf("task %q is %s, want done", tk.Title, tk.Status)
+		}
+	}
+}
+
+func TestAddTaskCommentConcurrentCallsAllApply(t *testing.T) {
+	const n = 10
+	tms := newTestServer(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := tms.taskManager.AddTask("p", task.Task{Title: "Review", Description: "Review the change"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := tms.handleAddTaskComment(context.Background(), callTool(map[string]any{
+				"project_name": "p",
+				"task_title":   "Review",
+				"text":         fmt.Sprintf("Comment %d", i),
+			}))
+			if err != nil || result.IsError {
+				t.Errorf("add_task_comment failed: %v %v", err, result)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	tms.taskManager.InvalidateCache("p")
+	project, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := len(project.Tasks[0].Comments); got != n {
+		t.Fatalf("got %d comments, want %d", got, n)
+	}
+}
+
+// suggestionTitles calls suggest_next_actions and returns the suggested titles
+// and the decoded result
+func suggestionTitles(t *testing.T, tms *TaskManagerServer, arguments map[string]any) ([]string, map[string]any) {
+	t.Helper()
+	var result struct {
+		Suggestions []struct {
+			Title string `json:"title"`
+		} `json:"suggestions"`
+	}
+	callResult, err := tms.handleSuggestNextActions(context.Background(), callTool(arguments))
+	decodeResult(t, callResult, err, &result)
+	var raw map[string]any
+	decodeResult(t, callResult, err, &raw)
+
+	var titles []string
+	for _, suggestion := range result.Suggestions {
+		titles = append(titles, suggestion.Title)
+	}
+	return titles, raw
+}
+
+func TestSuggestNextActionsFocusArea(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Login page", Description: "Build it", Category: task.CategoryMVP},
+		task.Task{Title: "Ranking model", Description: "Train it", Category: task.CategoryAI},
+		task.Task{Title: "Cache layer", Description: "Add it", Category: task.CategoryAI, Tags: []string{"backend"}},
+		// Saved as [GENERAL] and read back without a category
+		task.Task{Title: "Tidy up", Description: "Sweep it"},
+	)
+
+	tests := []struct {
+		focus string
+		want  []string
+	}{
+		{"MVP", []string{"Login page"}},
+		{"[MVP]", []string{"Login page"}},
+		{"mvp", []string{"Login page"}},
+		{"Backend", []string{"Cache layer"}},
+		{"general", []string{"Tidy up"}},
+		{"[GENERAL]", []string{"Tidy up"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.focus, func(t *testing.T) {
+			titles, raw := suggestionTitles(t, tms, map[string]any{"project_name": "p", "focus_area": tt.focus})
+			if fmt.Sprint(titles) != fmt.Sprint(tt.want) {
+				t.Errorf("suggestions = %v, want %v", titles, tt.want)
+			}
+			if _, ok := raw["focus_area_matched"]; ok {
+				t.Errorf("focus_area_matched is set although the focus area matched")
+			}
+		})
+	}
+}
+
+func TestSuggestNextActionsFallsBackWhenFocusAreaMatchesNothing(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Login page", Description: "Build it", Category: task.CategoryMVP},
+		task.Task{Title: "Ranking model", Description: "Train it", Category: task.CategoryAI},
+	)
+
+	titles, raw := suggestionTitles(t, tms, map[string]any{"project_name": "p", "focus_area": "UX"})
+	if len(titles) != 2 {
+		t.Errorf("suggestions = %v, want both tasks", titles)
+	}
+	if matched, ok := raw["focus_area_matched"].(bool); !ok || matched {
+		t.Errorf("focus_area_matched = %v, want false", raw["focus_area_matched"])
+	}
+	if note, _ := raw["note"].(string); note == "" {
+		t.Error("fallback result has no note explaining it")
+	}
+}
+
+func TestGenerateTaskFileStrictFileTypes(t *testing.T) {
+	tms := newTestServer(t)
+	root := useProjectRoot(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Write parser", Description: "Parse the input"})
+
+	generate := func(arguments map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		arguments["project_name"] = "p"
+		arguments["task_title"] = "Write parser"
+		result, err := tms.handleGenerateTaskFile(context.Background(), callTool(arguments))
+		if err != nil {
+			t.Fatalf("generate_task_file: %v", err)
+		}
+		return result
+	}
+
+	if category := errorCategory(t, generate(map[string]any{"strict": true, "file_path": "parser.go"}), nil); category != ErrorCategoryValidation {
+		t.Errorf("strict mode without file_type: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+	if _, err := os.Stat(filepath.Join(root, "parser.go")); !os.IsNotExist(err) {
+		t.Errorf("strict mode rejection still wrote the file: %v", err)
+	}
+
+	// The server setting is the default for calls that don't pass strict
+	tms.config.StrictFileTypes = true
+	if result := generate(map[string]any{"file_path": "parser.go"}); !result.IsError {
+		t.Error("STRICT_FILE_TYPES accepted a call without file_type")
+	}
+	if result := generate(map[string]any{"strict": false, "file_path": "parser.go"}); result.IsError {
+		t.Errorf("strict=false still rejected the call: %v", result)
+	}
+
+	if result := generate(map[string]any{"file_type": "go", "file_path": "typed.go"}); result.IsError {
+		t.Fatalf("strict mode rejected a call with file_type: %v", result)
+	}
+	if _, err := os.Stat(filepath.Join(root, "typed.go")); err != nil {
+		t.Errorf("generated file missing: %v", err)
+	}
+}
+
+func TestContextPrimer(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "api",
+		task.Task{Title: "Design schema", Description: "Tables", Priority: task.PriorityP0, Status: task.StatusDone},
+		task.Task{Title: "Write handlers", Description: "Endpoints", Priority: task.PriorityP1,
+			Subtasks: []task.Subtask{{Title: "List endpoint", Status: task.StatusTodo}}},
+	)
+	newServerProject(t, tms, "web")
+	prime := func(args map[string]any) string {
+		result, err := tms.handleContextPrimer(context.Background(), callTool(args))
+		return resultText(t, result, err)
+	}
+
+	primer := prime(map[string]any{})
+	for _, want := range []string{
+		"Task manager state: 2 project(s)",
+		"[api] 1/2 tasks done",
+		"- P1 Write handlers (todo,",
+		"next: List endpoint",
+		"[web] 0/0 tasks done",
+	} {
+		if !strings.Contains(primer, want) {
+			t.Errorf("primer is missing %q:\n%s", want, primer)
+		}
+	}
+	if strings.Contains(primer, "Design schema") {
+		t.Errorf("primer lists a done task as a next action:\n%s", primer)
+	}
+
+	without := prime(map[string]any{"actions_per_project": 0.0})
+	if strings.Contains(without, "Write handlers") {
+		t.Errorf("actions_per_project=0 still lists actions:\n%s", without)
+	}
+
+	truncated := prime(map[string]any{"max_bytes": 60.0})
+	if len(truncated) > 60 || !strings.HasSuffix(truncated, contextPrimerTruncationMarker) {
+		t.Errorf("max_bytes=60 gave %d bytes: %q", len(truncated), truncated)
+	}
+}
+
+func TestTruncateToBudget(t *testing.T) {
+	text := "first line\nsecond line\nthird line\n"
+	tests := []struct {
+		name     string
+		maxBytes int
+		want     string
+	}{
+		{"fits", len(text), text},
+		{"cut at a line boundary", 30, "first line" + contextPrimerTruncationMarker},
+		{"cut inside the first line", 20, "firs" + contextPrimerTruncationMarker},
+		{"budget smaller than the marker", 8, "first li"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateToBudget(text, tt.maxBytes)
+			if got != tt.want {
+				t.Errorf("truncateToBudget(%d) = %q, want %q", tt.maxBytes, got, tt.want)
+			}
+			if len(got) > tt.maxBytes {
+				t.Errorf("truncateToBudget(%d) returned %d bytes", tt.maxBytes, len(got))
+			}
+		})
+	}
+}
+
+func TestExpandTaskErrorCategories(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Build", Description: "Build it"})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"unknown task", map[string]any{"project_name": "p", "task_title": "Missing", "new_subtasks": []any{"Step"}}, ErrorCategoryNotFound},
+		{"unknown project", map[string]any{"project_name": "nope", "task_title": "Build", "new_subtasks": []any{"Step"}}, ErrorCategoryNotFound},
+		{"no subtasks", map[string]any{"project_name": "p", "task_title": "Build"}, ErrorCategoryValidation},
+		{"missing task_title", map[string]any{"project_name": "p", "new_subtasks": []any{"Step"}}, ErrorCategoryValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tms.handleExpandTask(context.Background(), callTool(tt.args))
+			if category := errorCategory(t, result, err); category != tt.want {
+				t.Errorf("category = %q, want %q", category, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkTag(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Login", Description: "d", Priority: task.PriorityP0},
+		task.Task{Title: "Logout", Description: "d", Priority: task.PriorityP0, Tags: []string{"auth"}},
+		task.Task{Title: "Docs", Description: "d", Priority: task.PriorityP3},
+	)
+	bulkTag := func(args map[string]any) map[string]any {
+		t.Helper()
+		args["project_name"] = "p"
+		result, err := tms.handleBulkTag(context.Background(), callTool(args))
+		var decoded map[string]any
+		decodeResult(t, result, err, &decoded)
+		return decoded
+	}
+	tagsByTitle := func() map[string][]string {
+		t.Helper()
+		tags := make(map[string][]string)
+		for _, tk := range reloadProject(t, tms, "p").Tasks {
+			tags[tk.Title] = tk.Tags
+		}
+		return tags
+	}
+
+	// A filter selects both P0 tasks; the one already tagged is left alone
+	got := bulkTag(map[string]any{"tag": " Auth ", "priority": "p0", "dry_run": true})
+	if got["selected_count"] != 2.0 || got["affected_count"] != 1.0 || got["saved"] != false {
+		t.Errorf("dry run result = %v, want 2 selected, 1 affected, not saved", got)
+	}
+	if tags := tagsByTitle(); len(tags["Login"]) != 0 {
+		t.Errorf("dry run saved tags: %v", tags)
+	}
+
+	bulkTag(map[string]any{"tag": " Auth ", "priority": "p0"})
+	bulkTag(map[string]any{"tag": "docs", "task_titles": []any{"Docs", "Login"}})
+	tags := tagsByTitle()
+	if !slices.Equal(tags["Login"], []string{"auth", "docs"}) || !slices.Equal(tags["Logout"], []string{"auth"}) || !slices.Equal(tags["Docs"], []string{"docs"}) {
+		t.Errorf("tags after adding = %v", tags)
+	}
+
+	got = bulkTag(map[string]any{"tag": "AUTH", "action": "remove", "all_tasks": true})
+	if got["affected_count"] != 2.0 {
+		t.Errorf("remove result = %v, want 2 affected", got)
+	}
+	if tags := tagsByTitle(); !slices.Equal(tags["Login"], []string{"docs"}) || len(tags["Logout"]) != 0 {
+		t.Errorf("tags after removing = %v", tags)
+	}
+
+	for name, args := range map[string]map[string]any{
+		"no selection":   {"project_name": "p", "tag": "x"},
+		"bad action":     {"project_name": "p", "tag": "x", "all_tasks": true, "action": "toggle"},
+		"tag with comma": {"project_name": "p", "tag": "a,b", "all_tasks": true},
+	} {
+		result, err := tms.handleBulkTag(context.Background(), callTool(args))
+		if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+			t.Errorf("%s: category = %q, want %q", name, category, ErrorCategoryValidation)
+		}
+	}
+
+	// An unknown title fails the whole call without tagging the others
+	result, err := tms.handleBulkTag(context.Background(), callTool(map[string]any{"project_name": "p", "tag": "late", "task_titles": []any{"Docs", "Missing"}}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryNotFound {
+		t.Errorf("unknown title: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+	if tags := tagsByTitle(); slices.Contains(tags["Docs"], "late") {
+		t.Errorf("failed bulk_tag still tagged Docs: %v", tags)
+	}
+}
+
+func TestMaxFileSizeFromEnv(t *testing.T) {
+	t.Setenv("MAX_FILE_SIZE", "2048")
+	tms := newTestServer(t)
+	if tms.config.MaxFileSize != 2048 {
+		t.Fatalf("config.MaxFileSize = %d, want 2048", tms.config.MaxFileSize)
+	}
+	newServerProject(t, tms, "p", task.Task{Title: "Big", Description: strings.Repeat("x", 4096)})
+
+	tms.taskManager.InvalidateCache("p")
+	result, err := tms.handleValidateProject(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+		t.Errorf("loading a project over MAX_FILE_SIZE: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+}
+
+func TestReadToolsAutoCreate(t *testing.T) {
+	suggest := func(tms *TaskManagerServer, args map[string]any) (*mcp.CallToolResult, error) {
+		return tms.handleSuggestNextActions(context.Background(), callTool(args))
+	}
+
+	tms := newTestServer(t)
+	result, err := suggest(tms, map[string]any{"project_name": "missing"})
+	if category := errorCategory(t, result, err); category != ErrorCategoryNotFound {
+		t.Errorf("missing project without auto_create: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+	if tms.taskManager.ProjectExists("missing") {
+		t.Error("a read without auto_create created the project")
+	}
+
+	var decoded map[string]any
+	result, err = suggest(tms, map[string]any{"project_name": "missing", "auto_create": true})
+	decodeResult(t, result, err, &decoded)
+	if !tms.taskManager.ProjectExists("missing") || decoded["project"] != "missing" {
+		t.Errorf("auto_create=true: exists = %v, result = %v", tms.taskManager.ProjectExists("missing"), decoded)
+	}
+
+	// An invalid name is still rejected rather than created
+	result, err = suggest(tms, map[string]any{"project_name": "../escape", "auto_create": true})
+	if resultText(t, result, err); !result.IsError {
+		t.Error("auto_create accepted an empty project name")
+	}
+
+	// AUTO_CREATE_PROJECTS makes it the default, and a call can still opt out
+	t.Setenv("AUTO_CREATE_PROJECTS", "true")
+	tms = newTestServer(t)
+	result, err = suggest(tms, map[string]any{"project_name": "optout", "auto_create": false})
+	if category := errorCategory(t, result, err); category != ErrorCategoryNotFound || tms.taskManager.ProjectExists("optout") {
+		t.Errorf("auto_create=false with AUTO_CREATE_PROJECTS: category = %q, exists = %v", category, tms.taskManager.ProjectExists("optout"))
+	}
+	result, err = suggest(tms, map[string]any{"project_name": "fromenv"})
+	decodeResult(t, result, err, &decoded)
+	if !tms.taskManager.ProjectExists("fromenv") {
+		t.Error("AUTO_CREATE_PROJECTS=true did not create the project")
+	}
+}
+
+// fakeSession is a client session whose notifications are buffered for inspection
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newFakeSession(id string) *fakeSession {
+	return &fakeSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10)}
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *fakeSession) SessionID() string                                   { return s.id }
+
+func TestWatchProjectNotifiesOnStatusChange(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "A", Description: "d"},
+		task.Task{Title: "B", Description: "d"},
+	)
+
+	result, err := tms.handleWatchProject(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+		t.Errorf("watch without a session: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+
+	session := newFakeSession("s1")
+	if err := tms.mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("RegisterSession: %v", err)
+	}
+	ctx := tms.mcpServer.WithContext(context.Background(), session)
+	var watched map[string]any
+	for _, wantAlready := range []bool{false, true} {
+		result, err := tms.handleWatchProject(ctx, callTool(map[string]any{"project_name": "p"}))
+		decodeResult(t, result, err, &watched)
+		if watched["already_watching"] != wantAlready {
+			t.Errorf("watch_project = %v, want already_watching %v", watched, wantAlready)
+		}
+	}
+
+	setStatus := func(title string, status task.TaskStatus) {
+		t.Helper()
+		if err := tms.taskManager.UpdateTaskStatus("p", title, "", status); err != nil {
+			t.Fatalf("UpdateTaskStatus: %v", err)
+		}
+	}
+	setStatus("A", task.StatusDone)
+
+	select {
+	case notification := <-session.notifications:
+		params := notification.Params.AdditionalFields
+		changes, _ := params["status_changes"].([]statusChange)
+		if notification.Method != projectProgressNotification || params["completed_tasks"] != 1 || params["total_tasks"] != 2 ||
+			len(changes) != 1 || changes[0].TaskTitle != "A" || changes[0].OldStatus != task.StatusTodo || changes[0].NewStatus != task.StatusDone {
+			t.Errorf("notification = %s %v", notification.Method, params)
+		}
+	default:
+		t.Fatal("no notification after a status change")
+	}
+
+	// A save that doesn't change any status is not reported
+	if err := tms.taskManager.UpdateProject("p", func(project *task.Project) error {
+		project.Tasks[1].Description = "edited"
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if len(session.notifications) != 0 {
+		t.Errorf("got a notification for a save without status changes: %v", <-session.notifications)
+	}
+
+	var unwatched map[string]any
+	result, err = tms.handleUnwatchProject(ctx, callTool(map[string]any{"project_name": "p"}))
+	decodeResult(t, result, err, &unwatched)
+	if unwatched["was_active"] != true {
+		t.Errorf("unwatch_project = %v, want was_active", unwatched)
+	}
+	setStatus("B", task.StatusDone)
+	if len(session.notifications) != 0 {
+		t.Errorf("got a notification after unwatching: %v", <-session.notifications)
+	}
+}
+
+func TestProjectWatchersSubscriberLimit(t *testing.T) {
+	watchers := newProjectWatchers(1)
+	project := &task.Project{Name: "p"}
+	if ok, err := watchers.subscribe("s1", project); !ok || err != nil {
+		t.Fatalf("first subscribe = %v, %v", ok, err)
+	}
+	if _, err := watchers.subscribe("s2", project); !errors.Is(err, task.ErrConflict) {
+		t.Errorf("subscribe over the limit = %v, want ErrConflict", err)
+	}
+
+	// Ending a session frees its slot
+	watchers.removeSession("s1")
+	if ok, err := watchers.subscribe("s2", project); !ok || err != nil {
+		t.Errorf("subscribe after removeSession = %v, %v", ok, err)
+	}
+}
+
+func TestComplexityBreakdown(t *testing.T) {
+	steps := func(n int) []task.Subtask {
+		subtasks := make([]task.Subtask, n)
+		for i := range subtasks {
+			subtasks[i] = task.Subtask{Title: fmt.Sprintf("Step %d", i+1)}
+		}
+		return subtasks
+	}
+	project := &task.Project{Tasks: []task.Task{
+		{ID: 1, Title: "Bare", Complexity: task.ComplexityHigh},
+		{ID: 2, Title: "Split", Complexity: task.ComplexityHigh, Subtasks: steps(3)},
+		{ID: 3, Title: "Partly split", Complexity: task.ComplexityHigh, Subtasks: steps(2)},
+		{ID: 4, Title: "Finished", Complexity: task.ComplexityHigh, Status: task.StatusDone},
+		{ID: 5, Title: "Easy", Complexity: task.ComplexityLow},
+		{ID: 6, Title: "Unrated"},
+	}}
+	candidateTitles := func(candidates []breakdownCandidate) []string {
+		var titles []string
+		for _, c := range candidates {
+			titles = append(titles, c.Title)
+		}
+		return titles
+	}
+
+	distribution, candidates := complexityBreakdown(project, 3, false)
+	wantDistribution := map[string]int{"low": 1, "medium": 0, "high": 4, "unset": 1}
+	if fmt.Sprint(distribution) != fmt.Sprint(wantDistribution) {
+		t.Errorf("distribution = %v, want %v", distribution, wantDistribution)
+	}
+	if got := candidateTitles(candidates); !slices.Equal(got, []string{"Bare", "Partly split"}) {
+		t.Errorf("candidates = %v, want [Bare Partly split]", got)
+	}
+	if candidates[1].SubtaskCount != 2 || candidates[1].TaskID != 3 {
+		t.Errorf("candidate = %+v", candidates[1])
+	}
+
+	if _, candidates := complexityBreakdown(project, 2, true); !slices.Equal(candidateTitles(candidates), []string{"Bare", "Finished"}) {
+		t.Errorf("min_subtasks=2 with completed = %v, want [Bare Finished]", candidateTitles(candidates))
+	}
+
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Bare", Description: "d"})
+	result, err := tms.handleComplexityBreakdown(context.Background(), callTool(map[string]any{"project_name": "p", "min_subtasks": 0.0}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+		t.Errorf("min_subtasks=0: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+}
+
+func TestNormalizeTitlesTool(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Ship", Description: "d"})
+	path := tms.taskManager.GetTaskFilePath("p")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	padded := strings.Replace(string(content), " Ship (", "    Ship   (", 1)
+	if padded == string(content) {
+		t.Fatalf("task header not found in:\n%s", content)
+	}
+	if err := os.WriteFile(path, []byte(padded), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var result struct {
+		ChangedCount int                `json:"changed_count"`
+		Changes      []task.TitleChange `json:"changes"`
+		Saved        bool               `json:"saved"`
+	}
+	toolResult, err := tms.handleNormalizeTitles(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	decodeResult(t, toolResult, err, &result)
+	if result.ChangedCount != 1 || !result.Saved || result.Changes[0].NewTitle != "Ship" || result.Changes[0].OldTitle != "   Ship  " {
+		t.Errorf("normalize_titles = %+v", result)
+	}
+	if content, _ := os.ReadFile(path); strings.Contains(string(content), "  Ship") {
+		t.Errorf("file still padded:\n%s", content)
+	}
+}
+
+func TestParseEstimatedHours(t *testing.T) {
+	tms := newTestServer(t)
+	tests := []struct {
+		name    string
+		value   any
+		want    int
+		wantErr bool
+	}{
+		{"absent", nil, 0, false},
+		{"whole hours", 8.0, 8, false},
+		{"rounds down", 2.4, 2, false},
+		{"rounds half up", 2.5, 3, false},
+		{"small estimate counts as an hour", 0.2, 1, false},
+		{"zero", 0.0, 0, false},
+		{"upper bound", 1000.0, 1000, false},
+		{"too large", 1000.6, 0, true},
+		{"negative", -1.0, 0, true},
+		{"string", "8", 0, true},
+		{"NaN", math.NaN(), 0, true},
+		{"infinite", math.Inf(1), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{}
+			if tt.value != nil {
+				args["estimated_hours"] = tt.value
+			}
+			got, err := tms.parseEstimatedHours(callTool(args))
+			if tt.wantErr {
+				if !errors.Is(err, task.ErrInvalidInput) {
+					t.Errorf("parseEstimatedHours(%v) = %d, %v; want ErrInvalidInput", tt.value, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parseEstimatedHours(%v) = %d, %v; want %d", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateTaskComplexityRoundsHours(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Build", Description: "d"})
+
+	result, err := tms.handleEstimateTaskComplexity(context.Background(), callTool(map[string]any{
+		"project_name": "p", "task_title": "Build", "complexity": "medium", "estimated_hours": 2.5,
+	}))
+	if text := resultText(t, result, err); result.IsError {
+		t.Fatalf("estimate_task_complexity: %s", text)
+	}
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.EstimatedHours != 3 || got.Complexity != task.ComplexityMedium {
+		t.Errorf("after reload: %d hours, %s complexity; want 3 hours, medium", got.EstimatedHours, got.Complexity)
+	}
+
+	result, err = tms.handleEstimateTaskComplexity(context.Background(), callTool(map[string]any{
+		"project_name": "p", "task_title": "Build", "complexity": "high", "estimated_hours": -4.0,
+	}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+		t.Errorf("negative hours: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.Complexity != task.ComplexityMedium {
+		t.Errorf("rejected estimate still changed complexity to %s", got.Complexity)
+	}
+}
+
+func TestGetNextTaskReportsCompletion(t *testing.T) {
+	t.Setenv("COMPLETION_MESSAGE", "All done.")
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "A", Description: "d", EstimatedHours: 2, Subtasks: []task.Subtask{{Title: "Step"}}},
+		task.Task{Title: "B", Description: "d", EstimatedHours: 3},
+	)
+	for _, title := range []string{"A", "B"} {
+		if err := tms.taskManager.UpdateTaskStatus("p", title, "", task.StatusDone); err != nil {
+			t.Fatalf("UpdateTaskStatus: %v", err)
+		}
+	}
+
+	var result struct {
+		Completed bool                 `json:"completed"`
+		Message   string               `json:"message"`
+		Stats     task.CompletionStats `json:"stats"`
+	}
+	toolResult, err := tms.handleGetNextTask(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	decodeResult(t, toolResult, err, &result)
+	if !result.Completed || result.Message != "All done." {
+		t.Errorf("completion = %+v, want completed with the configured message", result)
+	}
+	if result.Stats.TotalTasks != 2 || result.Stats.TotalSubtasks != 1 || result.Stats.TotalEstimatedHours != 5 || result.Stats.FinishedAt == nil {
+		t.Errorf("stats = %+v", result.Stats)
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "finishing", task.Task{
+		Title: "Wrap up", Description: "d", Status: task.StatusInProgress,
+		Subtasks: []task.Subtask{{Title: "Last step", Status: task.StatusDone}},
+	})
+	newServerProject(t, tms, "quiet", task.Task{Title: "Later", Description: "d"})
+
+	type projectResult struct {
+		Project        string   `json:"project"`
+		UpdatesApplied []string `json:"updates_applied"`
+		Error          string   `json:"error"`
+	}
+	var result struct {
+		DryRun            bool            `json:"dry_run"`
+		ProjectsEvaluated int             `json:"projects_evaluated"`
+		TotalUpdates      int             `json:"total_updates"`
+		Projects          []projectResult `json:"projects"`
+	}
+	evaluate := func(dryRun bool) {
+		t.Helper()
+		toolResult, err := tms.handleEvaluateAll(context.Background(), callTool(map[string]any{"dry_run": dryRun}))
+		decodeResult(t, toolResult, err, &result)
+		if result.ProjectsEvaluated != 2 || result.TotalUpdates != 1 || len(result.Projects) != 2 {
+			t.Fatalf("evaluate_all(dry_run=%v) = %+v, want 2 projects with 1 update", dryRun, result)
+		}
+		if finishing := result.Projects[0]; finishing.Project != "finishing" || len(finishing.UpdatesApplied) != 1 || finishing.Error != "" {
+			t.Errorf("finishing = %+v, want one update", finishing)
+		}
+		if quiet := result.Projects[1]; quiet.Project != "quiet" || len(quiet.UpdatesApplied) != 0 {
+			t.Errorf("quiet = %+v, want no updates", quiet)
+		}
+	}
+
+	evaluate(true)
+	if status := reloadProject(t, tms, "finishing").Tasks[0].Status; status != task.StatusInProgress {
+		t.Errorf("dry run saved the update: status = %s", status)
+	}
+
+	evaluate(false)
+	if status := reloadProject(t, tms, "finishing").Tasks[0].Status; status != task.StatusDone {
+		t.Errorf("status after evaluate_all = %s, want done", status)
+	}
+}
+
+func TestProgressDelta(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Schema", Description: "Tables"},
+		task.Task{Title: "Handlers", Description: "Endpoints"},
+	)
+
+	type deltaResult struct {
+		SnapshotsAvailable int                `json:"snapshots_available"`
+		Note               string             `json:"note"`
+		Delta              task.ProgressDelta `json:"delta"`
+	}
+	progressDelta := func(arguments map[string]any) deltaResult {
+		t.Helper()
+		var result deltaResult
+		r, err := tms.handleProgressDelta(context.Background(), callTool(arguments))
+		decodeResult(t, r, err, &result)
+		return result
+	}
+	titles := func(tasks []task.SnapshotTask) []string {
+		var titles []string
+		for _, t := range tasks {
+			titles = append(titles, t.Title)
+		}
+		return titles
+	}
+
+	// Without snapshots there's nothing to diff against
+	if got := progressDelta(map[string]any{"project_name": "p", "since": "2024-01-01"}); got.SnapshotsAvailable != 0 || got.Note == "" {
+		t.Fatalf("progress_delta without snapshots = %+v, want a note and no delta", got)
+	}
+
+	first, err := tms.taskManager.RecordSnapshot("p")
+	if err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+	if err := tms.taskManager.UpdateTaskStatus("p", "Schema", "", task.StatusDone); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+	if err := tms.taskManager.AddTasks("p", []task.Task{{Title: "Release", Description: "Ship it"}}); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+	second, err := tms.taskManager.RecordSnapshot("p")
+	if err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+	if err := tms.taskManager.UpdateTaskStatus("p", "Handlers", "", task.StatusInProgress); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+
+	// Between the two snapshots
+	got := progressDelta(map[string]any{
+		"project_name": "p",
+		"since":        first.Time.Format(time.RFC3339Nano),
+		"until":        second.Time.Format(time.RFC3339Nano),
+	})
+	if got.SnapshotsAvailable != 2 || got.Note != "" {
+		t.Errorf("snapshots_available = %d, note = %q; want 2 and no note", got.SnapshotsAvailable, got.Note)
+	}
+	if c := titles(got.Delta.Completed); !slices.Equal(c, []string{"Schema"}) {
+		t.Errorf("completed = %v, want [Schema]", c)
+	}
+	if a := titles(got.Delta.Added); !slices.Equal(a, []string{"Release"}) {
+		t.Errorf("added = %v, want [Release]", a)
+	}
+	if len(got.Delta.StatusChanged) != 1 || got.Delta.StatusChanged[0].Title != "Schema" {
+		t.Errorf("status changes = %+v, want only Schema", got.Delta.StatusChanged)
+	}
+
+	// Without until, the window runs to the current state
+	got = progressDelta(map[string]any{"project_name": "p", "since": first.Time.Format(time.RFC3339Nano)})
+	if len(got.Delta.StatusChanged) != 2 || got.Delta.StatusChanged[1].Title != "Handlers" ||
+		got.Delta.StatusChanged[1].NewStatus != task.StatusInProgress {
+		t.Errorf("status changes up to now = %+v, want Schema and Handlers", got.Delta.StatusChanged)
+	}
+
+	// A window starting before the first snapshot falls back to it and says so
+	got = progressDelta(map[string]any{"project_name": "p", "since": "2000-01-01"})
+	if got.Note == "" || !got.Delta.From.Equal(first.Time) {
+		t.Errorf("early since: note = %q, from = %s; want a note and the first snapshot as baseline", got.Note, got.Delta.From)
+	}
+
+	r, err := tms.handleProgressDelta(context.Background(), callTool(map[string]any{
+		"project_name": "p", "since": "2024-05-02", "until": "2024-05-01",
+	}))
+	if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+		t.Errorf("until before since: category = %s, want %s", category, ErrorCategoryValidation)
+	}
+}
+
+func TestEstimateTaskComplexitySubtaskGate(t *testing.T) {
+	tests := []struct {
+		name        string
+		envGate     string
+		paramGate   string
+		complexity  string
+		wantCreated bool
+	}{
+		{"default gate skips low", "", "", "low", false},
+		{"default gate creates medium", "", "", "medium", true},
+		{"low gate creates low", "low", "", "low", true},
+		{"high gate skips medium", "high", "", "medium", false},
+		{"high gate creates high", "high", "", "high", true},
+		{"parameter overrides the configured gate", "high", "low", "low", true},
+		{"parameter can raise the gate", "low", "high", "medium", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envGate != "" {
+				t.Setenv("SUBTASK_COMPLEXITY_GATE", tt.envGate)
+			}
+			tms := newTestServer(t)
+			newServerProject(t, tms, "p", task.Task{Title: "Build", Description: "d"})
+
+			arguments := map[string]any{
+				"project_name":         "p",
+				"task_title":           "Build",
+				"complexity":           tt.complexity,
+				"suggested_subtasks":   []any{"Design", "Implement"},
+				"auto_create_subtasks": true,
+			}
+			if tt.paramGate != "" {
+				arguments["min_complexity_for_subtasks"] = tt.paramGate
+			}
+			result, err := tms.handleEstimateTaskComplexity(context.Background(), callTool(arguments))
+			text := resultText(t, result, err)
+			if result.IsError {
+				t.Fatalf("estimate_task_complexity: %s", text)
+			}
+
+			subtasks := reloadProject(t, tms, "p").Tasks[0].Subtasks
+			if created := len(subtasks) == 2; created != tt.wantCreated {
+				t.Errorf("created subtasks = %v (%d), want %v", created, len(subtasks), tt.wantCreated)
+			}
+			if skipped := strings.Contains(text, "skipped creating 2 subtasks"); skipped == tt.wantCreated {
+				t.Errorf("result %q: reported skip = %v, want %v", text, skipped, !tt.wantCreated)
+			}
+		})
+	}
+
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Build", Description: "d"})
+	result, err := tms.handleEstimateTaskComplexity(context.Background(), callTool(map[string]any{
+		"project_name": "p", "task_title": "Build", "complexity": "high", "min_complexity_for_subtasks": "huge",
+	}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryValidation {
+		t.Errorf("invalid gate: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+}
+
+func TestDoneCriteriaTools(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Ship it", Description: "d"})
+	ctx := context.Background()
+
+	setCriteria := func(criteria ...any) {
+		t.Helper()
+		result, err := tms.handleSetDoneCriteria(ctx, callTool(map[string]any{
+			"project_name": "p", "task_title": "Ship it", "criteria": criteria,
+		}))
+		if text := resultText(t, result, err); result.IsError {
+			t.Fatalf("set_done_criteria: %s", text)
+		}
+	}
+
+	setCriteria("Changelog updated", "Tagged in git")
+	var acknowledged struct {
+		CriteriaMet    int     `json:"criteria_met"`
+		CriteriaTotal  int     `json:"criteria_total"`
+		TaskCompletion float64 `json:"task_completion"`
+	}
+	result, err := tms.handleAcknowledgeDoneCriterion(ctx, callTool(map[string]any{
+		"project_name": "p", "task_title": "Ship it", "criterion": "Tagged in git",
+	}))
+	decodeResult(t, result, err, &acknowledged)
+	if acknowledged.CriteriaMet != 1 || acknowledged.CriteriaTotal != 2 || acknowledged.TaskCompletion != 50 {
+		t.Errorf("acknowledge_done_criterion = %+v, want 1 of 2 met and 50%% complete", acknowledged)
+	}
+
+	got := reloadProject(t, tms, "p").Tasks[0]
+	if !slices.Equal(got.DoneCriteria, []string{"Changelog updated", "Tagged in git"}) || !slices.Equal(got.MetCriteria, []string{"Tagged in git"}) {
+		t.Fatalf("after reload: criteria %v met %v", got.DoneCriteria, got.MetCriteria)
+	}
+
+	// get_task reports the criteria
+	var details struct {
+		Task task.Task `json:"task"`
+	}
+	result, err = tms.handleGetTask(ctx, callTool(map[string]any{"project_name": "p", "task_title": "Ship it"}))
+	decodeResult(t, result, err, &details)
+	if !slices.Equal(details.Task.DoneCriteria, got.DoneCriteria) || !slices.Equal(details.Task.MetCriteria, got.MetCriteria) {
+		t.Errorf("get_task criteria = %v met %v", details.Task.DoneCriteria, details.Task.MetCriteria)
+	}
+
+	// Replacing the list keeps the acknowledgment of criteria that stay
+	setCriteria("Tagged in git", "Announced")
+	got = reloadProject(t, tms, "p").Tasks[0]
+	if !slices.Equal(got.DoneCriteria, []string{"Tagged in git", "Announced"}) || !slices.Equal(got.MetCriteria, []string{"Tagged in git"}) {
+		t.Errorf("after replacing: criteria %v met %v", got.DoneCriteria, got.MetCriteria)
+	}
+
+	result, err = tms.handleAcknowledgeDoneCriterion(ctx, callTool(map[string]any{
+		"project_name": "p", "task_title": "Ship it", "criterion": "Changelog updated",
+	}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryNotFound {
+		t.Errorf("acknowledging a removed criterion: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+
+	// An empty list clears them
+	setCriteria()
+	if got := reloadProject(t, tms, "p").Tasks[0]; len(got.DoneCriteria) != 0 || len(got.MetCriteria) != 0 {
+		t.Errorf("after clearing: criteria %v met %v", got.DoneCriteria, got.MetCriteria)
+	}
+}
+
+func TestSuggestionWeightsChangeOrdering(t *testing.T) {
+	tasks := []task.Task{
+		{Title: "Hotfix", Description: "d", Priority: task.PriorityP0, Dependencies: []int{2}},
+		{Title: "Refactor", Description: "d", Priority: task.PriorityP2},
+	}
+	tests := []struct {
+		name    string
+		weights string
+		want    []string
+	}{
+		// Ready P2 (50+50) beats blocked P0 (100-25)
+		{"default weights favour ready tasks", "", []string{"Refactor", "Hotfix"}},
+		// With readiness ignored, priority alone decides
+		{"readiness ignored", "ready=0,blocked=0", []string{"Hotfix", "Refactor"}},
+		// A large P2 weight outranks everything else
+		{"priority weight raised", "ready=0,blocked=0,priority_p2=200", []string{"Refactor", "Hotfix"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.weights != "" {
+				t.Setenv("SUGGESTION_WEIGHTS", tt.weights)
+			}
+			tms := newTestServer(t)
+			newServerProject(t, tms, "p", tasks...)
+
+			titles, _ := suggestionTitles(t, tms, map[string]any{"project_name": "p"})
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("suggestions = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProjectAsTree(t *testing.T) {
+	resolved := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	project := &task.Project{Name: "p", Tasks: []task.Task{
+		{
+			ID:       1,
+			Title:    "Design",
+			Status:   task.StatusInProgress,
+			Priority: task.PriorityP1,
+			Category: task.CategoryMVP,
+			Choices: []task.Choice{
+				{Question: "Which database?", Options: []string{"Postgres", "SQLite"}, Selected: "Postgres", ResolvedAt: &resolved},
+				{Question: "Which cache?", Options: []string{"Redis", "None"}},
+			},
+			Subtasks: []task.Subtask{
+				{Title: "Schema", Status: task.StatusDone},
+				{Title: "Indexes", Status: task.StatusTodo, Choices: []task.Choice{{Question: "B-tree or hash?", Options: []string{"B-tree", "Hash"}}}},
+			},
+		},
+		{ID: 2, Title: "Deploy", Status: task.StatusDone, Priority: task.PriorityP2},
+	}}
+
+	root := buildProjectTree(project, task.DefaultCriteriaWeight)
+	if root.Type != "project" || root.Title != "p" || len(root.Children) != 2 || root.Progress != project.GetProgressPercentage() {
+		t.Fatalf("root = %s %q with %d children and %v%% progress", root.Type, root.Title, len(root.Children), root.Progress)
+	}
+
+	design := root.Children[0]
+	if design.Type != "task" || design.ID != 1 || design.Title != "Design" || design.Priority != task.PriorityP1 ||
+		design.Category != task.CategoryMVP || design.Status != task.StatusInProgress || design.Progress != 50 {
+		t.Errorf("task node = %+v", design)
+	}
+	// Choices come before subtasks
+	var shape []string
+	for _, child := range design.Children {
+		shape = append(shape, child.Type+":"+child.Title)
+	}
+	if want := []string{"choice:Which database?", "choice:Which cache?", "subtask:Schema", "subtask:Indexes"}; !slices.Equal(shape, want) {
+		t.Fatalf("task children = %v, want %v", shape, want)
+	}
+	if c := design.Children[0]; c.Progress != 100 || c.Selected != "Postgres" || !slices.Equal(c.Options, []string{"Postgres", "SQLite"}) {
+		t.Errorf("resolved choice node = %+v", c)
+	}
+	if c := design.Children[1]; c.Progress != 0 || c.Selected != "" {
+		t.Errorf("pending choice node = %+v", c)
+	}
+	if s := design.Children[2]; s.Progress != 100 || s.Status != task.StatusDone || len(s.Children) != 0 {
+		t.Errorf("done subtask node = %+v", s)
+	}
+	if s := design.Children[3]; s.Progress != 0 || len(s.Children) != 1 || s.Children[0].Type != "choice" || s.Children[0].Title != "B-tree or hash?" {
+		t.Errorf("subtask with a choice = %+v", s)
+	}
+	if deploy := root.Children[1]; deploy.Progress != 100 || deploy.Children == nil || len(deploy.Children) != 0 {
+		t.Errorf("done task without children = %+v", deploy)
+	}
+
+	// Through the tool, on a saved project; leaves encode children as []
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{
+		Title:       "Design",
+		Description: "d",
+		Subtasks:    []task.Subtask{{Title: "Schema", Status: task.StatusDone}, {Title: "Indexes", Status: task.StatusTodo}},
+	})
+	result, err := tms.handleGetProjectAsTree(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	text := resultText(t, result, err)
+	if result.IsError {
+		t.Fatalf("get_project_as_tree: %s", text)
+	}
+	var tree treeNode
+	if err := json.Unmarshal([]byte(text), &tree); err != nil {
+		t.Fatalf("decode tree: %v", err)
+	}
+	if len(tree.Children) != 1 || len(tree.Children[0].Children) != 2 || tree.Children[0].Progress != 50 {
+		t.Errorf("tool tree = %+v", tree)
+	}
+	if !strings.Contains(text, `"children":[]`) {
+		t.Errorf("leaf nodes should encode children as []: %s", text)
+	}
+
+	result, err = tms.handleGetProjectAsTree(context.Background(), callTool(map[string]any{"project_name": "missing"}))
+	if category := errorCategory(t, result, err); category != ErrorCategoryNotFound {
+		t.Errorf("missing project: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestSetTaskCategoryGeneral(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Build", Description: "d", Category: task.CategoryMVP})
+
+	for _, value := range []string{"[GENERAL]", "general"} {
+		result, err := tms.handleSetTaskCategory(context.Background(), callTool(map[string]any{
+			"project_name": "p", "task_title": "Build", "category": value,
+		}))
+		if text := resultText(t, result, err); result.IsError {
+			t.Fatalf("set_task_category %q: %s", value, text)
+		}
+		got := reloadProject(t, tms, "p").Tasks[0]
+		if got.Category != "" || got.EffectiveCategory() != task.CategoryGeneral {
+			t.Errorf("after setting %q: category = %q, effective %q; want none, shown as [GENERAL]", value, got.Category, got.EffectiveCategory())
+		}
+	}
+
+	content, err := os.ReadFile(tms.taskManager.GetTaskFilePath("p"))
+	if err != nil {
+		t.Fatalf("read task file: %v", err)
+	}
+	if !strings.Contains(string(content), "[GENERAL] Build") {
+		t.Errorf("task file does not show the general category:\n%s", content)
+	}
+}
+
+func TestAllTasks(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "alpha",
+		task.Task{Title: "Schema", Description: "d", Priority: task.PriorityP0, Category: task.CategoryMVP, Tags: []string{"backend"}},
+		task.Task{Title: "Logo", Description: "d", Priority: task.PriorityP1, Category: task.CategoryUX, Status: task.StatusDone},
+		task.Task{Title: "API", Description: "d", Priority: task.PriorityP2, Tags: []string{"backend"}, Status: task.StatusInProgress,
+			Subtasks: []task.Subtask{{Title: "List", Status: task.StatusDone}, {Title: "Create", Status: task.StatusTodo}}},
+	)
+	newServerProject(t, tms, "beta",
+		task.Task{Title: "Outage", Description: "d", Priority: task.PriorityP0, Tags: []string{"backend", "ops"}},
+		task.Task{Title: "Cleanup", Description: "d", Priority: task.PriorityP3},
+	)
+
+	type allTasksResult struct {
+		Total      int                `json:"total"`
+		Count      int                `json:"count"`
+		HasMore    bool               `json:"has_more"`
+		NextOffset *int               `json:"next_offset"`
+		Tasks      []crossProjectTask `json:"tasks"`
+	}
+	allTasks := func(arguments map[string]any) allTasksResult {
+		t.Helper()
+		var result allTasksResult
+		r, err := tms.handleAllTasks(context.Background(), callTool(arguments))
+		decodeResult(t, r, err, &result)
+		return result
+	}
+	names := func(tasks []crossProjectTask) []string {
+		var names []string
+		for _, t := range tasks {
+			names = append(names, t.Project+"/"+t.Title)
+		}
+		return names
+	}
+
+	all := allTasks(map[string]any{})
+	if want := []string{"alpha/Schema", "alpha/Logo", "alpha/API", "beta/Outage", "beta/Cleanup"}; !slices.Equal(names(all.Tasks), want) {
+		t.Fatalf("all tasks = %v, want %v", names(all.Tasks), want)
+	}
+	if api := all.Tasks[2]; api.Progress != 50 || api.Status != task.StatusInProgress || api.Priority != task.PriorityP2 {
+		t.Errorf("API entry = %+v, want 50%% progress, in progress, P2", api)
+	}
+	if logo := all.Tasks[1]; logo.Progress != 100 || logo.Category != task.CategoryUX {
+		t.Errorf("Logo entry = %+v, want 100%% progress in [UX]", logo)
+	}
+
+	filters := []struct {
+		name      string
+		arguments map[string]any
+		want      []string
+	}{
+		{"status", map[string]any{"status": "todo"}, []string{"alpha/Schema", "beta/Outage", "beta/Cleanup"}},
+		{"priority", map[string]any{"priority": "P0"}, []string{"alpha/Schema", "beta/Outage"}},
+		{"category", map[string]any{"category": "MVP"}, []string{"alpha/Schema"}},
+		{"tag", map[string]any{"tag": "backend"}, []string{"alpha/Schema", "alpha/API", "beta/Outage"}},
+		{"combined", map[string]any{"tag": "backend", "status": "todo", "priority": "P0"}, []string{"alpha/Schema", "beta/Outage"}},
+		{"no match", map[string]any{"tag": "frontend"}, nil},
+	}
+	for _, tt := range filters {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allTasks(tt.arguments)
+			if !slices.Equal(names(got.Tasks), tt.want) || got.Total != len(tt.want) {
+				t.Errorf("tasks = %v (total %d), want %v", names(got.Tasks), got.Total, tt.want)
+			}
+		})
+	}
+
+	// Pagination
+	page := allTasks(map[string]any{"limit": 2.0})
+	if page.Total != 5 || page.Count != 2 || !page.HasMore || page.NextOffset == nil || *page.NextOffset != 2 {
+		t.Errorf("first page = total %d, count %d, has_more %v, next_offset %v", page.Total, page.Count, page.HasMore, page.NextOffset)
+	}
+	page = allTasks(map[string]any{"limit": 2.0, "offset": 4.0})
+	if !slices.Equal(names(page.Tasks), []string{"beta/Cleanup"}) || page.HasMore || page.NextOffset != nil {
+		t.Errorf("last page = %v, has_more %v, next_offset %v", names(page.Tasks), page.HasMore, page.NextOffset)
+	}
+	if page = allTasks(map[string]any{"offset": 10.0}); page.Count != 0 || page.Total != 5 || page.HasMore {
+		t.Errorf("offset past the end = count %d, total %d, has_more %v", page.Count, page.Total, page.HasMore)
+	}
+	for _, arguments := range []map[string]any{{"limit": 0.0}, {"limit": 501.0}, {"offset": -1.0}, {"status": "finished"}} {
+		r, err := tms.handleAllTasks(context.Background(), callTool(arguments))
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%v: category = %q, want %q", arguments, category, ErrorCategoryValidation)
+		}
+	}
+
+	// A save through the manager drops the cached tasks
+	if err := tms.taskManager.AddTasks("beta", []task.Task{{Title: "Postmortem", Description: "d"}}); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+	if got := allTasks(map[string]any{}); got.Total != 6 {
+		t.Errorf("after adding a task: total = %d, want 6", got.Total)
+	}
+}
+
+func TestIncompleteSubtasksMode(t *testing.T) {
+	shipIt := task.Task{
+		Title:       "Ship it",
+		Description: "d",
+		Status:      task.StatusInProgress,
+		Subtasks:    []task.Subtask{{Title: "Build", Status: task.StatusDone}, {Title: "Tag release", Status: task.StatusTodo}},
+	}
+	markDone := func(tms *TaskManagerServer, force bool) (*mcp.CallToolResult, error) {
+		return tms.handleUpdateTaskStatus(context.Background(), callTool(map[string]any{
+			"project_name": "p", "task_title": "Ship it", "status": "done", "force": force,
+		}))
+	}
+	subtaskStatuses := func(tms *TaskManagerServer) (task.TaskStatus, []task.TaskStatus) {
+		got := reloadProject(t, tms, "p").Tasks[0]
+		var statuses []task.TaskStatus
+		for _, subtask := range got.Subtasks {
+			statuses = append(statuses, subtask.Status)
+		}
+		return got.Status, statuses
+	}
+	allDone := []task.TaskStatus{task.StatusDone, task.StatusDone}
+
+	t.Run("complete mode completes open subtasks", func(t *testing.T) {
+		tms := newTestServer(t)
+		newServerProject(t, tms, "p", shipIt)
+		result, err := markDone(tms, false)
+		if text := resultText(t, result, err); result.IsError || !strings.Contains(text, "Auto-completed subtask 'Tag release'") {
+			t.Fatalf("update_task_status: %s", text)
+		}
+		if status, subtasks := subtaskStatuses(tms); status != task.StatusDone || !slices.Equal(subtasks, allDone) {
+			t.Errorf("after reload: task %s, subtasks %v", status, subtasks)
+		}
+	})
+
+	t.Run("confirm mode refuses without force", func(t *testing.T) {
+		t.Setenv("INCOMPLETE_SUBTASKS_MODE", "confirm")
+		tms := newTestServer(t)
+		newServerProject(t, tms, "p", shipIt)
+
+		result, err := markDone(tms, false)
+		text := resultText(t, result, err)
+		if category := errorCategory(t, result, err); category != ErrorCategoryConflict {
+			t.Errorf("category = %q, want %q", category, ErrorCategoryConflict)
+		}
+		if !strings.Contains(text, "Tag release") || strings.Contains(text, "Build,") {
+			t.Errorf("warning should list only the incomplete subtasks: %s", text)
+		}
+		if status, subtasks := subtaskStatuses(tms); status != task.StatusInProgress || subtasks[1] != task.StatusTodo {
+			t.Errorf("refused update changed the file: task %s, subtasks %v", status, subtasks)
+		}
+
+		// The bulk tool reports the same conflict per entry
+		var bulk struct {
+			Results []statusUpdateResult `json:"results"`
+		}
+		result, err = tms.handleUpdateTaskStatuses(context.Background(), callTool(map[string]any{
+			"project_name": "p",
+			"updates":      []any{map[string]any{"task_title": "Ship it", "status": "done"}},
+		}))
+		decodeResult(t, result, err, &bulk)
+		if len(bulk.Results) != 1 || bulk.Results[0].Result != statusUpdateConflict {
+			t.Errorf("update_task_statuses results = %+v, want one conflict", bulk.Results)
+		}
+	})
+
+	t.Run("confirm mode proceeds with force", func(t *testing.T) {
+		t.Setenv("INCOMPLETE_SUBTASKS_MODE", "confirm")
+		tms := newTestServer(t)
+		newServerProject(t, tms, "p", shipIt)
+		result, err := markDone(tms, true)
+		if text := resultText(t, result, err); result.IsError {
+			t.Fatalf("update_task_status with force: %s", text)
+		}
+		if status, subtasks := subtaskStatuses(tms); status != task.StatusDone || !slices.Equal(subtasks, allDone) {
+			t.Errorf("after reload: task %s, subtasks %v", status, subtasks)
+		}
+	})
+}
+
+func TestFindDuplicatesTool(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Write API documentation", Description: "Reference docs"},
+		task.Task{Title: "Write API documentaton", Description: "Endpoint docs"},
+		task.Task{Title: "Deploy", Description: "Ship it"},
+	)
+
+	var result struct {
+		GroupCount int                   `json:"group_count"`
+		Groups     []task.DuplicateGroup `json:"groups"`
+	}
+	r, err := tms.handleFindDuplicates(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	decodeResult(t, r, err, &result)
+	if result.GroupCount != 1 || len(result.Groups[0].Tasks) != 2 {
+		t.Errorf("fuzzy by default: groups = %+v, want the two documentation tasks", result.Groups)
+	}
+
+	r, err = tms.handleFindDuplicates(context.Background(), callTool(map[string]any{"project_name": "p", "fuzzy": false}))
+	decodeResult(t, r, err, &result)
+	if result.GroupCount != 0 {
+		t.Errorf("exact only: groups = %+v, want none", result.Groups)
+	}
+
+	for _, threshold := range []float64{0, -0.5, 1.5} {
+		r, err = tms.handleFindDuplicates(context.Background(), callTool(map[string]any{"project_name": "p", "threshold": threshold}))
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("threshold %v: category = %q, want %q", threshold, category, ErrorCategoryValidation)
+		}
+	}
+}
+
+func TestReconcileProjectAfterHandEdit(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Ship it", Description: "d", Status: task.StatusInProgress,
+			Subtasks: []task.Subtask{{Title: "Build", Status: task.StatusTodo}, {Title: "Tag release", Status: task.StatusTodo}}},
+		task.Task{Title: "Later", Description: "d", Subtasks: []task.Subtask{{Title: "Plan", Status: task.StatusTodo}}},
+	)
+
+	// Check off both subtasks of the first task by hand
+	path := tms.taskManager.GetTaskFilePath("p")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read task file: %v", err)
+	}
+	edited := strings.Replace(string(content), "- [ ] Build", "- [x] Build", 1)
+	edited = strings.Replace(edited, "- [ ] Tag release", "- [x] Tag release", 1)
+	if edited == string(content) {
+		t.Fatalf("task file has no unchecked subtasks to edit:\n%s", content)
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatalf("write task file: %v", err)
+	}
+	tms.taskManager.InvalidateCache("p")
+
+	var result struct {
+		DryRun      bool     `json:"dry_run"`
+		Updates     []string `json:"updates"`
+		UpdateCount int      `json:"update_count"`
+		Saved       bool     `json:"saved"`
+	}
+	reconcile := func(dryRun bool) {
+		t.Helper()
+		r, err := tms.handleReconcileProject(context.Background(), callTool(map[string]any{"project_name": "p", "dry_run": dryRun}))
+		decodeResult(t, r, err, &result)
+	}
+
+	reconcile(true)
+	if !result.DryRun || result.UpdateCount != 1 || result.Saved || !strings.Contains(result.Updates[0], "Ship it") {
+		t.Fatalf("dry run = %+v, want one unsaved update for Ship it", result)
+	}
+	if after, _ := os.ReadFile(path); string(after) != edited {
+		t.Errorf("dry run rewrote the task file")
+	}
+
+	reconcile(false)
+	if result.UpdateCount != 1 || !result.Saved {
+		t.Fatalf("reconcile = %+v, want one saved update", result)
+	}
+	project := reloadProject(t, tms, "p")
+	if project.Tasks[0].Status != task.StatusDone || project.Tasks[1].Status != task.StatusTodo {
+		t.Errorf("after reconcile: statuses %s, %s; want done, todo", project.Tasks[0].Status, project.Tasks[1].Status)
+	}
+
+	// Nothing left to do
+	reconcile(false)
+	if result.UpdateCount != 0 || result.Saved || result.Updates == nil {
+		t.Errorf("second reconcile = %+v, want no updates", result)
+	}
+
+	r, err := tms.handleReconcileProject(context.Background(), callTool(map[string]any{"project_name": "missing"}))
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("missing project: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestListProjectsReportsUnreadableProjects(t *testing.T) {
+	t.Setenv("MAX_FILE_SIZE", "2048")
+	tms := newTestServer(t)
+	newServerProject(t, tms, "full", task.Task{Title: "Build", Description: "d"})
+	newServerProject(t, tms, "empty")
+	newServerProject(t, tms, "broken", task.Task{Title: "Big", Description: strings.Repeat("x", 4096)})
+	for _, name := range []string{"full", "empty", "broken"} {
+		tms.taskManager.InvalidateCache(name)
+	}
+
+	var result struct {
+		Projects []struct {
+			Name string `json:"name"`
+		} `json:"projects"`
+		Unreadable map[string]string `json:"unreadable_projects"`
+	}
+	listProjects := func(excludeEmpty bool) []string {
+		t.Helper()
+		result.Unreadable = nil
+		r, err := tms.handleListProjects(context.Background(), callTool(map[string]any{"exclude_empty": excludeEmpty}))
+		decodeResult(t, r, err, &result)
+		var names []string
+		for _, project := range result.Projects {
+			names = append(names, project.Name)
+		}
+		return names
+	}
+
+	if names := listProjects(false); !slices.Equal(names, []string{"empty", "full"}) {
+		t.Errorf("list_projects = %v, want [empty full]", names)
+	}
+	if _, reported := result.Unreadable["broken"]; !reported || len(result.Unreadable) != 1 {
+		t.Errorf("unreadable_projects = %v, want only broken", result.Unreadable)
+	}
+
+	if names := listProjects(true); !slices.Equal(names, []string{"full"}) {
+		t.Errorf("list_projects with exclude_empty = %v, want [full]", names)
+	}
+	if _, reported := result.Unreadable["broken"]; !reported {
+		t.Errorf("unreadable_projects with exclude_empty = %v, want broken reported", result.Unreadable)
+	}
+}
+
+func TestParsePRD(t *testing.T) {
+	tms := newTestServer(t)
+	prd := `# Checkout [MVP]
+
+## Cart (P0)
+Customers keep items between visits.
+
+## Payments
+- Card payments (P1)
+  - Stripe integration
+  - Refunds
+- Invoices [INFRA]
+`
+	type prdResult struct {
+		TasksCreated    int               `json:"tasks_created"`
+		SubtasksCreated int               `json:"subtasks_created"`
+		ProjectCreated  bool              `json:"project_created"`
+		Skipped         []json.RawMessage `json:"skipped"`
+	}
+	parsePRD := func(dryRun bool) prdResult {
+		t.Helper()
+		var result prdResult
+		r, err := tms.handleParsePRD(context.Background(), callTool(map[string]any{
+			"project_name": "shop", "prd_content": prd, "dry_run": dryRun,
+		}))
+		decodeResult(t, r, err, &result)
+		return result
+	}
+
+	// A dry run reports the extraction but writes nothing
+	preview := parsePRD(true)
+	if preview.TasksCreated != 3 || preview.SubtasksCreated != 2 || preview.ProjectCreated {
+		t.Fatalf("dry run = %+v, want 3 tasks and 2 subtasks previewed", preview)
+	}
+	if tms.taskManager.ProjectExists("shop") {
+		t.Fatal("dry run created the project")
+	}
+
+	before := time.Now().UTC().Truncate(time.Second)
+	created := parsePRD(false)
+	if created.TasksCreated != 3 || created.SubtasksCreated != 2 || !created.ProjectCreated {
+		t.Fatalf("parse_prd = %+v, want 3 tasks and 2 subtasks in a new project", created)
+	}
+
+	project := reloadProject(t, tms, "shop")
+	type summary struct {
+		id       int
+		title    string
+		priority task.TaskPriority
+		category task.TaskCategory
+		subtasks int
+	}
+	want := []summary{
+		{1, "Cart", task.PriorityP0, task.CategoryMVP, 0},
+		{2, "Card payments", task.PriorityP1, task.CategoryMVP, 2},
+		{3, "Invoices", task.DefaultTaskPriority(), task.CategoryInfra, 0},
+	}
+	if len(project.Tasks) != len(want) {
+		t.Fatalf("reloaded project has %d tasks, want %d", len(project.Tasks), len(want))
+	}
+	for i, w := range want {
+		got := project.Tasks[i]
+		if g := (summary{got.ID, got.Title, got.Priority, got.Category, len(got.Subtasks)}); g != w {
+			t.Errorf("task %d = %+v, want %+v", i+1, g, w)
+		}
+	}
+	if desc := project.Tasks[0].Description; desc != "Customers keep items between visits." {
+		t.Errorf("Cart description = %q", desc)
+	}
+	for _, subtask := range project.Tasks[1].Subtasks {
+		if subtask.CreatedAt.Before(before) || subtask.CreatedAt.Location() != time.UTC || subtask.Status != task.StatusTodo {
+			t.Errorf("subtask %q: created %s, status %s; want a UTC time from this run and todo", subtask.Title, subtask.CreatedAt, subtask.Status)
+		}
+	}
+
+	// Running it again skips the tasks that now exist
+	again := parsePRD(false)
+	if again.TasksCreated != 0 || len(again.Skipped) != 3 || again.ProjectCreated {
+		t.Errorf("second parse_prd = %+v, want every task skipped", again)
+	}
+	if n := len(reloadProject(t, tms, "shop").Tasks); n != 3 {
+		t.Errorf("project has %d tasks after the second run, want 3", n)
+	}
+}
+
+func TestAddTaskBatchMode(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Existing", Description: "d"})
+
+	addTask := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["batch_mode"] = true
+		return tms.handleAddTask(context.Background(), callTool(arguments))
+	}
+	countTasks := func() int {
+		t.Helper()
+		var result struct {
+			Total int `json:"total"`
+		}
+		r, err := tms.handleAllTasks(context.Background(), callTool(map[string]any{}))
+		decodeResult(t, r, err, &result)
+		return result.Total
+	}
+
+	// Fill the cross-project cache before appending
+	if got := countTasks(); got != 1 {
+		t.Fatalf("total before appending = %d, want 1", got)
+	}
+	r, err := addTask(map[string]any{
+		"project_name": "p", "title": "Appended", "description": "Added in batch",
+		"subtasks": []any{"First", "Second"}, "priority": "P0", "category": "INFRA", "complexity": "high", "estimated_hours": 3.0,
+	})
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("add_task in batch mode failed: %s", text)
+	}
+	if got := countTasks(); got != 2 {
+		t.Errorf("total after appending = %d, want 2", got)
+	}
+
+	project, err := tms.taskManager.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if len(project.Tasks) != 2 {
+		t.Fatalf("project has %d tasks, want 2", len(project.Tasks))
+	}
+	appended := project.Tasks[1]
+	if appended.ID != 2 || appended.Title != "Appended" || appended.Description != "Added in batch" ||
+		appended.Priority != task.PriorityP0 || appended.Category != task.CategoryInfra ||
+		appended.Complexity != task.ComplexityHigh || appended.EstimatedHours != 3 || appended.Status != task.StatusTodo {
+		t.Errorf("appended task = %+v", appended)
+	}
+	if len(appended.Subtasks) != 2 || appended.Subtasks[0].Title != "First" || appended.Subtasks[1].Title != "Second" {
+		t.Errorf("appended subtasks = %+v, want First and Second", appended.Subtasks)
+	}
+
+	// Batch mode still rejects duplicates and unknown projects
+	r, err = addTask(map[string]any{"project_name": "p", "title": "Existing", "description": "d"})
+	if category := errorCategory(t, r, err); category != ErrorCategoryConflict {
+		t.Errorf("duplicate title: category = %q, want %q", category, ErrorCategoryConflict)
+	}
+	r, err = addTask(map[string]any{"project_name": "missing", "title": "New", "description": "d"})
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("unknown project: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestDeleteAndArchiveProjectTools(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "doomed", task.Task{Title: "Task", Description: "d"})
+	newServerProject(t, tms, "old", task.Task{Title: "Task", Description: "d"})
+
+	deleteProject := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		return tms.handleDeleteProject(context.Background(), callTool(arguments))
+	}
+
+	// Deletion is refused without confirm
+	for _, arguments := range []map[string]any{{"project_name": "doomed"}, {"project_name": "doomed", "confirm": false}} {
+		r, err := deleteProject(arguments)
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%v: category = %q, want %q", arguments, category, ErrorCategoryValidation)
+		}
+	}
+	if !tms.taskManager.ProjectExists("doomed") {
+		t.Fatal("an unconfirmed delete removed the project")
+	}
+
+	var deleted struct {
+		Project string `json:"project"`
+		Deleted bool   `json:"deleted"`
+	}
+	r, err := deleteProject(map[string]any{"project_name": "doomed", "confirm": true})
+	decodeResult(t, r, err, &deleted)
+	if deleted.Project != "doomed" || !deleted.Deleted || tms.taskManager.ProjectExists("doomed") {
+		t.Errorf("delete result = %+v, project exists %v", deleted, tms.taskManager.ProjectExists("doomed"))
+	}
+	r, err = deleteProject(map[string]any{"project_name": "doomed", "confirm": true})
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("deleting again: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+
+	var archived struct {
+		Archived    bool   `json:"archived"`
+		ArchivePath string `json:"archive_path"`
+	}
+	r, err = tms.handleArchiveProject(context.Background(), callTool(map[string]any{"project_name": "old"}))
+	decodeResult(t, r, err, &archived)
+	if _, statErr := os.Stat(archived.ArchivePath); !archived.Archived || statErr != nil {
+		t.Errorf("archive result = %+v (%v), want the file at archive_path", archived, statErr)
+	}
+	if projects, err := tms.taskManager.ListProjects(); err != nil || len(projects) != 0 {
+		t.Errorf("projects after delete and archive = %v, %v; want none", projects, err)
+	}
+	r, err = tms.handleArchiveProject(context.Background(), callTool(map[string]any{"project_name": "old"}))
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("archiving again: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestIncludeResolvedChoices(t *testing.T) {
+	tms := newTestServer(t)
+	resolvedAt := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	newServerProject(t, tms, "p", task.Task{
+		Title:       "Pick a stack",
+		Description: "d",
+		Choices: []task.Choice{
+			{Question: "Which database?", Options: []string{"Postgres", "SQLite"}, Selected: "Postgres", Reasoning: "Concurrent writers", ResolvedAt: &resolvedAt},
+			{Question: "Which cache?", Options: []string{"Redis", "None"}},
+		},
+	})
+	questions := func(choices []task.Choice) []string {
+		var questions []string
+		for _, choice := range choices {
+			questions = append(questions, choice.Question)
+		}
+		return questions
+	}
+
+	getTask := func(arguments map[string]any) task.Task {
+		t.Helper()
+		var result struct {
+			Task task.Task `json:"task"`
+		}
+		arguments["project_name"], arguments["task_title"] = "p", "Pick a stack"
+		r, err := tms.handleGetTask(context.Background(), callTool(arguments))
+		decodeResult(t, r, err, &result)
+		return result.Task
+	}
+	all := getTask(map[string]any{})
+	if got := questions(all.Choices); !slices.Equal(got, []string{"Which database?", "Which cache?"}) {
+		t.Errorf("get_task choices = %v, want both by default", got)
+	}
+	if decided := all.Choices[0]; decided.Selected != "Postgres" || decided.Reasoning != "Concurrent writers" || decided.ResolvedAt == nil {
+		t.Errorf("resolved choice = %+v, want its selection and reasoning", decided)
+	}
+	if got := questions(getTask(map[string]any{"include_resolved_choices": false}).Choices); !slices.Equal(got, []string{"Which cache?"}) {
+		t.Errorf("get_task choices without resolved = %v, want [Which cache?]", got)
+	}
+
+	suggest := func(arguments map[string]any) map[string]any {
+		t.Helper()
+		_, raw := suggestionTitles(t, tms, arguments)
+		suggestions, _ := raw["suggestions"].([]any)
+		if len(suggestions) != 1 {
+			t.Fatalf("got %d suggestions, want 1", len(suggestions))
+		}
+		return suggestions[0].(map[string]any)
+	}
+	if suggestion := suggest(map[string]any{"project_name": "p"}); suggestion["resolved_choices"] != nil {
+		t.Errorf("suggestion includes resolved choices by default: %v", suggestion["resolved_choices"])
+	}
+	suggestion := suggest(map[string]any{"project_name": "p", "include_resolved_choices": true})
+	resolved, _ := suggestion["resolved_choices"].([]any)
+	if len(resolved) != 1 {
+		t.Fatalf("resolved_choices = %v, want one choice", suggestion["resolved_choices"])
+	}
+	if choice := resolved[0].(map[string]any); choice["question"] != "Which database?" || choice["selected"] != "Postgres" || choice["reasoning"] != "Concurrent writers" {
+		t.Errorf("resolved choice = %v", choice)
+	}
+	if suggestion["pending_choices"] == nil {
+		t.Error("suggestion dropped its pending choices")
+	}
+}
+
+func TestFilterTasksTool(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "API", Description: "d", Status: task.StatusInProgress, Priority: task.PriorityP0, Assignee: "dana", Tags: []string{"backend", "api"}},
+		task.Task{Title: "Schema", Description: "d", Priority: task.PriorityP0, Tags: []string{"backend"}},
+		task.Task{Title: "Logo", Description: "d", Status: task.StatusInProgress, Priority: task.PriorityP2, Category: task.CategoryUX},
+	)
+
+	filterTasks := func(arguments map[string]any) []string {
+		t.Helper()
+		var result struct {
+			Count int                `json:"count"`
+			Tasks []task.TaskSummary `json:"tasks"`
+		}
+		arguments["project_name"] = "p"
+		r, err := tms.handleFilterTasks(context.Background(), callTool(arguments))
+		decodeResult(t, r, err, &result)
+		titles := []string{}
+		for _, summary := range result.Tasks {
+			titles = append(titles, summary.Title)
+		}
+		if result.Count != len(titles) {
+			t.Errorf("count = %d for %d tasks", result.Count, len(titles))
+		}
+		return titles
+	}
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		want      []string
+	}{
+		{"no filter", map[string]any{}, []string{"API", "Schema", "Logo"}},
+		{"P0 in progress", map[string]any{"priority": "p0", "status": "in_progress"}, []string{"API"}},
+		{"category", map[string]any{"category": "ux"}, []string{"Logo"}},
+		{"any tag", map[string]any{"tags": []any{"api", "backend"}}, []string{"API", "Schema"}},
+		{"all tags", map[string]any{"tags": []any{"api", "backend"}, "tag_match": "all"}, []string{"API"}},
+		{"assignee", map[string]any{"assignee": "DANA"}, []string{"API"}},
+		{"no match", map[string]any{"priority": "P3"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterTasks(tt.arguments); !slices.Equal(got, tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, arguments := range []map[string]any{{"status": "finished"}, {"priority": "P9"}, {"tag_match": "some"}, {"min_progress": 80.0, "max_progress": 20.0}} {
+		arguments["project_name"] = "p"
+		r, err := tms.handleFilterTasks(context.Background(), callTool(arguments))
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%v: category = %q, want %q", arguments, category, ErrorCategoryValidation)
+		}
+	}
+}
+
+func TestTaskDependencyTools(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Schema", Description: "d"},
+		task.Task{Title: "Handlers", Description: "d"},
+		task.Task{Title: "Release", Description: "d"},
+	)
+
+	type dependencyResult struct {
+		Changed      bool  `json:"changed"`
+		Dependencies []int `json:"dependencies"`
+	}
+	call := func(add bool, taskTitle, dependsOn string) (*mcp.CallToolResult, error) {
+		request := callTool(map[string]any{"project_name": "p", "task_title": taskTitle, "depends_on_title": dependsOn})
+		if add {
+			return tms.handleAddTaskDependency(context.Background(), request)
+		}
+		return tms.handleRemoveTaskDependency(context.Background(), request)
+	}
+	change := func(add bool, taskTitle, dependsOn string) dependencyResult {
+		t.Helper()
+		var result dependencyResult
+		r, err := call(add, taskTitle, dependsOn)
+		decodeResult(t, r, err, &result)
+		return result
+	}
+	dependencies := func() [][]int {
+		t.Helper()
+		var deps [][]int
+		for _, tk := range reloadProject(t, tms, "p").Tasks {
+			deps = append(deps, tk.Dependencies)
+		}
+		return deps
+	}
+
+	// Handlers -> Schema, Release -> Handlers
+	if got := change(true, "Handlers", "Schema"); !got.Changed || !slices.Equal(got.Dependencies, []int{1}) {
+		t.Errorf("adding Handlers -> Schema = %+v", got)
+	}
+	if got := change(true, "Handlers", "Schema"); got.Changed {
+		t.Errorf("adding an existing dependency reported a change: %+v", got)
+	}
+	change(true, "Release", "Handlers")
+	if got := dependencies(); got[0] != nil || !slices.Equal(got[1], []int{1}) || !slices.Equal(got[2], []int{2}) {
+		t.Fatalf("dependencies after adding = %v", got)
+	}
+
+	rejected := []struct {
+		name, task, dependsOn string
+	}{
+		{"self dependency", "Schema", "Schema"},
+		{"direct cycle", "Schema", "Handlers"},
+		{"indirect cycle", "Schema", "Release"},
+	}
+	for _, tt := range rejected {
+		r, err := call(true, tt.task, tt.dependsOn)
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, ErrorCategoryValidation)
+		}
+	}
+	if got := dependencies(); got[0] != nil {
+		t.Errorf("a rejected dependency was saved: Schema depends on %v", got[0])
+	}
+
+	r, err := call(true, "Handlers", "Missing")
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("unknown dependency: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+
+	if got := change(false, "Release", "Handlers"); !got.Changed || len(got.Dependencies) != 0 {
+		t.Errorf("removing Release -> Handlers = %+v", got)
+	}
+	if got := change(false, "Release", "Schema"); got.Changed {
+		t.Errorf("removing an absent dependency reported a change: %+v", got)
+	}
+	if got := dependencies(); len(got[2]) != 0 || !slices.Equal(got[1], []int{1}) {
+		t.Errorf("dependencies after removing = %v", got)
+	}
+	// With Release free again, it can be made a prerequisite of Schema
+	change(true, "Schema", "Release")
+}
+
+func TestMoveTaskTool(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "backend", task.Task{Title: "Write API docs", Description: "d"}, task.Task{Title: "Shared", Description: "d"})
+	newServerProject(t, tms, "docs", task.Task{Title: "Shared", Description: "d"})
+
+	moveTask := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["from_project"], arguments["to_project"] = "backend", "docs"
+		return tms.handleMoveTask(context.Background(), callTool(arguments))
+	}
+
+	var result struct {
+		Move task.TaskMove `json:"move"`
+	}
+	r, err := moveTask(map[string]any{"task_title": "api docs", "partial_match": true})
+	decodeResult(t, r, err, &result)
+	if result.Move.Title != "Write API docs" || result.Move.NewID != 2 {
+		t.Errorf("move = %+v, want 'Write API docs' as task 2", result.Move)
+	}
+	if titles := reloadProject(t, tms, "docs").Tasks; len(titles) != 2 || titles[1].Title != "Write API docs" {
+		t.Errorf("docs tasks = %+v, want the moved task appended", titles)
+	}
+	if tasks := reloadProject(t, tms, "backend").Tasks; len(tasks) != 1 || tasks[0].Title != "Shared" {
+		t.Errorf("backend tasks = %+v, want only Shared left", tasks)
+	}
+
+	r, err = moveTask(map[string]any{"task_title": "Shared"})
+	if category := errorCategory(t, r, err); category != ErrorCategoryConflict {
+		t.Errorf("moving onto an existing title: category = %q, want %q", category, ErrorCategoryConflict)
+	}
+	if tasks := reloadProject(t, tms, "backend").Tasks; len(tasks) != 1 {
+		t.Errorf("a refused move changed the source: %+v", tasks)
+	}
+}
+
+func TestUndoLastChangeTool(t *testing.T) {
+	tms := newTestServer(t)
+	if err := tms.taskManager.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	undo := func() (*mcp.CallToolResult, error) {
+		return tms.handleUndoLastChange(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	}
+
+	// A new project has nothing to undo
+	r, err := undo()
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("undo with no history: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+
+	r, err = tms.handleAddTask(context.Background(), callTool(map[string]any{"project_name": "p", "title": "Draft", "description": "d"}))
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("add_task: %s", text)
+	}
+	r, err = tms.handleUpdateTaskStatus(context.Background(), callTool(map[string]any{"project_name": "p", "task_title": "Draft", "status": "in_progress"}))
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("update_task_status: %s", text)
+	}
+
+	r, err = undo()
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("undo_last_change: %s", text)
+	}
+	if tasks := reloadProject(t, tms, "p").Tasks; len(tasks) != 1 || tasks[0].Status != task.StatusTodo {
+		t.Errorf("tasks after undoing the status change = %+v, want Draft back in todo", tasks)
+	}
+	r, err = undo()
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("undo_last_change: %s", text)
+	}
+	if tasks := reloadProject(t, tms, "p").Tasks; len(tasks) != 0 {
+		t.Errorf("tasks after undoing the add = %+v, want none", tasks)
+	}
+	r, err = undo()
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("undo past the start: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestSetTaskPriorityAndCategory(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Polish", Description: "d", Priority: task.PriorityP1},
+		task.Task{Title: "Outage fix", Description: "d", Priority: task.PriorityP3},
+	)
+	setField := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["project_name"] = "p"
+		return handler(context.Background(), callTool(arguments))
+	}
+
+	if titles, _ := suggestionTitles(t, tms, map[string]any{"project_name": "p"}); len(titles) != 2 || titles[0] != "Polish" {
+		t.Fatalf("suggestions before reprioritizing = %v, want Polish first", titles)
+	}
+	before := reloadProject(t, tms, "p").Tasks[1].UpdatedAt
+
+	r, err := setField(tms.handleSetTaskPriority, map[string]any{"task_title": "Outage fix", "priority": "p0"})
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("set_task_priority: %s", text)
+	}
+	r, err = setField(tms.handleSetTaskCategory, map[string]any{"task_title": "Outage fix", "category": "infra"})
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("set_task_category: %s", text)
+	}
+
+	got := reloadProject(t, tms, "p").Tasks[1]
+	if got.Priority != task.PriorityP0 || got.Category != task.CategoryInfra || got.UpdatedAt.Before(before) {
+		t.Errorf("after reload: priority %s, category %q, updated %s (was %s)", got.Priority, got.Category, got.UpdatedAt, before)
+	}
+	if titles, _ := suggestionTitles(t, tms, map[string]any{"project_name": "p"}); len(titles) != 2 || titles[0] != "Outage fix" {
+		t.Errorf("suggestions after reprioritizing = %v, want Outage fix first", titles)
+	}
+
+	failures := []struct {
+		name      string
+		handler   func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		arguments map[string]any
+		want      string
+	}{
+		{"unknown priority", tms.handleSetTaskPriority, map[string]any{"task_title": "Polish", "priority": "P5"}, ErrorCategoryValidation},
+		{"priority word", tms.handleSetTaskPriority, map[string]any{"task_title": "Polish", "priority": "urgent"}, ErrorCategoryValidation},
+		{"missing priority", tms.handleSetTaskPriority, map[string]any{"task_title": "Polish"}, ErrorCategoryValidation},
+		{"priority of a missing task", tms.handleSetTaskPriority, map[string]any{"task_title": "Nope", "priority": "P0"}, ErrorCategoryNotFound},
+		{"unknown category", tms.handleSetTaskCategory, map[string]any{"task_title": "Polish", "category": "[OPS]"}, ErrorCategoryValidation},
+		{"category of a missing task", tms.handleSetTaskCategory, map[string]any{"task_title": "Nope", "category": "UX"}, ErrorCategoryNotFound},
+	}
+	for _, tt := range failures {
+		r, err := setField(tt.handler, tt.arguments)
+		if category := errorCategory(t, r, err); category != tt.want {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, tt.want)
+		}
+	}
+	if polish := reloadProject(t, tms, "p").Tasks[0]; polish.Priority != task.PriorityP1 || polish.Category != "" {
+		t.Errorf("a rejected change was saved: %+v", polish)
+	}
+}
+
+func TestAddTaskPriorityCategoryAndComplexity(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Existing", Description: "d"})
+	addTask := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["project_name"] = "p"
+		arguments["description"] = "d"
+		return tms.handleAddTask(context.Background(), callTool(arguments))
+	}
+
+	r, err := addTask(map[string]any{"title": "Provision cluster", "priority": "p0", "category": "infra", "complexity": "HIGH", "estimated_hours": 5.0})
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("add_task with fields: %s", text)
+	}
+	r, err = addTask(map[string]any{"title": "Plain"})
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("add_task without fields: %s", text)
+	}
+
+	project := reloadProject(t, tms, "p")
+	if len(project.Tasks) != 3 {
+		t.Fatalf("project has %d tasks, want 3", len(project.Tasks))
+	}
+	if got := project.Tasks[1]; got.Priority != task.PriorityP0 || got.Category != task.CategoryInfra ||
+		got.Complexity != task.ComplexityHigh || got.EstimatedHours != 5 || got.Status != task.StatusTodo {
+		t.Errorf("task with fields after reload = %+v", got)
+	}
+	if got := project.Tasks[2]; got.Priority != task.DefaultTaskPriority() || got.Category != "" ||
+		got.Complexity != "" || got.EstimatedHours != 0 || got.Status != task.StatusTodo {
+		t.Errorf("task without fields after reload = %+v, want the defaults", got)
+	}
+
+	failures := []struct {
+		name      string
+		arguments map[string]any
+	}{
+		{"unknown priority", map[string]any{"title": "Bad priority", "priority": "P9"}},
+		{"unknown category", map[string]any{"title": "Bad category", "category": "OPS"}},
+		{"unknown complexity", map[string]any{"title": "Bad complexity", "complexity": "huge"}},
+		{"negative hours", map[string]any{"title": "Bad hours", "estimated_hours": -1.0}},
+	}
+	for _, tt := range failures {
+		r, err := addTask(tt.arguments)
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, ErrorCategoryValidation)
+		}
+	}
+	if n := len(reloadProject(t, tms, "p").Tasks); n != 3 {
+		t.Errorf("project has %d tasks after the rejected calls, want 3", n)
+	}
+}
+
+func TestReorderTasksTool(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p",
+		task.Task{Title: "Write docs", Description: "d"},
+		task.Task{Title: "Fix login", Description: "d"},
+		task.Task{Title: "Ship release", Description: "d"},
+	)
+	reorder := func(order ...any) (*mcp.CallToolResult, error) {
+		return tms.handleReorderTasks(context.Background(), callTool(map[string]any{"project_name": "p", "order": order}))
+	}
+
+	failures := []struct {
+		name  string
+		order []any
+		want  string
+	}{
+		{"empty order", nil, ErrorCategoryValidation},
+		{"not a permutation", []any{2.0, 1.0}, ErrorCategoryValidation},
+		{"duplicate ID", []any{2.0, 1.0, 1.0}, ErrorCategoryValidation},
+		{"duplicate by title and ID", []any{"Fix login", 2.0, 3.0}, ErrorCategoryValidation},
+		{"unknown ID", []any{2.0, 1.0, 9.0}, ErrorCategoryNotFound},
+		{"unknown title", []any{"Fix login", "Write docs", "Celebrate"}, ErrorCategoryNotFound},
+		{"fractional ID", []any{2.5, 1.0, 3.0}, ErrorCategoryValidation},
+		{"wrong type", []any{true, 1.0, 3.0}, ErrorCategoryValidation},
+	}
+	for _, tt := range failures {
+		r, err := reorder(tt.order...)
+		if category := errorCategory(t, r, err); category != tt.want {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, tt.want)
+		}
+	}
+
+	r, err := reorder("Fix login", 3.0, "Write docs")
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("reorder_tasks: %s", text)
+	}
+	var got []string
+	for _, reloaded := range reloadProject(t, tms, "p").Tasks {
+		got = append(got, reloaded.Title)
+	}
+	if want := []string{"Fix login", "Ship release", "Write docs"}; !slices.Equal(got, want) {
+		t.Errorf("order after reload = %v, want %v", got, want)
+	}
+
+	var next struct {
+		Task string `json:"task"`
+	}
+	r, err = tms.handleGetNextTask(context.Background(), callTool(map[string]any{"project_name": "p"}))
+	decodeResult(t, r, err, &next)
+	if next.Task != "Fix login" {
+		t.Errorf("get_next_task after reordering = %q, want Fix login", next.Task)
+	}
+}
+
+func TestProjectTemplateTools(t *testing.T) {
+	tms := newTestServer(t)
+
+	var listed struct {
+		Templates []task.ProjectTemplate `json:"templates"`
+	}
+	r, err := tms.handleListTemplates(context.Background(), callTool(map[string]any{}))
+	decodeResult(t, r, err, &listed)
+	var names []string
+	for _, template := range listed.Templates {
+		names = append(names, template.Name)
+	}
+	if want := []string{"cli-tool", "library", "web-app"}; !slices.Equal(names, want) {
+		t.Fatalf("list_templates = %v, want %v", names, want)
+	}
+
+	var created struct {
+		Tasks int `json:"tasks"`
+	}
+	r, err = tms.handleCreateProjectFromTemplate(context.Background(), callTool(map[string]any{"project_name": "tool", "template": "cli-tool"}))
+	decodeResult(t, r, err, &created)
+	if created.Tasks != listed.Templates[0].TaskCount {
+		t.Errorf("created %d tasks, cli-tool lists %d", created.Tasks, listed.Templates[0].TaskCount)
+	}
+	if n := len(reloadProject(t, tms, "tool").Tasks); n != created.Tasks {
+		t.Errorf("project has %d tasks after reload, want %d", n, created.Tasks)
+	}
+
+	failures := []struct {
+		name      string
+		arguments map[string]any
+		want      string
+	}{
+		{"existing project", map[string]any{"project_name": "tool", "template": "library"}, ErrorCategoryConflict},
+		{"unknown template", map[string]any{"project_name": "other", "template": "mobile-app"}, ErrorCategoryNotFound},
+		{"missing template", map[string]any{"project_name": "other"}, ErrorCategoryValidation},
+		{"invalid project name", map[string]any{"project_name": "../other", "template": "library"}, ErrorCategoryValidation},
+	}
+	for _, tt := range failures {
+		r, err := tms.handleCreateProjectFromTemplate(context.Background(), callTool(tt.arguments))
+		if category := errorCategory(t, r, err); category != tt.want {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, tt.want)
+		}
+	}
+	if n := len(reloadProject(t, tms, "tool").Tasks); n != created.Tasks {
+		t.Errorf("project has %d tasks after the conflict, want %d", n, created.Tasks)
+	}
+}
+
+func TestApplySubtaskTemplate(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{
+		Title: "Add search", Description: "d",
+		Subtasks: []task.Subtask{{Title: "write TESTS", Status: task.StatusDone}},
+	})
+	apply := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["project_name"] = "p"
+		return tms.handleApplySubtaskTemplate(context.Background(), callTool(arguments))
+	}
+	var result struct {
+		Added   []string `json:"added"`
+		Skipped []string `json:"skipped"`
+	}
+
+	r, err := apply(map[string]any{"task_title": "Add search", "template": "code-review"})
+	decodeResult(t, r, err, &result)
+	if want := []string{"Update docs", "Self-review the diff"}; !slices.Equal(result.Added, want) || !slices.Equal(result.Skipped, []string{"Write tests"}) {
+		t.Errorf("first apply added %v and skipped %v, want %v added and Write tests skipped", result.Added, result.Skipped, want)
+	}
+
+	applied := reloadProject(t, tms, "p").Tasks[0]
+	var titles []string
+	for _, subtask := range applied.Subtasks {
+		titles = append(titles, subtask.Title)
+		if subtask.Title != "write TESTS" && subtask.Status != task.StatusTodo {
+			t.Errorf("new subtask '%s' is %s, want todo", subtask.Title, subtask.Status)
+		}
+	}
+	if want := []string{"write TESTS", "Update docs", "Self-review the diff"}; !slices.Equal(titles, want) {
+		t.Errorf("subtasks after reload = %v, want %v", titles, want)
+	}
+
+	// Applying the same template again changes nothing
+	r, err = apply(map[string]any{"task_title": "Add search", "template": "code-review"})
+	decodeResult(t, r, err, &result)
+	if len(result.Added) != 0 || len(result.Skipped) != 3 {
+		t.Errorf("second apply added %v and skipped %v, want nothing added", result.Added, result.Skipped)
+	}
+	if again := reloadProject(t, tms, "p").Tasks[0]; len(again.Subtasks) != 3 || !again.UpdatedAt.Equal(applied.UpdatedAt) {
+		t.Errorf("second apply changed the task: %d subtasks, updated %s (was %s)", len(again.Subtasks), again.UpdatedAt, applied.UpdatedAt)
+	}
+
+	failures := []struct {
+		name      string
+		arguments map[string]any
+		want      string
+	}{
+		{"missing task", map[string]any{"task_title": "Nope", "template": "code-review"}, ErrorCategoryNotFound},
+		{"unknown template", map[string]any{"task_title": "Add search", "template": "nope"}, ErrorCategoryNotFound},
+		{"missing template", map[string]any{"task_title": "Add search"}, ErrorCategoryValidation},
+	}
+	for _, tt := range failures {
+		r, err := apply(tt.arguments)
+		if category := errorCategory(t, r, err); category != tt.want {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, tt.want)
+		}
+	}
+	r, err = tms.handleApplySubtaskTemplate(context.Background(), callTool(map[string]any{"project_name": "missing", "task_title": "Add search", "template": "code-review"}))
+	if category := errorCategory(t, r, err); category != ErrorCategoryNotFound {
+		t.Errorf("missing project: category = %q, want %q", category, ErrorCategoryNotFound)
+	}
+}
+
+func TestResolveSubtaskChoiceAfterReload(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{
+		Title: "Add caching", Description: "d",
+		Subtasks: []task.Subtask{
+			{Title: "Pick a store", Choices: []task.Choice{{ID: "choice_store", Question: "Which store?", Options: []string{"Redis", "Memcached"}}}},
+			{Title: "Measure hit rate"},
+		},
+	})
+	resolve := func(option string) (*mcp.CallToolResult, error) {
+		return tms.handleResolveChoice(context.Background(), callTool(map[string]any{
+			"project_name": "p", "task_title": "Add caching", "choice_id": "choice_store", "selected_option": option,
+		}))
+	}
+
+	// Resolve the choice as read back from the file, not the cached project
+	tms.taskManager.InvalidateCache("p")
+	r, err := resolve("Valkey")
+	if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+		t.Errorf("unknown option: category = %q, want %q", category, ErrorCategoryValidation)
+	}
+	r, err = resolve("Redis")
+	if text := resultText(t, r, err); r.IsError {
+		t.Fatalf("resolve_choice: %s", text)
+	}
+
+	reloaded := reloadProject(t, tms, "p").Tasks[0]
+	if len(reloaded.Subtasks) != 2 || len(reloaded.Choices) != 0 {
+		t.Fatalf("after reload: %d subtasks and %d task choices, want 2 and 0", len(reloaded.Subtasks), len(reloaded.Choices))
+	}
+	choices := reloaded.Subtasks[0].Choices
+	if len(choices) != 1 || choices[0].ID != "choice_store" || choices[0].Selected != "Redis" || choices[0].ResolvedAt == nil {
+		t.Errorf("subtask choices after reload = %+v, want choice_store resolved to Redis", choices)
+	}
+}
+
+func TestBlockedReasonPersistsUntilUnblocked(t *testing.T) {
+	tms := newTestServer(t)
+	newServerProject(t, tms, "p", task.Task{Title: "Deploy", Description: "d", Subtasks: []task.Subtask{{Title: "Step"}}})
+	setStatus := func(arguments map[string]any) (*mcp.CallToolResult, error) {
+		arguments["project_name"] = "p"
+		arguments["task_title"] = "Deploy"
+		return tms.handleUpdateTaskStatus(context.Background(), callTool(arguments))
+	}
+	mustSetStatus := func(arguments map[string]any) {
+		t.Helper()
+		r, err := setStatus(arguments)
+		if text := resultText(t, r, err); r.IsError {
+			t.Fatalf("update_task_status %v: %s", arguments, text)
+		}
+	}
+
+	mustSetStatus(map[string]any{"status": "blocked", "blocked_reason": "Waiting on  credentials"})
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.Status != task.StatusBlocked || got.BlockedReason != "Waiting on credentials" {
+		t.Errorf("after blocking: status %s, reason %q", got.Status, got.BlockedReason)
+	}
+
+	var attention struct {
+		Tasks []map[string]any `json:"tasks"`
+	}
+	r, err := tms.handleGetTasksNeedingAttention(context.Background(), callTool(map[string]any{"project_name": "p", "attention_type": "blocked"}))
+	decodeResult(t, r, err, &attention)
+	if len(attention.Tasks) != 1 || attention.Tasks[0]["blocked_reason"] != "Waiting on credentials" {
+		t.Errorf("attention items = %v, want Deploy with its blocked reason", attention.Tasks)
+	}
+
+	// Changing a subtask leaves the task blocked with its reason
+	mustSetStatus(map[string]any{"status": "in_progress", "subtask_title": "Step"})
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.Status != task.StatusBlocked || got.BlockedReason != "Waiting on credentials" {
+		t.Errorf("after a subtask update: status %s, reason %q", got.Status, got.BlockedReason)
+	}
+
+	mustSetStatus(map[string]any{"status": "in_progress"})
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.Status != task.StatusInProgress || got.BlockedReason != "" {
+		t.Errorf("after unblocking: status %s, reason %q, want no reason", got.Status, got.BlockedReason)
+	}
+
+	// Blocking again without a reason doesn't bring back the old one
+	mustSetStatus(map[string]any{"status": "blocked"})
+	if got := reloadProject(t, tms, "p").Tasks[0]; got.Status != task.StatusBlocked || got.BlockedReason != "" {
+		t.Errorf("after blocking again: status %s, reason %q, want no reason", got.Status, got.BlockedReason)
+	}
+
+	failures := []struct {
+		name      string
+		arguments map[string]any
+	}{
+		{"reason without blocked", map[string]any{"status": "todo", "blocked_reason": "Why"}},
+		{"reason on a subtask", map[string]any{"status": "blocked", "subtask_title": "Step", "blocked_reason": "Why"}},
+	}
+	for _, tt := range failures {
+		r, err := setStatus(tt.arguments)
+		if category := errorCategory(t, r, err); category != ErrorCategoryValidation {
+			t.Errorf("%s: category = %q, want %q", tt.name, category, ErrorCategoryValidation)
+		}
+	}
+}
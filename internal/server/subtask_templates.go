@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleListSubtaskTemplates handles the list_subtask_templates tool
+func (tms *TaskManagerServer) handleListSubtaskTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	templates, err := task.ListSubtaskTemplates()
+	if err != nil {
+		return tms.createErrorResult("list_subtask_templates", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"templates": templates,
+	})
+	if err != nil {
+		return tms.createErrorResult("list_subtask_templates", fmt.Errorf("failed to marshal templates: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleApplySubtaskTemplate handles the apply_subtask_template tool
+func (tms *TaskManagerServer) handleApplySubtaskTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	templateName, err := request.RequireString("template")
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", task.NewError(task.ErrInvalidInput, "missing template: %w", err)), nil
+	}
+
+	template, err := task.GetSubtaskTemplate(strings.TrimSpace(templateName))
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", err), nil
+	}
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	var added, skipped []string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		// Applying a template the task already has is a no-op, not a save
+		added, skipped = missingSubtasks(targetTask, template.Subtasks)
+		if len(added) == 0 {
+			return task.SkipSave
+		}
+		appendSubtasks(targetTask, added)
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":    projectName,
+		"task_id":    targetTask.ID,
+		"task_title": targetTask.Title,
+		"template":   template.Name,
+		"added":      added,
+		"skipped":    skipped,
+		"message":    fmt.Sprintf("Added %d subtasks from template '%s' to task '%s' (%d already present)", len(added), template.Name, targetTask.Title, len(skipped)),
+	})
+	if err != nil {
+		return tms.createErrorResult("apply_subtask_template", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// missingSubtasks splits titles into those the task doesn't have yet and those
+// it already has as a subtask, comparing titles ignoring case and surrounding
+// whitespace
+func missingSubtasks(t *task.Task, titles []string) ([]string, []string) {
+	existing := make(map[string]bool, len(t.Subtasks))
+	for _, subtask := range t.Subtasks {
+		existing[strings.ToLower(strings.TrimSpace(subtask.Title))] = true
+	}
+
+	added := []string{}
+	skipped := []string{}
+	for _, title := range titles {
+		key := strings.ToLower(strings.TrimSpace(title))
+		if existing[key] {
+			skipped = append(skipped, title)
+			continue
+		}
+		existing[key] = true
+		added = append(added, title)
+	}
+	return added, skipped
+}
@@ -0,0 +1,332 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// parseCategoryParam accepts a category with or without brackets, in any case
+// ("mvp", "MVP" or "[MVP]"), and returns the validated category
+func parseCategoryParam(value string) (task.TaskCategory, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if !strings.HasPrefix(value, "[") {
+		value = "[" + value + "]"
+	}
+	return task.ValidateTaskCategory(value)
+}
+
+// parseTaskFilter builds a TaskFilter from the optional status, category,
+// priority, complexity, assignee, min_progress and max_progress parameters of
+// a request
+func (tms *TaskManagerServer) parseTaskFilter(request mcp.CallToolRequest) (task.TaskFilter, error) {
+	var filter task.TaskFilter
+
+	if value := mcp.ParseString(request, "status", ""); value != "" {
+		status, err := task.ValidateTaskStatus(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+
+	if value := mcp.ParseString(request, "category", ""); value != "" {
+		category, err := parseCategoryParam(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Category = &category
+	}
+
+	if value := mcp.ParseString(request, "priority", ""); value != "" {
+		priority, err := task.ValidateTaskPriority(strings.ToUpper(value))
+		if err != nil {
+			return filter, err
+		}
+		filter.Priority = &priority
+	}
+
+	if value := mcp.ParseString(request, "complexity", ""); value != "" {
+		complexity, err := task.ValidateTaskComplexity(strings.ToLower(value))
+		if err != nil {
+			return filter, err
+		}
+		filter.Complexity = &complexity
+	}
+
+	if value := mcp.ParseString(request, "assignee", ""); value != "" {
+		assignee, err := task.ValidateAssignee(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Assignee = &assignee
+	}
+
+	var err error
+	if filter.MinProgress, err = parseProgressBound(request, "min_progress"); err != nil {
+		return filter, err
+	}
+	if filter.MaxProgress, err = parseProgressBound(request, "max_progress"); err != nil {
+		return filter, err
+	}
+	if filter.MinProgress != nil && filter.MaxProgress != nil && *filter.MinProgress > *filter.MaxProgress {
+		return filter, task.NewError(task.ErrInvalidInput, "min_progress (%g) cannot be greater than max_progress (%g)", *filter.MinProgress, *filter.MaxProgress)
+	}
+
+	return filter, nil
+}
+
+// parseProgressBound parses an optional 0-100 percentage parameter, returning nil when it is absent
+func parseProgressBound(request mcp.CallToolRequest, name string) (*float64, error) {
+	if _, present := request.GetArguments()[name]; !present {
+		return nil, nil
+	}
+	progress := mcp.ParseFloat64(request, name, -1)
+	if progress < 0 || progress > 100 {
+		return nil, task.NewError(task.ErrInvalidInput, "%s must be a percentage between 0 and 100", name)
+	}
+	return &progress, nil
+}
+
+// handleBulkTag handles the bulk_tag tool
+func (tms *TaskManagerServer) handleBulkTag(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	tagParam, err := request.RequireString("tag")
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", task.NewError(task.ErrInvalidInput, "missing tag: %w", err)), nil
+	}
+	tag, err := task.ValidateTag(tagParam)
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", err), nil
+	}
+
+	action := mcp.ParseString(request, "action", "add")
+	if action != "add" && action != "remove" {
+		return tms.createErrorResult("bulk_tag", task.NewError(task.ErrInvalidInput, "invalid action: %s. Valid options: add, remove", action)), nil
+	}
+
+	filter, err := tms.parseTaskFilter(request)
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", err), nil
+	}
+
+	titles, err := tms.parseStringArray(request, "task_titles")
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", err), nil
+	}
+
+	if len(titles) == 0 && filter.IsEmpty() && !tms.parseBooleanField(request, "all_tasks", false) {
+		return tms.createErrorResult("bulk_tag", task.NewError(task.ErrInvalidInput, "select tasks with task_titles, a filter (status/category/priority/complexity) or all_tasks=true")), nil
+	}
+
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	var selected []*task.Task
+	var affected []string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		// Resolve the selection: explicit titles take precedence over the filter
+		selected = nil
+		if len(titles) > 0 {
+			for _, title := range titles {
+				targetTask, _, err := tms.findTaskByTitle(project, title)
+				if err != nil {
+					return err
+				}
+				selected = append(selected, targetTask)
+			}
+		} else {
+			for i := range project.Tasks {
+				if filter.Matches(&project.Tasks[i]) {
+					selected = append(selected, &project.Tasks[i])
+				}
+			}
+		}
+
+		affected = []string{}
+		for _, t := range selected {
+			changed := false
+			if action == "add" {
+				changed = t.AddTag(tag)
+			} else {
+				changed = t.RemoveTag(tag)
+			}
+			if changed {
+				t.UpdatedAt = time.Now().UTC()
+				affected = append(affected, t.Title)
+			}
+		}
+
+		if dryRun || len(affected) == 0 {
+			return task.SkipSave
+		}
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":        projectName,
+		"tag":            tag,
+		"action":         action,
+		"dry_run":        dryRun,
+		"selected_count": len(selected),
+		"affected_count": len(affected),
+		"affected_tasks": affected,
+		"saved":          !dryRun && len(affected) > 0,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("bulk_tag", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// parseTagList parses an array parameter of tags, returning them normalized
+func (tms *TaskManagerServer) parseTagList(request mcp.CallToolRequest, fieldName string) ([]string, error) {
+	values, err := tms.parseStringArray(request, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		tag, err := task.ValidateTag(value)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// handleAddTaskTags handles the add_task_tags tool
+func (tms *TaskManagerServer) handleAddTaskTags(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return tms.handleTaskTags(request, "add_task_tags", true)
+}
+
+// handleRemoveTaskTags handles the remove_task_tags tool
+func (tms *TaskManagerServer) handleRemoveTaskTags(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return tms.handleTaskTags(request, "remove_task_tags", false)
+}
+
+// handleTaskTags adds or removes a list of tags on a single task
+func (tms *TaskManagerServer) handleTaskTags(request mcp.CallToolRequest, operation string, add bool) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	tags, err := tms.parseTagList(request, "tags")
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+	if len(tags) == 0 {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "tags must list at least one tag")), nil
+	}
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	var changed []string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		changed = []string{}
+		for _, tag := range tags {
+			if add && targetTask.AddTag(tag) || !add && targetTask.RemoveTag(tag) {
+				changed = append(changed, tag)
+			}
+		}
+
+		if len(changed) == 0 {
+			return task.SkipSave
+		}
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+
+	result := map[string]interface{}{
+		"project": projectName,
+		"task":    targetTask.Title,
+		"changed": changed,
+		"tags":    targetTask.Tags,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult(operation, fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleFilterTasks handles the filter_tasks tool
+func (tms *TaskManagerServer) handleFilterTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("filter_tasks", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	filter, err := tms.parseTaskFilter(request)
+	if err != nil {
+		return tms.createErrorResult("filter_tasks", err), nil
+	}
+
+	if filter.Tags, err = tms.parseTagList(request, "tags"); err != nil {
+		return tms.createErrorResult("filter_tasks", err), nil
+	}
+	switch match := mcp.ParseString(request, "tag_match", "any"); match {
+	case "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return tms.createErrorResult("filter_tasks", task.NewError(task.ErrInvalidInput, "invalid tag_match: %s. Valid options: any, all", match)), nil
+	}
+
+	project, err := tms.loadProjectForRead(request, projectName)
+	if err != nil {
+		return tms.createErrorResult("filter_tasks", err), nil
+	}
+
+	tasks := []task.TaskSummary{}
+	for _, t := range project.FilterTasks(filter) {
+		tasks = append(tasks, t.ToSummary())
+	}
+
+	result := map[string]interface{}{
+		"project": projectName,
+		"filter":  filter,
+		"count":   len(tasks),
+		"tasks":   tasks,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("filter_tasks", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleSetTaskPriority handles the set_task_priority tool
+func (tms *TaskManagerServer) handleSetTaskPriority(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireString("priority")
+	if err != nil {
+		return tms.createErrorResult("set_task_priority", task.NewError(task.ErrInvalidInput, "missing priority: %w", err)), nil
+	}
+	priority, err := task.ValidateTaskPriority(strings.ToUpper(strings.TrimSpace(value)))
+	if err != nil {
+		return tms.createErrorResult("set_task_priority", err), nil
+	}
+
+	return tms.setTaskField(request, "set_task_priority", "priority", func(t *task.Task) (string, string) {
+		old := t.Priority
+		t.Priority = priority
+		return string(old), string(priority)
+	})
+}
+
+// handleSetTaskCategory handles the set_task_category tool
+func (tms *TaskManagerServer) handleSetTaskCategory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireString("category")
+	if err != nil {
+		return tms.createErrorResult("set_task_category", task.NewError(task.ErrInvalidInput, "missing category: %w", err)), nil
+	}
+	category, err := parseCategoryParam(value)
+	if err != nil {
+		return tms.createErrorResult("set_task_category", err), nil
+	}
+
+	return tms.setTaskField(request, "set_task_category", "category", func(t *task.Task) (string, string) {
+		old := t.EffectiveCategory()
+		// [GENERAL] is how a task without a category is written
+		t.Category = category
+		if category == task.CategoryGeneral {
+			t.Category = ""
+		}
+		return string(old), string(category)
+	})
+}
+
+// handleAssignTask handles the assign_task tool
+func (tms *TaskManagerServer) handleAssignTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireString("assignee")
+	if err != nil {
+		return tms.createErrorResult("assign_task", task.NewError(task.ErrInvalidInput, "missing assignee: %w", err)), nil
+	}
+	assignee, err := task.ValidateAssignee(value)
+	if err != nil {
+		return tms.createErrorResult("assign_task", err), nil
+	}
+
+	return tms.setTaskField(request, "assign_task", "assignee", func(t *task.Task) (string, string) {
+		old := t.Assignee
+		t.Assignee = assignee
+		return old, assignee
+	})
+}
+
+// handleUnassignTask handles the unassign_task tool
+func (tms *TaskManagerServer) handleUnassignTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return tms.setTaskField(request, "unassign_task", "assignee", func(t *task.Task) (string, string) {
+		old := t.Assignee
+		t.Assignee = ""
+		return old, ""
+	})
+}
+
+// setTaskField loads the project and task named in the request, changes one
+// field with update, which returns the old and new values, and saves the
+// project
+func (tms *TaskManagerServer) setTaskField(request mcp.CallToolRequest, operation, field string, update func(t *task.Task) (string, string)) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult(operation, task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	partial := tms.parseBooleanField(request, "partial_match", false)
+	var targetTask *task.Task
+	var oldValue, newValue string
+	err = tms.updateProject(projectName, func(project *task.Project) error {
+		targetTask, _, err = tms.resolveTaskTitle(project, taskTitle, partial)
+		if err != nil {
+			return err
+		}
+
+		oldValue, newValue = update(targetTask)
+		targetTask.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return tms.createErrorResult(operation, err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":      projectName,
+		"task_id":      targetTask.ID,
+		"task_title":   targetTask.Title,
+		"old_" + field: oldValue,
+		"new_" + field: newValue,
+		"message":      fmt.Sprintf("Changed %s of task '%s' from %s to %s", field, targetTask.Title, fieldValueText(oldValue), fieldValueText(newValue)),
+	})
+	if err != nil {
+		return tms.createErrorResult(operation, fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// fieldValueText is how a field value reads in messages, where an empty value
+// would otherwise vanish
+func fieldValueText(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Where the tasks directory came from
+const (
+	tasksDirFromConfig      = "config"
+	tasksDirFromEnv         = "env"
+	tasksDirFromProjectRoot = "project_root"
+	tasksDirFromFallback    = "fallback"
+)
+
+// tasksDirResolution records which tasks directory was chosen and why
+type tasksDirResolution struct {
+	Dir    string `json:"dir"`
+	Source string `json:"source"`
+	// Reason explains why the fallback directory was used; empty otherwise
+	Reason string `json:"reason,omitempty"`
+	// Warnings lists problems found while resolving the directory
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// fallbackWarning describes a switch to the fallback tasks directory
+func fallbackWarning(dir, reason string) []string {
+	return []string{fmt.Sprintf("using fallback tasks directory %s because %s", dir, reason)}
+}
+
+// resolveTasksDir picks the tasks directory: the configured one, then TASKS_DIR,
+// then a tasks folder in the detected project root. When none applies, or the
+// chosen path is unsafe, it falls back to config.FallbackTasksDir, then
+// ~/.mcp-task-manager/tasks, then the temp dir - unless config.DisableFallback
+// is set, in which case it returns an error instead.
+func resolveTasksDir(config ServerConfig, detectRoot func() (string, error)) (tasksDirResolution, error) {
+	resolution := tasksDirResolution{Dir: config.TasksDir, Source: tasksDirFromConfig}
+	if resolution.Dir == "" {
+		resolution = tasksDirResolution{Dir: os.Getenv("TASKS_DIR"), Source: tasksDirFromEnv}
+	}
+	if resolution.Dir == "" {
+		// Auto-detect project root and use tasks subdirectory
+		projectRoot, err := detectRoot()
+		if err != nil {
+			return fallbackTasksDir(config, fmt.Sprintf("project root detection failed: %v", err))
+		}
+		resolution = tasksDirResolution{Dir: filepath.Join(projectRoot, "tasks"), Source: tasksDirFromProjectRoot}
+	}
+
+	resolution.Dir = absoluteTasksDir(resolution.Dir)
+
+	// Safety check: never allow creating directories in system root or other unsafe locations
+	if isUnsafeTasksDir(resolution.Dir) {
+		return fallbackTasksDir(config, fmt.Sprintf("tasks directory %s is in an unsafe location", resolution.Dir))
+	}
+
+	return resolution, nil
+}
+
+// fallbackTasksDir returns the fallback tasks directory, or an error when falling back is disabled
+func fallbackTasksDir(config ServerConfig, reason string) (tasksDirResolution, error) {
+	if config.DisableFallback {
+		return tasksDirResolution{}, fmt.Errorf("%s and the fallback tasks directory is disabled: set TASKS_DIR or tasks_dir", reason)
+	}
+
+	if config.FallbackTasksDir != "" {
+		dir := absoluteTasksDir(config.FallbackTasksDir)
+		if isUnsafeTasksDir(dir) {
+			return tasksDirResolution{}, fmt.Errorf("%s and the fallback tasks directory %s is in an unsafe location", reason, dir)
+		}
+		return tasksDirResolution{Dir: dir, Source: tasksDirFromFallback, Reason: reason, Warnings: fallbackWarning(dir, reason)}, nil
+	}
+
+	// Fall back to a safe directory in user's home
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(homeDir, ".mcp-task-manager", "tasks")
+		return tasksDirResolution{Dir: dir, Source: tasksDirFromFallback, Reason: reason, Warnings: fallbackWarning(dir, reason)}, nil
+	}
+
+	// Final fallback - use temp directory, which does not survive a reboot
+	dir := filepath.Join(os.TempDir(), "mcp-task-manager", "tasks")
+	warnings := append(fallbackWarning(dir, reason), "the home directory could not be determined, so tasks are kept in the temp directory and may be lost")
+	return tasksDirResolution{Dir: dir, Source: tasksDirFromFallback, Reason: reason, Warnings: warnings}, nil
+}
+
+// absoluteTasksDir makes a relative tasks directory relative to the user's home directory
+func absoluteTasksDir(dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".mcp-task-manager", dir)
+	}
+	// Last resort - use temp directory
+	return filepath.Join(os.TempDir(), "mcp-task-manager", dir)
+}
+
+// isUnsafeTasksDir reports whether dir is the filesystem root or a system directory
+func isUnsafeTasksDir(dir string) bool {
+	return dir == "/" || dir == "/tasks" || strings.HasPrefix(dir, "/bin") || strings.HasPrefix(dir, "/usr") || strings.HasPrefix(dir, "/etc")
+}
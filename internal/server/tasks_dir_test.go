@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveTasksDir(t *testing.T) {
+	home := t.TempDir()
+	root := t.TempDir()
+	fallback := t.TempDir()
+	detected := func() (string, error) { return root, nil }
+	undetected := func() (string, error) { return "", errors.New("no project markers") }
+	systemRoot := func() (string, error) { return "/", nil }
+
+	tests := []struct {
+		name       string
+		config     ServerConfig
+		env        string
+		noHome     bool
+		detect     func() (string, error)
+		wantDir    string
+		wantSource string
+		wantReason string
+		wantErr    string
+	}{
+		{"configured directory", ServerConfig{TasksDir: "/srv/tasks"}, "/ignored", false, detected, "/srv/tasks", tasksDirFromConfig, "", ""},
+		{"TASKS_DIR", ServerConfig{}, "/srv/env-tasks", false, detected, "/srv/env-tasks", tasksDirFromEnv, "", ""},
+		{"relative directory goes under home", ServerConfig{TasksDir: "mine"}, "", false, detected, filepath.Join(home, ".mcp-task-manager", "mine"), tasksDirFromConfig, "", ""},
+		{"detected project root", ServerConfig{}, "", false, detected, filepath.Join(root, "tasks"), tasksDirFromProjectRoot, "", ""},
+		{"detection failed, configured fallback", ServerConfig{FallbackTasksDir: fallback}, "", false, undetected, fallback, tasksDirFromFallback, "project root detection failed", ""},
+		{"detection failed, home fallback", ServerConfig{}, "", false, undetected, filepath.Join(home, ".mcp-task-manager", "tasks"), tasksDirFromFallback, "project root detection failed", ""},
+		{"detection failed, temp fallback", ServerConfig{}, "", true, undetected, filepath.Join(os.TempDir(), "mcp-task-manager", "tasks"), tasksDirFromFallback, "project root detection failed", ""},
+		{"unsafe directory falls back", ServerConfig{FallbackTasksDir: fallback}, "", false, systemRoot, fallback, tasksDirFromFallback, "unsafe location", ""},
+		{"unsafe configured directory falls back", ServerConfig{TasksDir: "/etc/tasks"}, "", false, detected, filepath.Join(home, ".mcp-task-manager", "tasks"), tasksDirFromFallback, "unsafe location", ""},
+		{"fallback disabled", ServerConfig{DisableFallback: true, FallbackTasksDir: fallback}, "", false, undetected, "", "", "", "fallback tasks directory is disabled"},
+		{"fallback disabled, unsafe directory", ServerConfig{DisableFallback: true}, "", false, systemRoot, "", "", "", "fallback tasks directory is disabled"},
+		{"unsafe fallback directory", ServerConfig{FallbackTasksDir: "/usr/tasks"}, "", false, undetected, "", "", "", "fallback tasks directory /usr/tasks is in an unsafe location"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TASKS_DIR", tt.env)
+			t.Setenv("HOME", home)
+			if tt.noHome {
+				t.Setenv("HOME", "")
+			}
+
+			resolution, err := resolveTasksDir(tt.config, tt.detect)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveTasksDir = %+v, %v; want an error containing %q", resolution, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveTasksDir: %v", err)
+			}
+			if resolution.Dir != tt.wantDir || resolution.Source != tt.wantSource {
+				t.Errorf("resolution = %s from %s, want %s from %s", resolution.Dir, resolution.Source, tt.wantDir, tt.wantSource)
+			}
+			if tt.wantReason == "" {
+				if resolution.Reason != "" || len(resolution.Warnings) != 0 {
+					t.Errorf("no fallback, yet reason = %q and warnings = %v", resolution.Reason, resolution.Warnings)
+				}
+				return
+			}
+			if !strings.Contains(resolution.Reason, tt.wantReason) || len(resolution.Warnings) == 0 {
+				t.Errorf("reason = %q, warnings = %v; want a reason containing %q and a warning", resolution.Reason, resolution.Warnings, tt.wantReason)
+			}
+			if tt.noHome && len(resolution.Warnings) != 2 {
+				t.Errorf("temp fallback warnings = %v, want one about losing tasks", resolution.Warnings)
+			}
+		})
+	}
+}
+
+func TestDebugInfoReportsTasksDirResolution(t *testing.T) {
+	tms := newTestServer(t)
+
+	var info struct {
+		Resolution tasksDirResolution `json:"tasks_directory_resolution"`
+	}
+	result, err := tms.handleDebugInfo(context.Background(), callTool(map[string]any{}))
+	decodeResult(t, result, err, &info)
+	// LoadServerConfig reads TASKS_DIR into the config
+	if info.Resolution.Source != tasksDirFromConfig || info.Resolution.Dir != tms.taskManager.GetTasksDir() {
+		t.Errorf("tasks_directory_resolution = %+v, want the configured %s", info.Resolution, tms.taskManager.GetTasksDir())
+	}
+
+	// A fallback is reported with its reason
+	tms.tasksDir = tasksDirResolution{Dir: "/srv/tasks", Source: tasksDirFromFallback, Reason: "project root detection failed"}
+	result, err = tms.handleDebugInfo(context.Background(), callTool(map[string]any{}))
+	decodeResult(t, result, err, &info)
+	if info.Resolution.Source != tasksDirFromFallback || info.Resolution.Reason != "project root detection failed" {
+		t.Errorf("tasks_directory_resolution = %+v, want the fallback and its reason", info.Resolution)
+	}
+}
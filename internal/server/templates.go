@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// handleListTemplates handles the list_templates tool
+func (tms *TaskManagerServer) handleListTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	templates, err := task.ListTemplates()
+	if err != nil {
+		return tms.createErrorResult("list_templates", err), nil
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"templates": templates,
+	})
+	if err != nil {
+		return tms.createErrorResult("list_templates", fmt.Errorf("failed to marshal templates: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleCreateProjectFromTemplate handles the create_project_from_template tool
+func (tms *TaskManagerServer) handleCreateProjectFromTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("create_project_from_template", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	templateName, err := request.RequireString("template")
+	if err != nil {
+		return tms.createErrorResult("create_project_from_template", task.NewError(task.ErrInvalidInput, "missing template: %w", err)), nil
+	}
+
+	if err := tms.validateProjectName(projectName); err != nil {
+		return tms.createErrorResult("create_project_from_template", err), nil
+	}
+
+	project, err := tms.taskManager.CreateProjectFromTemplate(projectName, templateName)
+	if err != nil {
+		return tms.createErrorResult("create_project_from_template", err), nil
+	}
+
+	subtasks := 0
+	for _, t := range project.Tasks {
+		subtasks += len(t.Subtasks)
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"project":  project.Name,
+		"template": templateName,
+		"tasks":    len(project.Tasks),
+		"subtasks": subtasks,
+		"message":  fmt.Sprintf("Created project '%s' from template '%s' with %d tasks", project.Name, templateName, len(project.Tasks)),
+	})
+	if err != nil {
+		return tms.createErrorResult("create_project_from_template", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
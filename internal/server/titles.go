@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-task-manager-go/internal/task"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleNormalizeTitles handles the normalize_titles tool
+func (tms *TaskManagerServer) handleNormalizeTitles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("normalize_titles", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	if err := tms.validateProjectName(projectName); err != nil {
+		return tms.createErrorResult("normalize_titles", err), nil
+	}
+
+	dryRun := tms.parseBooleanField(request, "dry_run", false)
+
+	changes, err := tms.taskManager.NormalizeTitles(projectName, dryRun)
+	if err != nil {
+		return tms.createErrorResult("normalize_titles", err), nil
+	}
+	saved := !dryRun && len(changes) > 0
+
+	result := map[string]interface{}{
+		"project":       projectName,
+		"dry_run":       dryRun,
+		"changed_count": len(changes),
+		"changes":       changes,
+		"saved":         saved,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("normalize_titles", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleRenameTask handles the rename_task tool
+func (tms *TaskManagerServer) handleRenameTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("rename_task", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	taskTitle, err := request.RequireString("task_title")
+	if err != nil {
+		return tms.createErrorResult("rename_task", task.NewError(task.ErrInvalidInput, "missing task_title: %w", err)), nil
+	}
+
+	newTitle, err := request.RequireString("new_title")
+	if err != nil {
+		return tms.createErrorResult("rename_task", task.NewError(task.ErrInvalidInput, "missing new_title: %w", err)), nil
+	}
+	newTitle, err = tms.validateTaskTitle(newTitle)
+	if err != nil {
+		return tms.createErrorResult("rename_task", err), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("rename_task", err), nil
+	}
+
+	targetTask, _, err := tms.resolveTaskTitle(project, taskTitle, tms.parseBooleanField(request, "partial_match", false))
+	if err != nil {
+		return tms.createErrorResult("rename_task", err), nil
+	}
+	oldTitle := targetTask.Title
+
+	if err := tms.taskManager.RenameTask(projectName, oldTitle, newTitle); err != nil {
+		return tms.createErrorResult("rename_task", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":   projectName,
+		"task_id":   targetTask.ID,
+		"old_title": oldTitle,
+		"new_title": newTitle,
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("rename_task", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newNetworkTestServer returns a test server configured to listen on a free
+// local port, and the address it will listen on
+func newNetworkTestServer(t *testing.T) (*TaskManagerServer, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	host, port, _ := net.SplitHostPort(addr)
+	t.Setenv("HOST", host)
+	t.Setenv("PORT", port)
+	return newTestServer(t), addr
+}
+
+// serveInBackground runs serve until the returned cancel is called, and
+// returns a channel receiving serve's error
+func serveInBackground(t *testing.T, serve func(context.Context) error) (context.CancelFunc, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serve(ctx)
+	}()
+	t.Cleanup(cancel)
+	return cancel, errCh
+}
+
+// waitForStop fails the test unless serving stops without error soon
+func waitForStop(t *testing.T, errCh <-chan error) {
+	t.Helper()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("server stopped with error: %v", err)
+		}
+	case <-time.After(2 * shutdownTimeout):
+		t.Fatal("server did not stop after its context was cancelled")
+	}
+}
+
+// postJSONRPC sends a JSON-RPC request to the streamable HTTP endpoint,
+// retrying while the server starts up
+func postJSONRPC(t *testing.T, url, sessionID, method string, params any) *http.Response {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if sessionID != "" {
+			request.Header.Set("Mcp-Session-Id", sessionID)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err == nil {
+			return response
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("POST %s: %v", method, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestServeHTTPInitializeAndListTools(t *testing.T) {
+	tms, addr := newNetworkTestServer(t)
+	cancel, errCh := serveInBackground(t, tms.ServeHTTP)
+	url := "http://" + addr + httpEndpointPath
+
+	response := postJSONRPC(t, url, "", "initialize", map[string]any{
+		"protocolVersion": "2025-03-26",
+		"clientInfo":      map[string]any{"name": "test", "version": "1.0"},
+		"capabilities":    map[string]any{},
+	})
+	var initialized struct {
+		Result struct {
+			ServerInfo struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	err := json.NewDecoder(response.Body).Decode(&initialized)
+	response.Body.Close()
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("initialize: status %d, %v", response.StatusCode, err)
+	}
+	if initialized.Result.ServerInfo.Name == "" {
+		t.Error("initialize returned no server name")
+	}
+	sessionID := response.Header.Get("Mcp-Session-Id")
+	if sessionID == "" {
+		t.Fatal("initialize returned no session ID")
+	}
+
+	response = postJSONRPC(t, url, sessionID, "tools/list", map[string]any{})
+	var listed struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&listed)
+	response.Body.Close()
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("tools/list: status %d, %v", response.StatusCode, err)
+	}
+	var names []string
+	for _, tool := range listed.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	if len(names) != len(tms.registeredTools) {
+		t.Errorf("tools/list returned %d tools, want %d", len(names), len(tms.registeredTools))
+	}
+	if !slices.Contains(names, "add_task") {
+		t.Errorf("tools/list is missing add_task: %v", names)
+	}
+
+	cancel()
+	waitForStop(t, errCh)
+}
+
+func TestServeSSEStopsWhenContextCancelled(t *testing.T) {
+	tms, addr := newNetworkTestServer(t)
+	cancel, errCh := serveInBackground(t, tms.ServeSSE)
+
+	// Hold an event stream open, as a connected client would
+	var stream *http.Response
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var err error
+		stream, err = http.Get("http://" + addr + "/sse")
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GET /sse: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	defer stream.Body.Close()
+	event, err := bufio.NewReader(stream.Body).ReadString('\n')
+	if err != nil || !strings.HasPrefix(event, "event: endpoint") {
+		t.Fatalf("first SSE line = %q, %v, want the endpoint event", event, err)
+	}
+
+	cancel()
+	waitForStop(t, errCh)
+
+	if conn, err := net.Dial("tcp", addr); err == nil {
+		conn.Close()
+		t.Error("server still accepts connections after stopping")
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-task-manager-go/internal/task"
+)
+
+// treeNode is one node of the project tree returned by get_project_as_tree.
+// Every node has the same shape so UIs can render it recursively.
+type treeNode struct {
+	Type     string            `json:"type"` // project, task, subtask or choice
+	ID       int               `json:"id,omitempty"`
+	Title    string            `json:"title"`
+	Status   task.TaskStatus   `json:"status,omitempty"`
+	Progress float64           `json:"progress"`
+	Priority task.TaskPriority `json:"priority,omitempty"`
+	Category task.TaskCategory `json:"category,omitempty"`
+	Options  []string          `json:"options,omitempty"`
+	Selected string            `json:"selected,omitempty"`
+	Children []treeNode        `json:"children"`
+}
+
+// buildProjectTree shapes a project into a project → tasks → subtasks → choices
+// tree. Progress is a percentage: see taskProgress for tasks; subtasks and
+// choices are 100 when done (or resolved) and 0 when not.
+func buildProjectTree(project *task.Project, criteriaWeight float64) treeNode {
+	root := treeNode{
+		Type:     "project",
+		Title:    project.Name,
+		Progress: project.GetProgressPercentage(),
+		Children: make([]treeNode, 0, len(project.Tasks)),
+	}
+
+	for _, t := range project.Tasks {
+		taskNode := treeNode{
+			Type:     "task",
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   t.Status,
+			Progress: taskProgress(&t, criteriaWeight),
+			Priority: t.Priority,
+			Category: t.Category,
+			Children: choiceNodes(t.Choices),
+		}
+
+		for _, subtask := range t.Subtasks {
+			taskNode.Children = append(taskNode.Children, treeNode{
+				Type:     "subtask",
+				Title:    subtask.Title,
+				Status:   subtask.Status,
+				Progress: statusProgress(subtask.Status),
+				Children: choiceNodes(subtask.Choices),
+			})
+		}
+
+		root.Children = append(root.Children, taskNode)
+	}
+
+	return root
+}
+
+// choiceNodes builds leaf nodes for a list of choices
+func choiceNodes(choices []task.Choice) []treeNode {
+	nodes := make([]treeNode, 0, len(choices))
+	for _, choice := range choices {
+		node := treeNode{
+			Type:     "choice",
+			Title:    choice.Question,
+			Options:  choice.Options,
+			Selected: choice.Selected,
+			Children: []treeNode{},
+		}
+		if choice.ResolvedAt != nil {
+			node.Progress = 100
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// taskProgress is a task's completion percentage: 100 once it is done,
+// otherwise its blend of subtask and done criteria completion
+func taskProgress(t *task.Task, criteriaWeight float64) float64 {
+	if t.Status == task.StatusDone {
+		return 100
+	}
+	return t.GetTaskCompletion(criteriaWeight)
+}
+
+// statusProgress is the progress of an item without children
+func statusProgress(status task.TaskStatus) float64 {
+	if status == task.StatusDone {
+		return 100
+	}
+	return 0
+}
+
+// handleGetProjectAsTree handles the get_project_as_tree tool
+func (tms *TaskManagerServer) handleGetProjectAsTree(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("get_project_as_tree", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("get_project_as_tree", err), nil
+	}
+
+	resultJSON, err := json.Marshal(buildProjectTree(project, tms.config.DoneCriteriaWeight))
+	if err != nil {
+		return tms.createErrorResult("get_project_as_tree", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
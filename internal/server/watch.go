@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"mcp-task-manager-go/internal/task"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultMaxWatchSubscribers bounds concurrent watch_project subscriptions
+const defaultMaxWatchSubscribers = 32
+
+// projectProgressNotification is the method of notifications sent to watchers
+const projectProgressNotification = "notifications/project_progress"
+
+// progressSnapshot is the part of a project watchers are notified about
+type progressSnapshot struct {
+	completedTasks int
+	totalTasks     int
+	progress       float64
+	statuses       map[int]task.TaskStatus
+	titles         map[int]string
+}
+
+// newProgressSnapshot captures the progress-relevant state of a project
+func newProgressSnapshot(project *task.Project) progressSnapshot {
+	snapshot := progressSnapshot{
+		completedTasks: project.GetCompletedTaskCount(),
+		totalTasks:     len(project.Tasks),
+		progress:       project.GetProgressPercentage(),
+		statuses:       make(map[int]task.TaskStatus, len(project.Tasks)),
+		titles:         make(map[int]string, len(project.Tasks)),
+	}
+	for _, t := range project.Tasks {
+		snapshot.statuses[t.ID] = t.Status
+		snapshot.titles[t.ID] = t.Title
+	}
+	return snapshot
+}
+
+// statusChange describes a task whose status changed between two snapshots
+type statusChange struct {
+	TaskID    int             `json:"task_id"`
+	TaskTitle string          `json:"task_title"`
+	OldStatus task.TaskStatus `json:"old_status,omitempty"`
+	NewStatus task.TaskStatus `json:"new_status,omitempty"`
+}
+
+// diff returns the status changes from previous to s; added tasks have no
+// old status and removed tasks have no new status
+func (s progressSnapshot) diff(previous progressSnapshot) []statusChange {
+	changes := []statusChange{}
+	for id, status := range s.statuses {
+		if old, existed := previous.statuses[id]; !existed || old != status {
+			changes = append(changes, statusChange{TaskID: id, TaskTitle: s.titles[id], OldStatus: old, NewStatus: status})
+		}
+	}
+	for id, old := range previous.statuses {
+		if _, exists := s.statuses[id]; !exists {
+			changes = append(changes, statusChange{TaskID: id, TaskTitle: previous.titles[id], OldStatus: old})
+		}
+	}
+	return changes
+}
+
+// projectWatchers tracks which client sessions are watching which projects and
+// pushes a notification to them whenever a save changes a project's progress
+type projectWatchers struct {
+	mutex          sync.Mutex
+	mcpServer      *server.MCPServer
+	maxSubscribers int
+	sessions       map[string]map[string]bool // project -> session IDs
+	snapshots      map[string]progressSnapshot
+}
+
+// newProjectWatchers creates a watcher registry with a subscriber bound
+func newProjectWatchers(maxSubscribers int) *projectWatchers {
+	if maxSubscribers <= 0 {
+		maxSubscribers = defaultMaxWatchSubscribers
+	}
+	return &projectWatchers{
+		maxSubscribers: maxSubscribers,
+		sessions:       make(map[string]map[string]bool),
+		snapshots:      make(map[string]progressSnapshot),
+	}
+}
+
+// subscriberCount returns the number of active subscriptions. Caller must hold the mutex.
+func (w *projectWatchers) subscriberCount() int {
+	count := 0
+	for _, sessions := range w.sessions {
+		count += len(sessions)
+	}
+	return count
+}
+
+// subscribe registers a session for a project, using project as the baseline
+// for the first change notification. It returns false if already subscribed.
+func (w *projectWatchers) subscribe(sessionID string, project *task.Project) (bool, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.sessions[project.Name][sessionID] {
+		return false, nil
+	}
+	if w.subscriberCount() >= w.maxSubscribers {
+		return false, task.NewError(task.ErrConflict, "watch subscriber limit reached (%d); unwatch another project first", w.maxSubscribers)
+	}
+
+	if w.sessions[project.Name] == nil {
+		w.sessions[project.Name] = make(map[string]bool)
+		w.snapshots[project.Name] = newProgressSnapshot(project)
+	}
+	w.sessions[project.Name][sessionID] = true
+	return true, nil
+}
+
+// unsubscribe removes a session's subscription to a project
+func (w *projectWatchers) unsubscribe(sessionID, projectName string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.sessions[projectName][sessionID] {
+		return false
+	}
+	w.removeLocked(sessionID, projectName)
+	return true
+}
+
+// removeSession drops every subscription held by a session
+func (w *projectWatchers) removeSession(sessionID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for projectName := range w.sessions {
+		w.removeLocked(sessionID, projectName)
+	}
+}
+
+// removeLocked removes one subscription. Caller must hold the mutex.
+func (w *projectWatchers) removeLocked(sessionID, projectName string) {
+	delete(w.sessions[projectName], sessionID)
+	if len(w.sessions[projectName]) == 0 {
+		delete(w.sessions, projectName)
+		delete(w.snapshots, projectName)
+	}
+}
+
+// projectSaved is registered as a task.Manager save listener. It notifies the
+// project's watchers when task statuses or counts changed since the last save.
+func (w *projectWatchers) projectSaved(project task.Project) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	sessions := w.sessions[project.Name]
+	if len(sessions) == 0 || w.mcpServer == nil {
+		return
+	}
+
+	snapshot := newProgressSnapshot(&project)
+	changes := snapshot.diff(w.snapshots[project.Name])
+	w.snapshots[project.Name] = snapshot
+	if len(changes) == 0 {
+		return
+	}
+
+	params := map[string]any{
+		"project":             project.Name,
+		"progress_percentage": snapshot.progress,
+		"completed_tasks":     snapshot.completedTasks,
+		"total_tasks":         snapshot.totalTasks,
+		"status_changes":      changes,
+	}
+
+	for sessionID := range sessions {
+		err := w.mcpServer.SendNotificationToSpecificClient(sessionID, projectProgressNotification, params)
+		if errors.Is(err, server.ErrSessionNotFound) {
+			w.removeLocked(sessionID, project.Name)
+		}
+	}
+}
+
+// handleWatchProject handles the watch_project tool
+func (tms *TaskManagerServer) handleWatchProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("watch_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return tms.createErrorResult("watch_project", task.NewError(task.ErrInvalidInput, "watching requires a client session (SSE or stdio transport)")), nil
+	}
+
+	project, err := tms.safeLoadProject(projectName)
+	if err != nil {
+		return tms.createErrorResult("watch_project", err), nil
+	}
+
+	subscribed, err := tms.watchers.subscribe(session.SessionID(), project)
+	if err != nil {
+		return tms.createErrorResult("watch_project", err), nil
+	}
+
+	result := map[string]interface{}{
+		"project":             projectName,
+		"watching":            true,
+		"already_watching":    !subscribed,
+		"notification_method": projectProgressNotification,
+		"progress_percentage": project.GetProgressPercentage(),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("watch_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
+
+// handleUnwatchProject handles the unwatch_project tool
+func (tms *TaskManagerServer) handleUnwatchProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectName, err := request.RequireString("project_name")
+	if err != nil {
+		return tms.createErrorResult("unwatch_project", task.NewError(task.ErrInvalidInput, "missing project_name: %w", err)), nil
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return tms.createErrorResult("unwatch_project", task.NewError(task.ErrInvalidInput, "watching requires a client session (SSE or stdio transport)")), nil
+	}
+
+	result := map[string]interface{}{
+		"project":    projectName,
+		"was_active": tms.watchers.unsubscribe(session.SessionID(), projectName),
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return tms.createErrorResult("unwatch_project", fmt.Errorf("failed to marshal result: %w", err)), nil
+	}
+
+	return tms.createSuccessResult(string(resultJSON)), nil
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// archiveDirName is the subdirectory of the tasks directory holding archived projects.
+// ListProjects and ProjectExists only look at the top level, so archived
+// projects drop out of both.
+const archiveDirName = "archive"
+
+// DeleteProject permanently removes a project file along with its progress
+// snapshots and history
+func (m *Manager) DeleteProject(projectName string) error {
+	if err := ValidateProjectName(projectName); err != nil {
+		return err
+	}
+
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	filePath := m.GetTaskFilePath(projectName)
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return NewError(ErrNotFound, "project '%s' does not exist", projectName)
+		}
+		return fmt.Errorf("failed to delete project file: %w", err)
+	}
+	m.InvalidateCache(projectName)
+
+	if err := os.Remove(m.snapshotFilePath(projectName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleted project file but failed to delete its snapshots: %w", err)
+	}
+	if err := os.RemoveAll(m.historyDir(projectName)); err != nil {
+		return fmt.Errorf("deleted project file but failed to delete its history: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveProject moves a project file into the archive subdirectory of the
+// tasks directory and returns its new path. When a project of the same name
+// was archived before, the new archive gets a timestamp suffix.
+func (m *Manager) ArchiveProject(projectName string) (string, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return "", err
+	}
+
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	filePath := m.GetTaskFilePath(projectName)
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return "", NewError(ErrNotFound, "project '%s' does not exist", projectName)
+		}
+		return "", fmt.Errorf("failed to stat project file: %w", err)
+	}
+
+	archiveDir := filepath.Join(m.tasksDir, archiveDirName)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	archivePath := filepath.Join(archiveDir, filepath.Base(filePath))
+	if _, err := os.Stat(archivePath); err == nil {
+		base := strings.TrimSuffix(filepath.Base(filePath), ".md")
+		archivePath = filepath.Join(archiveDir, fmt.Sprintf("%s-%s.md", base, time.Now().UTC().Format("20060102T150405Z")))
+	}
+
+	if err := os.Rename(filePath, archivePath); err != nil {
+		return "", fmt.Errorf("failed to archive project file: %w", err)
+	}
+	m.InvalidateCache(projectName)
+
+	return archivePath, nil
+}
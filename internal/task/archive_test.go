@@ -0,0 +1,100 @@
+package task
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDeleteProject(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "doomed", 2)
+	newTestProject(t, m, "kept", 1)
+	if _, err := m.RecordSnapshot("doomed"); err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+	if history, err := m.ListHistory("doomed"); err != nil || len(history) == 0 {
+		t.Fatalf("ListHistory = %v, %v; want the AddTasks version", history, err)
+	}
+
+	if err := m.DeleteProject("doomed"); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	if m.ProjectExists("doomed") {
+		t.Error("ProjectExists after delete = true")
+	}
+	if projects, err := m.ListProjects(); err != nil || !slices.Equal(projects, []string{"kept"}) {
+		t.Errorf("ListProjects after delete = %v, %v; want [kept]", projects, err)
+	}
+	if _, err := m.LoadProject("doomed"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadProject after delete = %v, want ErrNotFound", err)
+	}
+	for _, path := range []string{m.snapshotFilePath("doomed"), m.historyDir("doomed")} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after delete (%v)", path, err)
+		}
+	}
+
+	err := m.DeleteProject("doomed")
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "'doomed' does not exist") {
+		t.Errorf("deleting a missing project = %v, want ErrNotFound naming it", err)
+	}
+	if err := m.DeleteProject("../kept"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("deleting an invalid name = %v, want ErrInvalidInput", err)
+	}
+	if !m.ProjectExists("kept") {
+		t.Error("deleting other projects removed 'kept'")
+	}
+}
+
+func TestArchiveProject(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "old", 2)
+	content, err := os.ReadFile(m.GetTaskFilePath("old"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if _, err := m.LoadProject("old"); err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+
+	archivePath, err := m.ArchiveProject("old")
+	if err != nil {
+		t.Fatalf("ArchiveProject: %v", err)
+	}
+	if want := filepath.Join(m.tasksDir, archiveDirName, filepath.Base(m.GetTaskFilePath("old"))); archivePath != want {
+		t.Errorf("archive path = %s, want %s", archivePath, want)
+	}
+	if archived, err := os.ReadFile(archivePath); err != nil || string(archived) != string(content) {
+		t.Errorf("archived file differs from the project file (%v)", err)
+	}
+	if m.ProjectExists("old") {
+		t.Error("ProjectExists after archiving = true")
+	}
+	if projects, err := m.ListProjects(); err != nil || len(projects) != 0 {
+		t.Errorf("ListProjects after archiving = %v, %v; want none", projects, err)
+	}
+	if _, err := m.LoadProject("old"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadProject after archiving = %v, want ErrNotFound (cache not invalidated?)", err)
+	}
+
+	// A second project of the same name archives next to the first
+	newTestProject(t, m, "old", 1)
+	second, err := m.ArchiveProject("old")
+	if err != nil {
+		t.Fatalf("ArchiveProject again: %v", err)
+	}
+	if second == archivePath || filepath.Dir(second) != filepath.Dir(archivePath) {
+		t.Errorf("second archive path = %s, want a new file beside %s", second, archivePath)
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		t.Errorf("first archive lost: %v", err)
+	}
+
+	if _, err := m.ArchiveProject("old"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("archiving a missing project = %v, want ErrNotFound", err)
+	}
+}
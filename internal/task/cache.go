@@ -0,0 +1,121 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// cachedProject is a parsed project together with the version of the file it
+// was parsed from
+type cachedProject struct {
+	project *Project
+	version fileVersion
+}
+
+// CacheStats reports how often LoadProject was served from the project cache
+type CacheStats struct {
+	Entries int    `json:"entries"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+}
+
+// cachedProjectVersion returns a copy of the cached project when it was parsed
+// from the given file version, or nil on a miss
+func (m *Manager) cachedProjectVersion(projectName string, version fileVersion) *Project {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+
+	cached, ok := m.cache[projectName]
+	if !ok || cached.version != version {
+		m.cacheStats.Misses++
+		return nil
+	}
+	m.cacheStats.Hits++
+	return cloneProject(cached.project)
+}
+
+// storeCachedProject caches a copy of a project parsed from the given file version
+func (m *Manager) storeCachedProject(projectName string, project *Project, version fileVersion) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	m.cache[projectName] = cachedProject{project: cloneProject(project), version: version}
+}
+
+// InvalidateCache drops the cached copy of a project, so the next load reads
+// the file again. Edits to the file are detected by modification time and size
+// without this; it is for writes the manager can't see.
+func (m *Manager) InvalidateCache(projectName string) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	delete(m.cache, projectName)
+}
+
+// ClearCache drops every cached project
+func (m *Manager) ClearCache() {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	m.cache = make(map[string]cachedProject)
+}
+
+// CacheStats returns the project cache's size and hit counts
+func (m *Manager) CacheStats() CacheStats {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	stats := m.cacheStats
+	stats.Entries = len(m.cache)
+	return stats
+}
+
+// cloneProject deep-copies a project, so callers can modify a cached project
+// without changing the cache
+func cloneProject(p *Project) *Project {
+	clone := *p
+	if p.Tasks != nil {
+		clone.Tasks = make([]Task, len(p.Tasks))
+		for i, t := range p.Tasks {
+			clone.Tasks[i] = cloneTask(t)
+		}
+	}
+	return &clone
+}
+
+func cloneTask(t Task) Task {
+	t.Dependencies = slices.Clone(t.Dependencies)
+	t.Tags = slices.Clone(t.Tags)
+	t.DoneCriteria = slices.Clone(t.DoneCriteria)
+	t.MetCriteria = slices.Clone(t.MetCriteria)
+	t.Choices = cloneChoices(t.Choices)
+	t.Comments = slices.Clone(t.Comments)
+	t.CompletedAt = cloneTime(t.CompletedAt)
+	if t.Subtasks != nil {
+		subtasks := make([]Subtask, len(t.Subtasks))
+		for i, s := range t.Subtasks {
+			s.Choices = cloneChoices(s.Choices)
+			s.CompletedAt = cloneTime(s.CompletedAt)
+			subtasks[i] = s
+		}
+		t.Subtasks = subtasks
+	}
+	return t
+}
+
+func cloneChoices(choices []Choice) []Choice {
+	if choices == nil {
+		return nil
+	}
+	clone := make([]Choice, len(choices))
+	for i, c := range choices {
+		c.Options = slices.Clone(c.Options)
+		c.ResolvedAt = cloneTime(c.ResolvedAt)
+		clone[i] = c
+	}
+	return clone
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
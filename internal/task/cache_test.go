@@ -0,0 +1,114 @@
+package task
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadProjectReloadsEditsOnDisk(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 2)
+	load := func() *Project {
+		t.Helper()
+		project, err := m.LoadProject("p")
+		if err != nil {
+			t.Fatalf("LoadProject: %v", err)
+		}
+		return project
+	}
+
+	load()
+	before := m.CacheStats()
+	load()
+	if after := m.CacheStats(); after.Hits != before.Hits+1 || after.Misses != before.Misses {
+		t.Errorf("second load of an unchanged file: stats %+v -> %+v, want one more hit", before, after)
+	}
+
+	// An edit that changes the file's size
+	editExternally(t, m, "p", "Do the work", "Do the work, edited elsewhere")
+	if got := load().Tasks[0].Description; got != "Do the work, edited elsewhere" {
+		t.Errorf("description after an edit on disk = %q", got)
+	}
+
+	// An edit of the same size, noticed by its modification time
+	path := m.GetTaskFilePath("p")
+	editExternally(t, m, "p", "] Task 2 (", "] Task X (")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if got := load().Tasks[1].Title; got != "Task X" {
+		t.Errorf("title after a same-size edit on disk = %q, want Task X", got)
+	}
+
+	// A same-size edit that keeps the modification time needs an explicit invalidation
+	editExternally(t, m, "p", "] Task X (", "] Task Y (")
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if got := load().Tasks[1].Title; got != "Task X" {
+		t.Errorf("title before invalidating = %q, want the cached Task X", got)
+	}
+	m.InvalidateCache("p")
+	if got := load().Tasks[1].Title; got != "Task Y" {
+		t.Errorf("title after invalidating = %q, want Task Y", got)
+	}
+}
+
+func TestCachedProjectsAreIsolatedFromCallers(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	tasks := richTestProject().Tasks
+	completed := time.Date(2024, 5, 3, 17, 0, 0, 0, time.UTC)
+	tasks[0].CompletedAt = &completed
+	if err := m.AddTasks("p", tasks); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	// Change every shared part of the loaded copy without saving
+	scribble := func(project *Project) {
+		task := &project.Tasks[0]
+		task.Title = "Changed"
+		task.Tags[0] = "changed"
+		task.DoneCriteria[0] = "changed"
+		task.MetCriteria[0] = "changed"
+		task.Subtasks[0].Title = "changed"
+		task.Choices[0].Options[0] = "changed"
+		*task.Choices[0].ResolvedAt = time.Time{}
+		*task.CompletedAt = time.Time{}
+		project.Tasks[1].Dependencies[0] = 99
+		project.Tasks = append(project.Tasks[:1], Task{Title: "Extra"})
+	}
+	scribble(project)
+
+	cached, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	checkRichRoundTrip(t, cached)
+	if choice := cached.Tasks[0].Choices[0]; choice.ResolvedAt.IsZero() || cached.Tasks[0].CompletedAt.IsZero() {
+		t.Errorf("times in the cache were changed through a loaded copy")
+	}
+
+	// The cache refreshed by a save is isolated from the saved project too
+	if err := m.SaveProject(cached); err != nil {
+		t.Fatalf("SaveProject: %v", err)
+	}
+	hits := m.CacheStats().Hits
+	scribble(cached)
+	reloaded, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if m.CacheStats().Hits != hits+1 {
+		t.Error("load after a save missed the cache")
+	}
+	checkRichRoundTrip(t, reloaded)
+}
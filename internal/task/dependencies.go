@@ -0,0 +1,61 @@
+package task
+
+// WouldCreateCycle reports whether making task fromID depend on task toID
+// would create a circular dependency: true when they are the same task or
+// toID already depends on fromID, directly or transitively
+func WouldCreateCycle(project *Project, fromID, toID int) bool {
+	if fromID == toID {
+		return true
+	}
+	return dependsOn(dependencyMap(project), toID, fromID, make(map[int]bool))
+}
+
+// InDependencyCycle reports whether following the dependencies of task taskID
+// leads into a cycle
+func InDependencyCycle(project *Project, taskID int) bool {
+	return hasCycle(taskID, dependencyMap(project), make(map[int]bool), make(map[int]bool))
+}
+
+// dependencyMap maps each task ID to the IDs of the tasks it depends on
+func dependencyMap(project *Project) map[int][]int {
+	dependencies := make(map[int][]int, len(project.Tasks))
+	for _, t := range project.Tasks {
+		dependencies[t.ID] = t.Dependencies
+	}
+	return dependencies
+}
+
+// dependsOn reports whether taskID depends on targetID, directly or transitively
+func dependsOn(dependencies map[int][]int, taskID, targetID int, visited map[int]bool) bool {
+	if visited[taskID] {
+		return false
+	}
+	visited[taskID] = true
+
+	for _, depID := range dependencies[taskID] {
+		if depID == targetID || dependsOn(dependencies, depID, targetID, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCycle runs a depth-first search from taskID, reporting whether it finds a
+// dependency already on the current path
+func hasCycle(taskID int, dependencies map[int][]int, visited, recStack map[int]bool) bool {
+	visited[taskID] = true
+	recStack[taskID] = true
+
+	for _, depID := range dependencies[taskID] {
+		if !visited[depID] {
+			if hasCycle(depID, dependencies, visited, recStack) {
+				return true
+			}
+		} else if recStack[depID] {
+			return true
+		}
+	}
+
+	recStack[taskID] = false
+	return false
+}
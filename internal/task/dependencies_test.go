@@ -0,0 +1,52 @@
+package task
+
+import "testing"
+
+func TestWouldCreateCycle(t *testing.T) {
+	// 1 -> 2 -> 3 (each task depends on the next), with 4 on its own
+	chain := &Project{Tasks: []Task{
+		{ID: 1, Title: "A", Dependencies: []int{2}},
+		{ID: 2, Title: "B", Dependencies: []int{3}},
+		{ID: 3, Title: "C"},
+		{ID: 4, Title: "D"},
+	}}
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     bool
+	}{
+		{"self dependency", 3, 3, true},
+		{"direct cycle B -> A", 2, 1, true},
+		{"indirect cycle C -> A", 3, 1, true},
+		{"indirect cycle C -> B", 3, 2, true},
+		{"shortcut along the chain A -> C", 1, 3, false},
+		{"existing dependency A -> B", 1, 2, false},
+		{"unrelated task D -> A", 4, 1, false},
+		{"onto an unrelated task C -> D", 3, 4, false},
+		{"unknown task", 1, 99, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WouldCreateCycle(chain, tt.from, tt.to); got != tt.want {
+				t.Errorf("WouldCreateCycle(%d -> %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInDependencyCycle(t *testing.T) {
+	// 1 -> 2 -> 1 is a cycle; 3 leads into it and 4 doesn't
+	project := &Project{Tasks: []Task{
+		{ID: 1, Title: "A", Dependencies: []int{2}},
+		{ID: 2, Title: "B", Dependencies: []int{1}},
+		{ID: 3, Title: "C", Dependencies: []int{1}},
+		{ID: 4, Title: "D", Dependencies: []int{5}},
+		{ID: 5, Title: "E"},
+	}}
+	for id, want := range map[int]bool{1: true, 2: true, 3: true, 4: false, 5: false} {
+		if got := InDependencyCycle(project, id); got != want {
+			t.Errorf("InDependencyCycle(%d) = %v, want %v", id, got, want)
+		}
+	}
+}
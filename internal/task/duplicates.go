@@ -0,0 +1,166 @@
+package task
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultDuplicateThreshold is the title similarity (0-1) above which fuzzy
+// matching reports two tasks as suspected duplicates
+const DefaultDuplicateThreshold = 0.85
+
+// Reasons two tasks are reported as duplicates
+const (
+	DuplicateSameTitle       = "same_title"
+	DuplicateSameDescription = "same_description"
+	DuplicateSimilarTitle    = "similar_title"
+)
+
+// DuplicateTask identifies a task within a duplicate group
+type DuplicateTask struct {
+	ID     int        `json:"id"`
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+// DuplicateGroup is a set of tasks suspected to describe the same work
+type DuplicateGroup struct {
+	Tasks   []DuplicateTask `json:"tasks"`
+	Reasons []string        `json:"reasons"`
+	// Similarity is the lowest title similarity between directly matched tasks
+	Similarity float64 `json:"similarity"`
+}
+
+// normalizeForComparison lowercases text, drops punctuation and collapses
+// whitespace so cosmetic differences don't hide duplicates
+func normalizeForComparison(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// similarity returns 1 minus the edit distance between a and b divided by the
+// length of the longer string: 1 for identical strings, 0 for nothing in common
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	// Levenshtein distance with a single rolling row
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		previous := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			current := row[j]
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			row[j] = min(row[j]+1, row[j-1]+1, previous+cost)
+			previous = current
+		}
+	}
+
+	return 1 - float64(row[len(rb)])/float64(longest)
+}
+
+// FindDuplicateTasks groups tasks whose normalized titles or descriptions are
+// identical. With fuzzy set, tasks whose normalized titles are at least
+// threshold similar are grouped too. Groups are transitive: if A matches B and
+// B matches C, all three are reported together.
+func (p *Project) FindDuplicateTasks(fuzzy bool, threshold float64) []DuplicateGroup {
+	count := len(p.Tasks)
+	titles := make([]string, count)
+	descriptions := make([]string, count)
+	for i, t := range p.Tasks {
+		titles[i] = normalizeForComparison(t.Title)
+		descriptions[i] = normalizeForComparison(t.Description)
+	}
+
+	// Union-find over task indexes
+	parent := make([]int, count)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	reasons := make(map[int]map[string]bool)
+	lowest := make(map[int]float64)
+	type match struct {
+		a, b   int
+		reason string
+		score  float64
+	}
+	var matches []match
+
+	for i := 0; i < count; i++ {
+		for j := i + 1; j < count; j++ {
+			score := similarity(titles[i], titles[j])
+			switch {
+			case titles[i] == titles[j]:
+				matches = append(matches, match{i, j, DuplicateSameTitle, 1})
+			case descriptions[i] != "" && descriptions[i] == descriptions[j]:
+				matches = append(matches, match{i, j, DuplicateSameDescription, score})
+			case fuzzy && score >= threshold:
+				matches = append(matches, match{i, j, DuplicateSimilarTitle, score})
+			}
+		}
+	}
+
+	for _, m := range matches {
+		parent[find(m.a)] = find(m.b)
+	}
+	for _, m := range matches {
+		root := find(m.a)
+		if reasons[root] == nil {
+			reasons[root] = make(map[string]bool)
+			lowest[root] = m.score
+		}
+		reasons[root][m.reason] = true
+		lowest[root] = min(lowest[root], m.score)
+	}
+
+	members := make(map[int][]DuplicateTask)
+	var roots []int
+	for i, t := range p.Tasks {
+		root := find(i)
+		if reasons[root] == nil {
+			continue
+		}
+		if members[root] == nil {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], DuplicateTask{ID: t.ID, Title: t.Title, Status: t.Status})
+	}
+
+	groups := make([]DuplicateGroup, 0, len(roots))
+	for _, root := range roots {
+		group := DuplicateGroup{Tasks: members[root], Similarity: lowest[root]}
+		for reason := range reasons[root] {
+			group.Reasons = append(group.Reasons, reason)
+		}
+		sort.Strings(group.Reasons)
+		groups = append(groups, group)
+	}
+	return groups
+}
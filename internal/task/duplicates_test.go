@@ -0,0 +1,93 @@
+package task
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"same", "same", 1},
+		{"abc", "", 0},
+		{"abc", "xyz", 0},
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"documentation", "documentaton", 1 - 1.0/13},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := similarity(tt.b, tt.a); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("similarity(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicateTasks(t *testing.T) {
+	project := testProject(
+		Task{Title: "Set up CI", Description: "Pipeline"},
+		Task{Title: "Write API documentation", Description: "Reference docs"},
+		Task{Title: "set up ci!", Description: "GitHub Actions"},
+		Task{Title: "Write API documentaton", Description: "Endpoint docs"},
+		Task{Title: "Login page", Description: "Users sign in with email"},
+		Task{Title: "Sign-in screen", Description: "Users sign in with email."},
+		Task{Title: "Deploy", Description: "Ship it"},
+	)
+
+	groupIDs := func(groups []DuplicateGroup) [][]int {
+		var ids [][]int
+		for _, group := range groups {
+			var members []int
+			for _, task := range group.Tasks {
+				members = append(members, task.ID)
+			}
+			ids = append(ids, members)
+		}
+		return ids
+	}
+
+	exact := project.FindDuplicateTasks(false, DefaultDuplicateThreshold)
+	if got, want := groupIDs(exact), [][]int{{1, 3}, {5, 6}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("exact groups = %v, want %v", got, want)
+	}
+	if g := exact[0]; !slices.Equal(g.Reasons, []string{DuplicateSameTitle}) || g.Similarity != 1 {
+		t.Errorf("same title group = %+v", g)
+	}
+	if g := exact[1]; !slices.Equal(g.Reasons, []string{DuplicateSameDescription}) || g.Similarity >= DefaultDuplicateThreshold {
+		t.Errorf("same description group = %+v", g)
+	}
+
+	fuzzy := project.FindDuplicateTasks(true, DefaultDuplicateThreshold)
+	if got, want := groupIDs(fuzzy), [][]int{{1, 3}, {2, 4}, {5, 6}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("fuzzy groups = %v, want %v", got, want)
+	}
+	if g := fuzzy[1]; !slices.Equal(g.Reasons, []string{DuplicateSimilarTitle}) || g.Similarity < DefaultDuplicateThreshold || g.Similarity == 1 {
+		t.Errorf("similar title group = %+v", g)
+	}
+
+	// A stricter threshold drops the near match
+	if got := groupIDs(project.FindDuplicateTasks(true, 0.99)); len(got) != 2 {
+		t.Errorf("groups at threshold 0.99 = %v, want only the exact matches", got)
+	}
+
+	// Matches chain: A~B and B~C put all three in one group
+	chain := testProject(
+		Task{Title: "Fix login bug"},
+		Task{Title: "Fix login bugs"},
+		Task{Title: "Fix logins bugs"},
+		Task{Title: "Unrelated"},
+	)
+	if got := groupIDs(chain.FindDuplicateTasks(true, 0.9)); !slices.EqualFunc(got, [][]int{{1, 2, 3}}, slices.Equal) {
+		t.Errorf("chained groups = %v, want [[1 2 3]]", got)
+	}
+
+	single := testProject(Task{Title: "Only one"})
+	if groups := single.FindDuplicateTasks(true, DefaultDuplicateThreshold); len(groups) != 0 {
+		t.Errorf("groups for a single task = %+v", groups)
+	}
+}
@@ -0,0 +1,40 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds let callers tell user mistakes apart from system failures.
+// Check them with errors.Is; errors without a kind are internal failures.
+var (
+	// ErrInvalidInput means the caller supplied a malformed or out-of-range value
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrNotFound means the referenced project, task or subtask does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict means the operation clashes with existing state, e.g. a duplicate title
+	ErrConflict = errors.New("conflict")
+)
+
+// kindError tags an error with one of the error kinds without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// NewError formats an error (supporting %w) and tags it with the given kind
+func NewError(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, err: fmt.Errorf(format, args...)}
+}
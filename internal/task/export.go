@@ -0,0 +1,91 @@
+package task
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumns are the columns of a tasks CSV export
+var csvColumns = []string{
+	"id", "title", "category", "priority", "status", "complexity",
+	"estimated_hours", "subtask_count", "completed_subtasks", "dependencies",
+}
+
+// WriteTasksCSV writes a project's tasks as CSV, one row per task, with
+// dependencies as semicolon-separated task IDs. With flattenSubtasks, each
+// subtask gets its own row after its task, identified as "<task id>.<n>",
+// and a parent_id column links it to the task.
+func WriteTasksCSV(w io.Writer, project *Project, flattenSubtasks bool) error {
+	writer := csv.NewWriter(w)
+
+	header := csvColumns
+	if flattenSubtasks {
+		header = append([]string{"id", "parent_id"}, csvColumns[1:]...)
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, t := range project.Tasks {
+		completed := 0
+		for _, subtask := range t.Subtasks {
+			if subtask.Status == StatusDone {
+				completed++
+			}
+		}
+		dependencies := make([]string, len(t.Dependencies))
+		for i, depID := range t.Dependencies {
+			dependencies[i] = strconv.Itoa(depID)
+		}
+
+		row := []string{
+			strconv.Itoa(t.ID),
+			t.Title,
+			string(t.Category),
+			string(t.Priority),
+			string(t.Status),
+			string(t.Complexity),
+			strconv.Itoa(t.EstimatedHours),
+			strconv.Itoa(len(t.Subtasks)),
+			strconv.Itoa(completed),
+			strings.Join(dependencies, ";"),
+		}
+		if flattenSubtasks {
+			row = append([]string{row[0], ""}, row[1:]...)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		if !flattenSubtasks {
+			continue
+		}
+		for i, subtask := range t.Subtasks {
+			row := []string{
+				fmt.Sprintf("%d.%d", t.ID, i+1),
+				strconv.Itoa(t.ID),
+				subtask.Title,
+				"",
+				"",
+				string(subtask.Status),
+				string(subtask.Complexity),
+				strconv.Itoa(subtask.EstimatedHours),
+				"",
+				"",
+				"",
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
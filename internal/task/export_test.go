@@ -0,0 +1,96 @@
+package task
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares output with testdata/name, rewriting the file with -update
+func checkGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s:\n got:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// exportTestProject has titles with the characters each export format must
+// escape: commas and quotes for CSV, quotes and brackets for Mermaid labels,
+// and colons, semicolons and hashes for Gantt tasks
+func exportTestProject() Project {
+	created := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	return Project{
+		Name:      "Launch: v2",
+		CreatedAt: created,
+		Tasks: []Task{
+			{ID: 1, Title: `Design "v2" schema, tables`, Category: CategoryMVP, Priority: PriorityP0, Status: StatusDone,
+				Complexity: ComplexityHigh, EstimatedHours: 6,
+				Subtasks: []Subtask{
+					{Title: "Users, roles", Status: StatusDone},
+					{Title: `Indexes "fast"`, Status: StatusTodo, Complexity: ComplexityLow, EstimatedHours: 2},
+				}},
+			{ID: 2, Title: "[API] handlers", Priority: PriorityP1, Status: StatusInProgress, EstimatedHours: 10, Dependencies: []int{1}},
+			{ID: 3, Title: `Fix: login; "remember me" #12;`, Category: CategoryUX, Priority: PriorityP2, Status: StatusBlocked,
+				Dependencies: []int{1, 2}},
+			{ID: 4, Title: "A very long task title that will be cut short in the graph", Priority: PriorityP3, Status: StatusTodo,
+				Dependencies: []int{3, 99}},
+		},
+	}
+}
+
+func TestWriteTasksCSVGolden(t *testing.T) {
+	project := exportTestProject()
+	for _, tt := range []struct {
+		golden  string
+		flatten bool
+	}{
+		{"tasks.csv.golden", false},
+		{"tasks_flat.csv.golden", true},
+	} {
+		t.Run(tt.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteTasksCSV(&buf, &project, tt.flatten); err != nil {
+				t.Fatalf("WriteTasksCSV: %v", err)
+			}
+			checkGolden(t, tt.golden, buf.String())
+
+			// The escaping reads back to the original titles
+			records, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+			if err != nil {
+				t.Fatalf("reading the CSV back: %v", err)
+			}
+			titleColumn := 1
+			if tt.flatten {
+				titleColumn = 2
+			}
+			if got, want := records[1][titleColumn], project.Tasks[0].Title; got != want {
+				t.Errorf("title read back = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestMermaidDependencyGraphGolden(t *testing.T) {
+	m := newTestManager(t)
+	checkGolden(t, "dependency_graph.golden", m.generateMermaidDependencyGraph(exportTestProject()))
+}
+
+func TestMermaidGanttGolden(t *testing.T) {
+	checkGolden(t, "gantt.golden", GenerateMermaidGantt(exportTestProject()))
+}
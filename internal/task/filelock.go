@@ -0,0 +1,85 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultLockTimeout is how long a save waits for another process to release
+// a project file before giving up
+const DefaultLockTimeout = 5 * time.Second
+
+// lockPollInterval is how often a blocked save retries the file lock
+const lockPollInterval = 10 * time.Millisecond
+
+// lockFileSuffix names the sidecar file locked while a project file is
+// written. Sidecars are left in place: removing one while another process
+// waits on it would let two writers lock different files.
+const lockFileSuffix = ".lock"
+
+// lockProjectFile takes an exclusive advisory lock on the sidecar of a project
+// file, so servers in other processes sharing the tasks directory can't write
+// it at the same time. Callers hold the in-process project lock first, so
+// goroutines in this process queue there rather than polling the file lock.
+// Returns a function that releases the lock.
+func (m *Manager) lockProjectFile(filePath string) (func(), error) {
+	file, err := os.OpenFile(filePath+lockFileSuffix, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(m.config.LockTimeout)
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock project file: %w", err)
+		}
+		if locked {
+			return func() {
+				unlockFile(file)
+				file.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, NewError(ErrConflict, "timed out after %s waiting for another process to release %s",
+				m.config.LockTimeout, filepath.Base(filePath))
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// writeFileAtomic replaces a file by writing a temporary file in the same
+// directory and renaming it over the original, so readers never see a
+// partially written file
+func writeFileAtomic(filePath string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
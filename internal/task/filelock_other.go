@@ -0,0 +1,14 @@
+//go:build !unix
+
+package task
+
+import "os"
+
+// tryLockFile always succeeds on platforms without flock, where only the
+// in-process project lock protects project files
+func tryLockFile(file *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlockFile is a no-op on platforms without flock
+func unlockFile(file *os.File) {}
@@ -0,0 +1,122 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultGanttHours is the bar length, one day, of tasks without an estimate
+const defaultGanttHours = 24
+
+// ganttDateFormat is the Go layout of the dateFormat declared in Gantt charts
+const ganttDateFormat = "2006-01-02 15:04"
+
+// GenerateMermaidGantt creates the source of a Mermaid Gantt chart that lays
+// the project's tasks out one after another, starting on the day the project
+// was created. Each task starts once the task before it and all of its
+// dependencies are finished, and lasts its estimated hours, or a day without
+// an estimate. Done tasks are marked done, tasks in progress active and
+// blocked tasks critical.
+func GenerateMermaidGantt(project Project) string {
+	var content strings.Builder
+
+	start := project.CreatedAt
+	if start.IsZero() {
+		start = time.Now()
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	content.WriteString("gantt\n")
+	content.WriteString(fmt.Sprintf("    title %s\n", ganttLabel(project.Name)))
+	content.WriteString("    dateFormat YYYY-MM-DD HH:mm\n")
+	content.WriteString("    axisFormat %m-%d\n")
+	content.WriteString("    section Tasks\n")
+
+	offset := 0
+	for _, t := range ganttOrder(project) {
+		hours := t.EstimatedHours
+		duration := fmt.Sprintf("%dh", hours)
+		if hours <= 0 {
+			hours = defaultGanttHours
+			duration = "1d"
+		}
+
+		tags := fmt.Sprintf("t%d", t.ID)
+		switch t.Status {
+		case StatusDone:
+			tags = "done, " + tags
+		case StatusInProgress:
+			tags = "active, " + tags
+		case StatusBlocked:
+			tags = "crit, " + tags
+		}
+
+		taskStart := start.Add(time.Duration(offset) * time.Hour)
+		content.WriteString(fmt.Sprintf("    %d. %s :%s, %s, %s\n", t.ID, ganttLabel(t.Title), tags, taskStart.Format(ganttDateFormat), duration))
+		offset += hours
+	}
+
+	return content.String()
+}
+
+// ganttOrder returns the tasks in the order they are charted: project order,
+// except that a task waits until the tasks it depends on are placed.
+// Dependencies on missing tasks are ignored, and tasks caught in a dependency
+// cycle go last in project order.
+func ganttOrder(project Project) []Task {
+	position := make(map[int]int, len(project.Tasks))
+	for i, t := range project.Tasks {
+		position[t.ID] = i
+	}
+
+	waitingOn := make([]int, len(project.Tasks))
+	dependents := make(map[int][]int)
+	for i, t := range project.Tasks {
+		for _, depID := range t.Dependencies {
+			if _, ok := position[depID]; ok && depID != t.ID {
+				waitingOn[i]++
+				dependents[depID] = append(dependents[depID], i)
+			}
+		}
+	}
+
+	var ready []int
+	for i := range project.Tasks {
+		if waitingOn[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]Task, 0, len(project.Tasks))
+	placed := make([]bool, len(project.Tasks))
+	for len(ready) > 0 {
+		// Take the earliest ready task in project order
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+
+		ordered = append(ordered, project.Tasks[i])
+		placed[i] = true
+		for _, dependent := range dependents[project.Tasks[i].ID] {
+			waitingOn[dependent]--
+			if waitingOn[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	for i, t := range project.Tasks {
+		if !placed[i] {
+			ordered = append(ordered, t)
+		}
+	}
+
+	return ordered
+}
+
+// ganttLabel removes the characters Mermaid reads as Gantt syntax from a title
+func ganttLabel(title string) string {
+	return strings.NewReplacer(":", " -", "#", "", ";", ",").Replace(title)
+}
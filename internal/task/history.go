@@ -0,0 +1,223 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// historyDirName is the subdirectory of the tasks directory holding the
+// previous versions of each project file, one directory per project
+const historyDirName = ".history"
+
+// DefaultHistoryLimit is how many previous versions are kept per project
+const DefaultHistoryLimit = 20
+
+// historyVersionFormat names history files after the time they were replaced,
+// so names sort in time order
+const historyVersionFormat = "20060102T150405.000000000Z"
+
+// HistoryVersion is a previous version of a project file
+type HistoryVersion struct {
+	// Version identifies the version for RestoreVersion
+	Version string `json:"version"`
+	// ReplacedAt is when a save replaced this version
+	ReplacedAt time.Time `json:"replaced_at"`
+	Size       int64     `json:"size"`
+}
+
+// historyDir returns the directory holding a project's previous versions,
+// named after its task file
+func (m *Manager) historyDir(projectName string) string {
+	base := strings.TrimSuffix(filepath.Base(m.GetTaskFilePath(projectName)), ".md")
+	return filepath.Join(m.tasksDir, historyDirName, base)
+}
+
+// recordHistory stores the content a write is about to replace as the
+// project's newest version, dropping the oldest versions beyond the history
+// limit. Callers hold the project lock.
+func (m *Manager) recordHistory(projectName string, content []byte) error {
+	if m.config.HistoryLimit < 0 {
+		return nil
+	}
+
+	dir := m.historyDir(projectName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	version := time.Now().UTC().Format(historyVersionFormat)
+	if err := os.WriteFile(filepath.Join(dir, version+".md"), content, 0644); err != nil {
+		return fmt.Errorf("failed to record project history: %w", err)
+	}
+
+	versions, err := m.readHistory(projectName)
+	if err != nil {
+		return err
+	}
+	for _, old := range versions[min(len(versions), m.config.HistoryLimit):] {
+		if err := os.Remove(filepath.Join(dir, old.Version+".md")); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune project history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readHistory returns a project's previous versions, newest first
+func (m *Manager) readHistory(projectName string) ([]HistoryVersion, error) {
+	entries, err := os.ReadDir(m.historyDir(projectName))
+	if os.IsNotExist(err) {
+		return []HistoryVersion{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	versions := []HistoryVersion{}
+	for _, entry := range entries {
+		version, ok := strings.CutSuffix(entry.Name(), ".md")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		replacedAt, err := time.Parse(historyVersionFormat, version)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		versions = append(versions, HistoryVersion{Version: version, ReplacedAt: replacedAt, Size: info.Size()})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+	return versions, nil
+}
+
+// ListHistory returns the previous versions kept for a project, newest first
+func (m *Manager) ListHistory(projectName string) ([]HistoryVersion, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return nil, err
+	}
+
+	lock := m.projectLock(projectName)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return m.readHistory(projectName)
+}
+
+// Undo restores a project to the version before its last change and removes
+// that version from the history, so repeated calls step further back
+func (m *Manager) Undo(projectName string) error {
+	return m.restoreHistory(projectName, "", true)
+}
+
+// RestoreVersion restores a project to one of its previous versions. The
+// current content is recorded in the history first, so the restore can itself
+// be undone.
+func (m *Manager) RestoreVersion(projectName string, version string) error {
+	if version == "" {
+		return NewError(ErrInvalidInput, "version cannot be empty")
+	}
+	return m.restoreHistory(projectName, version, false)
+}
+
+// restoreHistory replaces a project file with a previous version, the newest
+// when version is empty, and notifies save listeners
+func (m *Manager) restoreHistory(projectName string, version string, undo bool) error {
+	if err := ValidateProjectName(projectName); err != nil {
+		return err
+	}
+
+	project, err := m.writeHistoryVersion(projectName, version, undo)
+	if err != nil {
+		return err
+	}
+
+	m.notifySaved(project)
+	return nil
+}
+
+// writeHistoryVersion writes a previous version over a project file under the
+// project lock and returns the restored project. Undoing consumes the
+// version; otherwise the replaced content is recorded as a new version.
+func (m *Manager) writeHistoryVersion(projectName string, version string, undo bool) (*Project, error) {
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	filePath := m.GetTaskFilePath(projectName)
+	unlock, err := m.lockProjectFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, NewError(ErrNotFound, "project file not found: %s", projectName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	versions, err := m.readHistory(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, NewError(ErrNotFound, "project '%s' has no history to restore", projectName)
+	}
+	if version == "" {
+		version = versions[0].Version
+	}
+	found := false
+	for _, v := range versions {
+		if v.Version == version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, NewError(ErrNotFound, "version '%s' not found in the history of project '%s'", version, projectName)
+	}
+
+	versionPath := filepath.Join(m.historyDir(projectName), version+".md")
+	content, err := os.ReadFile(versionPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project version: %w", err)
+	}
+
+	// Refuse to restore a version that no longer parses
+	project, err := m.parseMarkdown(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse project version %s: %w", version, err)
+	}
+	project.Name = projectName
+
+	if !undo {
+		if err := m.recordHistory(projectName, current); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeFileAtomic(filePath, content); err != nil {
+		return nil, fmt.Errorf("failed to restore project file: %w", err)
+	}
+	m.InvalidateCache(projectName)
+
+	if undo {
+		if err := os.Remove(versionPath); err != nil {
+			return nil, fmt.Errorf("restored project but failed to remove the restored version from history: %w", err)
+		}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// taskTitles loads a project and returns its task titles
+func taskTitles(t *testing.T, m *Manager, projectName string) []string {
+	t.Helper()
+	project, err := m.LoadProject(projectName)
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	var titles []string
+	for _, task := range project.Tasks {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func TestUndoStepsBackThroughChanges(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	for _, title := range []string{"First", "Second"} {
+		if err := m.AddTask("p", Task{Title: title}); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+	}
+	if err := m.UpdateTaskStatus("p", "First", "", StatusDone); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+
+	// Undoing the edit restores the status, without the cache serving the edit
+	if err := m.Undo("p"); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if status := project.Tasks[0].Status; status != StatusTodo {
+		t.Errorf("status after undoing the edit = %s, want todo", status)
+	}
+
+	if err := m.Undo("p"); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if got := taskTitles(t, m, "p"); !slices.Equal(got, []string{"First"}) {
+		t.Errorf("tasks after undoing the second add = %v, want [First]", got)
+	}
+	if err := m.Undo("p"); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if got := taskTitles(t, m, "p"); len(got) != 0 {
+		t.Errorf("tasks after undoing the first add = %v, want none", got)
+	}
+
+	// The history is used up: the project as created has nothing before it
+	if history, err := m.ListHistory("p"); err != nil || len(history) != 0 {
+		t.Errorf("history after undoing everything = %v, %v; want none", history, err)
+	}
+	if err := m.Undo("p"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Undo with empty history = %v, want ErrNotFound", err)
+	}
+	if err := m.Undo("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Undo of a missing project = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRestoreVersionCanBeUndone(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 1)
+	for _, title := range []string{"Second", "Third"} {
+		if err := m.AddTask("p", Task{Title: title}); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+	}
+
+	history, err := m.ListHistory("p")
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("history has %d versions, want 3", len(history))
+	}
+	// Newest first: before Third, before Second, before Task 1
+	if err := m.RestoreVersion("p", history[1].Version); err != nil {
+		t.Fatalf("RestoreVersion: %v", err)
+	}
+	if got := taskTitles(t, m, "p"); !slices.Equal(got, []string{"Task 1"}) {
+		t.Errorf("tasks after restoring = %v, want [Task 1]", got)
+	}
+
+	if err := m.Undo("p"); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if got := taskTitles(t, m, "p"); !slices.Equal(got, []string{"Task 1", "Second", "Third"}) {
+		t.Errorf("tasks after undoing the restore = %v, want all three", got)
+	}
+
+	for _, version := range []string{"", "20000101T000000.000000000Z"} {
+		if err := m.RestoreVersion("p", version); err == nil {
+			t.Errorf("RestoreVersion(%q) succeeded", version)
+		}
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	for _, tt := range []struct {
+		limit, want int
+	}{{2, 2}, {-1, 0}} {
+		m, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{HistoryLimit: tt.limit})
+		if err != nil {
+			t.Fatalf("NewManagerWithConfig: %v", err)
+		}
+		newTestProject(t, m, "p", 1)
+		for _, title := range []string{"Second", "Third", "Fourth"} {
+			if err := m.AddTask("p", Task{Title: title}); err != nil {
+				t.Fatalf("AddTask: %v", err)
+			}
+		}
+		if history, err := m.ListHistory("p"); err != nil || len(history) != tt.want {
+			t.Errorf("limit %d: history has %d versions (%v), want %d", tt.limit, len(history), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ImportProject saves a project built outside the manager, such as one decoded
+// from an export_project JSON document, as the project's markdown file. Tasks
+// keep their IDs and dependencies, but details the markdown format doesn't
+// record, such as choices on subtasks, are lost. Missing statuses and
+// priorities get the defaults. The project is rejected when it has invalid
+// fields, duplicate IDs or titles, or dependencies on missing tasks. An existing
+// project is only replaced when overwrite is set, and its previous content is
+// kept in the history.
+func (m *Manager) ImportProject(project *Project, overwrite bool) error {
+	if err := ValidateProjectName(project.Name); err != nil {
+		return err
+	}
+	if err := normalizeImportedProject(project); err != nil {
+		return err
+	}
+
+	if !overwrite && m.ProjectExists(project.Name) {
+		return NewError(ErrConflict, "project '%s' already exists; set overwrite to replace it", project.Name)
+	}
+
+	// Write over whatever is on disk rather than checking for concurrent edits
+	project.loadedVersion = fileVersion{}
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = time.Now().UTC()
+	}
+	return m.SaveProject(project)
+}
+
+// normalizeImportedProject validates an imported project, normalizes its tags
+// and fills in default statuses and priorities
+func normalizeImportedProject(project *Project) error {
+	if project.Tasks == nil {
+		project.Tasks = []Task{}
+	}
+
+	description, err := ValidateProjectDescription(project.Description)
+	if err != nil {
+		return err
+	}
+	project.Description = description
+
+	ids := make(map[int]bool, len(project.Tasks))
+	for i := range project.Tasks {
+		t := &project.Tasks[i]
+
+		title, err := ValidateTaskTitle(t.Title)
+		if err != nil {
+			return fmt.Errorf("invalid task %d: %w", i+1, err)
+		}
+		t.Title = title
+
+		if t.ID <= 0 {
+			return NewError(ErrInvalidInput, "task '%s' has invalid ID %d", t.Title, t.ID)
+		}
+		if ids[t.ID] {
+			return NewError(ErrInvalidInput, "task ID %d is used by more than one task", t.ID)
+		}
+		ids[t.ID] = true
+
+		if t.Status == "" {
+			t.Status = DefaultTaskStatus()
+		} else if _, err := ValidateTaskStatus(string(t.Status)); err != nil {
+			return fmt.Errorf("task '%s': %w", t.Title, err)
+		}
+		if t.Priority == "" {
+			t.Priority = DefaultTaskPriority()
+		} else if _, err := ValidateTaskPriority(string(t.Priority)); err != nil {
+			return fmt.Errorf("task '%s': %w", t.Title, err)
+		}
+		if t.Category != "" {
+			if _, err := ValidateTaskCategory(string(t.Category)); err != nil {
+				return fmt.Errorf("task '%s': %w", t.Title, err)
+			}
+		}
+		if t.Complexity != "" {
+			if _, err := ValidateTaskComplexity(string(t.Complexity)); err != nil {
+				return fmt.Errorf("task '%s': %w", t.Title, err)
+			}
+		}
+
+		if t.Status != StatusBlocked {
+			t.BlockedReason = ""
+		} else if t.BlockedReason != "" {
+			if t.BlockedReason, err = ValidateBlockedReason(t.BlockedReason); err != nil {
+				return fmt.Errorf("task '%s': %w", t.Title, err)
+			}
+		}
+		if t.Assignee != "" {
+			if t.Assignee, err = ValidateAssignee(t.Assignee); err != nil {
+				return fmt.Errorf("task '%s': %w", t.Title, err)
+			}
+		}
+
+		for j, tag := range t.Tags {
+			if t.Tags[j], err = ValidateTag(tag); err != nil {
+				return fmt.Errorf("task '%s': %w", t.Title, err)
+			}
+		}
+		for j, choice := range t.Choices {
+			if err := ValidateChoice(choice); err != nil {
+				return fmt.Errorf("task '%s' choice %d: %w", t.Title, j+1, err)
+			}
+		}
+
+		for j := range t.Comments {
+			comment := &t.Comments[j]
+			if comment.Author, comment.Text, err = ValidateComment(comment.Author, comment.Text); err != nil {
+				return fmt.Errorf("task '%s' comment %d: %w", t.Title, j+1, err)
+			}
+		}
+
+		for j := range t.Subtasks {
+			subtask := &t.Subtasks[j]
+			if subtask.Title, err = ValidateTaskTitle(subtask.Title); err != nil {
+				return fmt.Errorf("task '%s' subtask %d: %w", t.Title, j+1, err)
+			}
+			if subtask.Status == "" {
+				subtask.Status = DefaultTaskStatus()
+			} else if _, err := ValidateTaskStatus(string(subtask.Status)); err != nil {
+				return fmt.Errorf("task '%s' subtask '%s': %w", t.Title, subtask.Title, err)
+			}
+			for k, choice := range subtask.Choices {
+				if err := ValidateChoice(choice); err != nil {
+					return fmt.Errorf("task '%s' subtask '%s' choice %d: %w", t.Title, subtask.Title, k+1, err)
+				}
+			}
+		}
+	}
+
+	if issues := ValidateProject(project); len(issues) > 0 {
+		messages := make([]string, len(issues))
+		for i, issue := range issues {
+			messages[i] = fmt.Sprintf("task '%s': %s", issue.TaskTitle, issue.Message)
+		}
+		return NewError(ErrInvalidInput, "invalid project: %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
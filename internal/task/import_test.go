@@ -0,0 +1,68 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectTimestampsAreUTC(t *testing.T) {
+	m := newTestManager(t)
+
+	imported := &Project{Name: "imported", Tasks: []Task{{ID: 1, Title: "First", Description: "First task"}}}
+	if err := m.ImportProject(imported, false); err != nil {
+		t.Fatalf("ImportProject: %v", err)
+	}
+	if loc := imported.CreatedAt.Location(); loc != time.UTC {
+		t.Errorf("imported CreatedAt is in %v, want UTC", loc)
+	}
+	if loc := imported.UpdatedAt.Location(); loc != time.UTC {
+		t.Errorf("imported UpdatedAt is in %v, want UTC", loc)
+	}
+
+	newTestProject(t, m, "p", 1)
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if err := m.SaveProject(project); err != nil {
+		t.Fatalf("SaveProject: %v", err)
+	}
+	if loc := project.UpdatedAt.Location(); loc != time.UTC {
+		t.Errorf("saved UpdatedAt is in %v, want UTC", loc)
+	}
+}
+
+func TestImportProjectValidatesTagsAndChoices(t *testing.T) {
+	m := newTestManager(t)
+	longTag := strings.Repeat("x", 51)
+	badChoice := Choice{Question: "Which store?", Options: []string{"Files"}}
+
+	tests := map[string]Task{
+		"empty tag":           {Tags: []string{" "}},
+		"long tag":            {Tags: []string{longTag}},
+		"tag with comma":      {Tags: []string{"a,b"}},
+		"task choice":         {Choices: []Choice{badChoice}},
+		"subtask choice":      {Subtasks: []Subtask{{Title: "Step", Choices: []Choice{badChoice}}}},
+		"question with break": {Choices: []Choice{{Question: "Which\nstore?", Options: []string{"Files", "Database"}}}},
+	}
+	for name, task := range tests {
+		t.Run(name, func(t *testing.T) {
+			task.ID = 1
+			task.Title = "First"
+			project := &Project{Name: "imported", Tasks: []Task{task}}
+			if err := m.ImportProject(project, true); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("ImportProject error = %v, want invalid input", err)
+			}
+		})
+	}
+
+	project := &Project{Name: "imported", Tasks: []Task{{ID: 1, Title: "First", Tags: []string{" Backend "}}}}
+	if err := m.ImportProject(project, true); err != nil {
+		t.Fatalf("ImportProject: %v", err)
+	}
+	if got := project.Tasks[0].Tags; len(got) != 1 || got[0] != NormalizeTag(" Backend ") {
+		t.Errorf("tags = %q, want the normalized tag", got)
+	}
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MarkdownLabels are the words used for headings and field markers in project
+// files. The same labels are used to parse files back, so changing them makes
+// files written with the old labels unreadable until the labels are restored.
+type MarkdownLabels struct {
+	Title           string `json:"title"`
+	Overview        string `json:"overview"`
+	ProgressSummary string `json:"progress_summary"`
+	Categories      string `json:"categories"`
+	PriorityLevels  string `json:"priority_levels"`
+	Task            string `json:"task"`
+	Tags            string `json:"tags"`
+	Assignee        string `json:"assignee"`
+	BlockedReason   string `json:"blocked_reason"`
+	Dependencies    string `json:"dependencies"`
+	DoneCriteria    string `json:"done_criteria"`
+	Complexity      string `json:"complexity"`
+	EstimatedHours  string `json:"estimated_hours"`
+	Choices         string `json:"choices"`
+	Subtasks        string `json:"subtasks"`
+	Choice          string `json:"choice"`
+	Options         string `json:"options"`
+	Reasoning       string `json:"reasoning"`
+	Notes           string `json:"notes"`
+}
+
+// DefaultMarkdownLabels returns the English labels
+func DefaultMarkdownLabels() MarkdownLabels {
+	return MarkdownLabels{
+		Title:           "Project Tasks",
+		Overview:        "Project Overview",
+		ProgressSummary: "Progress Summary",
+		Categories:      "Categories",
+		PriorityLevels:  "Priority Levels",
+		Task:            "Task",
+		Tags:            "Tags",
+		Assignee:        "Assignee",
+		BlockedReason:   "Blocked reason",
+		Dependencies:    "Dependencies",
+		DoneCriteria:    "Definition of Done",
+		Complexity:      "Complexity",
+		EstimatedHours:  "Estimated hours",
+		Choices:         "Choices",
+		Subtasks:        "Subtasks",
+		Choice:          "Choice",
+		Options:         "Options",
+		Reasoning:       "Reasoning",
+		Notes:           "Notes",
+	}
+}
+
+// fields maps label names, as used in config, to the labels they set
+func (l *MarkdownLabels) fields() map[string]*string {
+	return map[string]*string{
+		"title":            &l.Title,
+		"overview":         &l.Overview,
+		"progress_summary": &l.ProgressSummary,
+		"categories":       &l.Categories,
+		"priority_levels":  &l.PriorityLevels,
+		"task":             &l.Task,
+		"tags":             &l.Tags,
+		"assignee":         &l.Assignee,
+		"blocked_reason":   &l.BlockedReason,
+		"dependencies":     &l.Dependencies,
+		"done_criteria":    &l.DoneCriteria,
+		"complexity":       &l.Complexity,
+		"estimated_hours":  &l.EstimatedHours,
+		"choices":          &l.Choices,
+		"subtasks":         &l.Subtasks,
+		"choice":           &l.Choice,
+		"options":          &l.Options,
+		"reasoning":        &l.Reasoning,
+		"notes":            &l.Notes,
+	}
+}
+
+// Set changes a single label by its config name (e.g. "subtasks")
+func (l *MarkdownLabels) Set(name, value string) error {
+	field, exists := l.fields()[name]
+	if !exists {
+		names := make([]string, 0, len(l.fields()))
+		for known := range l.fields() {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return NewError(ErrInvalidInput, "unknown markdown label %q (valid: %s)", name, strings.Join(names, ", "))
+	}
+	*field = strings.TrimSpace(value)
+	return nil
+}
+
+// withDefaults returns the labels with every empty label set to its English default
+func (l MarkdownLabels) withDefaults() MarkdownLabels {
+	defaults := DefaultMarkdownLabels()
+	defaultFields := defaults.fields()
+	for name, field := range l.fields() {
+		if *field == "" {
+			*field = *defaultFields[name]
+		}
+	}
+	return l
+}
+
+// validate checks that labels can be written and parsed back unambiguously
+func (l MarkdownLabels) validate() error {
+	// Task sections are told apart by label, so they must be distinct
+	sections := map[string]string{}
+	for name, field := range l.fields() {
+		if strings.ContainsAny(*field, ":\r\n") || strings.HasPrefix(*field, "#") || strings.HasPrefix(*field, "-") {
+			return NewError(ErrInvalidInput, "invalid markdown label %s=%q: labels cannot contain ':' or newlines or start with '#' or '-'", name, *field)
+		}
+		switch name {
+		case "tags", "assignee", "blocked_reason", "dependencies", "done_criteria", "complexity", "choices", "subtasks":
+			if other, exists := sections[*field]; exists {
+				return NewError(ErrInvalidInput, "markdown labels %s and %s are both %q", other, name, *field)
+			}
+			sections[*field] = name
+		}
+	}
+	return nil
+}
+
+// ParseMarkdownLabels parses a "subtasks=Teilaufgaben,tags=Schlagworte" style
+// list of label overrides
+func ParseMarkdownLabels(value string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	labels := DefaultMarkdownLabels()
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid markdown label %q (expected subtasks=Subtasks)", pair)
+		}
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		if err := labels.Set(name, parts[1]); err != nil {
+			return nil, err
+		}
+		overrides[name] = strings.TrimSpace(parts[1])
+	}
+	return overrides, nil
+}
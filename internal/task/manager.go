@@ -1,35 +1,218 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
-// Manager handles task file operations and project management
+// Sentinel errors returned by GetNextTask
+var (
+	// ErrAllCompleted means every task and subtask in the project is done
+	ErrAllCompleted = errors.New("all tasks completed")
+	// ErrNoReadyTasks means incomplete tasks remain but all are waiting on dependencies
+	ErrNoReadyTasks = errors.New("no tasks ready: remaining tasks are waiting on dependencies")
+)
+
+// Manager handles task file operations and project management.
+// Each project file has its own lock, so operations on different projects
+// run concurrently while operations on the same project are serialized.
 type Manager struct {
 	tasksDir string
-	mutex    sync.RWMutex
+	config   ManagerConfig
+	// headerPattern matches task headers using the configured task label,
+	// compiled once rather than on every parse
+	headerPattern *regexp.Regexp
+	locks         map[string]*sync.RWMutex
+	locksMutex    sync.Mutex
+	// updateLocks serialize this manager's load-modify-save operations on a
+	// project, so they don't keep invalidating one another's loads
+	updateLocks map[string]*sync.Mutex
+
+	saveListeners  []func(project Project)
+	listenersMutex sync.RWMutex
+
+	// cache holds parsed projects by name, reused while their file is unchanged
+	cache      map[string]cachedProject
+	cacheStats CacheStats
+	cacheMutex sync.Mutex
+}
+
+// DefaultMaxFileSize is the largest project file LoadProject will read (10MB)
+const DefaultMaxFileSize int64 = 10 * 1024 * 1024
+
+// namespaceSeparator joins a namespace and a project name in file names.
+// Sanitized project names never contain it, so the split is unambiguous.
+const namespaceSeparator = "__"
+
+// ManagerConfig holds tunable limits for a Manager
+type ManagerConfig struct {
+	// MaxFileSize is the largest project file, in bytes, that will be loaded
+	MaxFileSize int64
+
+	// Namespace, when set, prefixes every project file name (e.g. teamA__project.md)
+	// so servers for different teams can share one tasks directory
+	Namespace string
+
+	// HeadingLevel is the markdown heading level of task headers (2-5, default 2).
+	// Task sections use the next level down, so files can be embedded in larger documents.
+	HeadingLevel int
+
+	// Labels are the headings and field markers written to and parsed from
+	// project files. Empty labels fall back to DefaultMarkdownLabels.
+	Labels MarkdownLabels
+
+	// LockTimeout is how long a save waits for another process holding the
+	// project file's lock (default DefaultLockTimeout)
+	LockTimeout time.Duration
+
+	// HistoryLimit is how many previous versions of each project file are kept
+	// for undo (default DefaultHistoryLimit); a negative limit keeps none
+	HistoryLimit int
+}
+
+// DefaultHeadingLevel renders tasks as "## Task N:"
+const DefaultHeadingLevel = 2
+
+// DefaultManagerConfig returns the default manager configuration
+func DefaultManagerConfig() ManagerConfig {
+	return ManagerConfig{
+		MaxFileSize:  DefaultMaxFileSize,
+		HeadingLevel: DefaultHeadingLevel,
+		Labels:       DefaultMarkdownLabels(),
+		LockTimeout:  DefaultLockTimeout,
+		HistoryLimit: DefaultHistoryLimit,
+	}
 }
 
 // NewManager creates a new task manager
 func NewManager(tasksDir string) (*Manager, error) {
+	return NewManagerWithConfig(tasksDir, DefaultManagerConfig())
+}
+
+// NewManagerWithConfig creates a new task manager with custom limits.
+// Zero values in config fall back to the defaults.
+func NewManagerWithConfig(tasksDir string, config ManagerConfig) (*Manager, error) {
 	// Create tasks directory if it doesn't exist
 	if err := os.MkdirAll(tasksDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create tasks directory: %w", err)
 	}
 
-	return &Manager{
-		tasksDir: tasksDir,
-	}, nil
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = DefaultMaxFileSize
+	}
+
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = DefaultLockTimeout
+	}
+
+	if config.HistoryLimit == 0 {
+		config.HistoryLimit = DefaultHistoryLimit
+	}
+
+	if config.HeadingLevel == 0 {
+		config.HeadingLevel = DefaultHeadingLevel
+	}
+	if config.HeadingLevel < 2 || config.HeadingLevel > 5 {
+		return nil, NewError(ErrInvalidInput, "invalid heading level %d: must be between 2 and 5", config.HeadingLevel)
+	}
+
+	config.Labels = config.Labels.withDefaults()
+	if err := config.Labels.validate(); err != nil {
+		return nil, err
+	}
+
+	if config.Namespace != "" && SanitizeProjectName(config.Namespace) != config.Namespace {
+		return nil, NewError(ErrInvalidInput, "invalid namespace %q: use letters, digits, '-', '.' or single underscores", config.Namespace)
+	}
+
+	manager := &Manager{
+		tasksDir:      tasksDir,
+		config:        config,
+		headerPattern: compileTaskHeaderPattern(config.Labels.Task),
+		locks:         make(map[string]*sync.RWMutex),
+		updateLocks:   make(map[string]*sync.Mutex),
+		cache:         make(map[string]cachedProject),
+	}
+	if err := manager.Validate(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// Validate checks that the tasks directory is a directory the manager can
+// write to, by creating and removing a temporary file in it
+func (m *Manager) Validate() error {
+	stat, err := os.Stat(m.tasksDir)
+	if err != nil {
+		return fmt.Errorf("tasks directory %s is not accessible: %w", m.tasksDir, err)
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("tasks directory %s is not a directory", m.tasksDir)
+	}
+
+	probe, err := os.CreateTemp(m.tasksDir, ".write-check-*.tmp")
+	if err != nil {
+		return fmt.Errorf("tasks directory %s is not writable: %w", m.tasksDir, err)
+	}
+	probePath := probe.Name()
+	closeErr := probe.Close()
+	if err := os.Remove(probePath); err != nil {
+		return fmt.Errorf("tasks directory %s does not allow removing files: %w", m.tasksDir, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("tasks directory %s is not writable: %w", m.tasksDir, closeErr)
+	}
+
+	return nil
+}
+
+// projectLock returns the lock guarding a project's file, creating it on first use.
+// Locks are keyed by file path so names that sanitize to the same file share a lock.
+func (m *Manager) projectLock(projectName string) *sync.RWMutex {
+	key := m.GetTaskFilePath(projectName)
+
+	m.locksMutex.Lock()
+	defer m.locksMutex.Unlock()
+
+	lock, exists := m.locks[key]
+	if !exists {
+		lock = &sync.RWMutex{}
+		m.locks[key] = lock
+	}
+	return lock
+}
+
+// updateLock returns the lock held across a load-modify-save of a project,
+// creating it on first use. Like projectLock it is keyed by file path.
+func (m *Manager) updateLock(projectName string) *sync.Mutex {
+	key := m.GetTaskFilePath(projectName)
+
+	m.locksMutex.Lock()
+	defer m.locksMutex.Unlock()
+
+	lock, exists := m.updateLocks[key]
+	if !exists {
+		lock = &sync.Mutex{}
+		m.updateLocks[key] = lock
+	}
+	return lock
 }
 
 // GetTaskFilePath returns the path to a project's task file
 func (m *Manager) GetTaskFilePath(projectName string) string {
 	sanitizedName := SanitizeProjectName(projectName)
+	if m.config.Namespace != "" {
+		sanitizedName = m.config.Namespace + namespaceSeparator + sanitizedName
+	}
 	return filepath.Join(m.tasksDir, sanitizedName+".md")
 }
 
@@ -40,8 +223,9 @@ func (m *Manager) GetTasksDir() string {
 
 // ProjectExists checks if a project file exists
 func (m *Manager) ProjectExists(projectName string) bool {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	lock := m.projectLock(projectName)
+	lock.RLock()
+	defer lock.RUnlock()
 
 	filePath := m.GetTaskFilePath(projectName)
 	_, err := os.Stat(filePath)
@@ -54,29 +238,36 @@ func (m *Manager) CreateProject(projectName string) error {
 		return err
 	}
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
 
 	filePath := m.GetTaskFilePath(projectName)
 
+	unlock, err := m.lockProjectFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Check if file already exists
 	if _, err := os.Stat(filePath); err == nil {
-		return fmt.Errorf("project file already exists: %s", filePath)
+		return NewError(ErrConflict, "project file already exists: %s", filePath)
 	}
 
 	// Create initial project structure
 	project := Project{
 		Name:      projectName,
 		Tasks:     []Task{},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
 	}
 
 	// Generate initial markdown content
 	content := m.generateMarkdown(project)
 
 	// Write to file
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	if err := writeFileAtomic(filePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to create project file: %w", err)
 	}
 
@@ -85,14 +276,31 @@ func (m *Manager) CreateProject(projectName string) error {
 
 // LoadProject loads a project from its markdown file
 func (m *Manager) LoadProject(projectName string) (*Project, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	lock := m.projectLock(projectName)
+	lock.RLock()
+	defer lock.RUnlock()
 
 	filePath := m.GetTaskFilePath(projectName)
 
 	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("project file not found: %s", projectName)
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil, NewError(ErrNotFound, "project file not found: %s", projectName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat project file: %w", err)
+	}
+
+	// Refuse to read files too large to be a real project
+	if info.Size() > m.config.MaxFileSize {
+		return nil, NewError(ErrInvalidInput, "project file %s is %d bytes, which exceeds the maximum of %d bytes",
+			projectName, info.Size(), m.config.MaxFileSize)
+	}
+
+	// Reuse the parsed project while the file is unchanged
+	version := fileVersion{modTime: info.ModTime(), size: info.Size()}
+	if project := m.cachedProjectVersion(projectName, version); project != nil {
+		return project, nil
 	}
 
 	// Read file content
@@ -101,170 +309,561 @@ func (m *Manager) LoadProject(projectName string) (*Project, error) {
 		return nil, fmt.Errorf("failed to read project file: %w", err)
 	}
 
-	// Parse markdown content
-	project, err := m.parseMarkdown(string(content))
+	project, err := m.parseProjectFile(projectName, string(content), version)
+	if err != nil {
+		return nil, err
+	}
+	m.storeCachedProject(projectName, project, version)
+	return project, nil
+}
+
+// parseProjectFile parses the content of a project file read at the given version
+func (m *Manager) parseProjectFile(projectName string, content string, version fileVersion) (*Project, error) {
+	project, err := m.parseMarkdown(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse project file: %w", err)
 	}
 
 	project.Name = projectName
+	// The file's modification time is when the project was last saved
+	project.UpdatedAt = version.modTime.UTC()
+	project.loadedVersion = version
 	return project, nil
 }
 
-// SaveProject saves a project to its markdown file
+// OnProjectSaved registers a listener called after every successful SaveProject.
+// Listeners run synchronously on the saving goroutine, after the project lock
+// is released, and must not retain the project.
+func (m *Manager) OnProjectSaved(listener func(project Project)) {
+	m.listenersMutex.Lock()
+	defer m.listenersMutex.Unlock()
+	m.saveListeners = append(m.saveListeners, listener)
+}
+
+// SaveProject saves a project to its markdown file. It fails with ErrConflict,
+// without retrying, when the file changed after the project was loaded; use
+// UpdateProject to have the change applied again to the current file.
 func (m *Manager) SaveProject(project *Project) error {
+	if err := m.writeProject(project); err != nil {
+		return err
+	}
+
+	m.notifySaved(project)
+	return nil
+}
+
+// notifySaved calls the save listeners for a project written to disk
+func (m *Manager) notifySaved(project *Project) {
+	m.listenersMutex.RLock()
+	listeners := m.saveListeners
+	m.listenersMutex.RUnlock()
+
+	for _, listener := range listeners {
+		listener(*project)
+	}
+}
+
+// writeProject writes a project to its markdown file under the project lock
+func (m *Manager) writeProject(project *Project) error {
 	if err := ValidateProjectName(project.Name); err != nil {
 		return err
 	}
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	lock := m.projectLock(project.Name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	filePath := m.GetTaskFilePath(project.Name)
+
+	// Hold the file lock across the version check and the write, so a server
+	// in another process can't save in between
+	unlock, err := m.lockProjectFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Refuse to overwrite edits made to the file after this project was loaded
+	// (by an editor, another process, or another call); the caller should reload and retry
+	if project.loadedVersion != (fileVersion{}) {
+		if info, err := os.Stat(filePath); err == nil {
+			if !info.ModTime().Equal(project.loadedVersion.modTime) || info.Size() != project.loadedVersion.size {
+				return &kindError{kind: ErrConflict, err: &staleProjectError{project: project.Name}}
+			}
+		}
+	}
+
+	// Keep the content being replaced so the change can be undone
+	if previous, err := os.ReadFile(filePath); err == nil {
+		if err := m.recordHistory(project.Name, previous); err != nil {
+			return err
+		}
+	}
 
-	project.UpdatedAt = time.Now()
+	project.UpdatedAt = time.Now().UTC()
 
 	// Generate markdown content
 	content := m.generateMarkdown(*project)
 
-	// Write to file
-	filePath := m.GetTaskFilePath(project.Name)
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	// Write to a temporary file and rename it over the original
+	if err := writeFileAtomic(filePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to save project file: %w", err)
 	}
 
+	// Later saves of the same in-memory project compare against this write.
+	// The cache is refreshed from the written content rather than the project,
+	// so it holds exactly what a fresh load would return.
+	m.InvalidateCache(project.Name)
+	if info, err := os.Stat(filePath); err == nil {
+		project.loadedVersion = fileVersion{modTime: info.ModTime(), size: info.Size()}
+		if saved, err := m.parseProjectFile(project.Name, content, project.loadedVersion); err == nil {
+			m.storeCachedProject(project.Name, saved, project.loadedVersion)
+		}
+	}
+
 	return nil
 }
 
-// AddTask adds a new task to a project
+// staleProjectError reports a save of a project whose file changed after it was loaded
+type staleProjectError struct {
+	project string
+}
+
+func (e *staleProjectError) Error() string {
+	return fmt.Sprintf("project file %s was modified after it was loaded; reload the project and retry", e.project)
+}
+
+// AddTask adds a new task to a project. The description may be empty (for
+// example for tasks created by a parser from a bare heading); validating user
+// input is left to callers such as the add_task tool.
 func (m *Manager) AddTask(projectName string, task Task) error {
-	project, err := m.LoadProject(projectName)
+	return m.retryStaleSave(projectName, func() error {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+
+		prepareNewTask(&task, nextTaskID(project))
+		project.Tasks = append(project.Tasks, task)
+
+		return m.SaveProject(project)
+	})
+}
+
+// AddTasks adds several tasks to a project with a single load and save,
+// assigning them sequential IDs. The whole batch is rejected when a title
+// repeats within it or matches an existing task. On success the assigned
+// IDs and defaults are written back to tasks.
+func (m *Manager) AddTasks(projectName string, tasks []Task) error {
+	titles := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		title := strings.TrimSpace(t.Title)
+		if titles[title] {
+			return NewError(ErrConflict, "task title '%s' appears more than once in the batch", title)
+		}
+		titles[title] = true
+	}
+
+	added := make([]Task, len(tasks))
+	err := m.retryStaleSave(projectName, func() error {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+
+		for _, existing := range project.Tasks {
+			if titles[existing.Title] {
+				return NewError(ErrConflict, "task with title '%s' already exists", existing.Title)
+			}
+		}
+
+		id := nextTaskID(project)
+		for i, t := range tasks {
+			prepareNewTask(&t, id+i)
+			added[i] = t
+		}
+		project.Tasks = append(project.Tasks, added...)
+
+		return m.SaveProject(project)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Set task ID (simple incrementing ID)
+	copy(tasks, added)
+	return nil
+}
+
+// maxSaveAttempts bounds how often an update is retried after concurrent saves
+const maxSaveAttempts = 10
+
+// retryStaleSave runs a load-modify-save operation on a project. Operations
+// from this manager run one at a time per project; one is run again on a
+// freshly loaded project when another process or an editor saved the project
+// between its load and save.
+func (m *Manager) retryStaleSave(projectName string, operation func() error) error {
+	lock := m.updateLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return retryStale(operation)
+}
+
+// retryStale runs operation again, up to maxSaveAttempts times, while it fails
+// because a project it loaded was saved before it could save its changes
+func retryStale(operation func() error) error {
+	var err error
+	for attempt := 0; attempt < maxSaveAttempts; attempt++ {
+		err = operation()
+		var stale *staleProjectError
+		if !errors.As(err, &stale) {
+			return err
+		}
+	}
+	return err
+}
+
+// UpdateProject loads a project, applies modify to it and saves the result.
+// Updates from this manager are serialized; when another process saves the
+// project in between, it is reloaded and modify runs again, so modify must
+// only act on the project it is given. An error from modify is returned
+// without saving, except SkipSave which leaves the project unsaved and
+// returns nil.
+func (m *Manager) UpdateProject(projectName string, modify func(project *Project) error) error {
+	return m.retryStaleSave(projectName, func() error {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+		if err := modify(project); err != nil {
+			if errors.Is(err, SkipSave) {
+				return nil
+			}
+			return err
+		}
+		return m.SaveProject(project)
+	})
+}
+
+// SkipSave is returned by an UpdateProject modify function that changed
+// nothing, so the project isn't written
+var SkipSave = errors.New("skip save")
+
+// nextTaskID returns the ID following the highest task ID in a project
+func nextTaskID(project *Project) int {
 	maxID := 0
 	for _, existingTask := range project.Tasks {
 		if existingTask.ID > maxID {
 			maxID = existingTask.ID
 		}
 	}
-	task.ID = maxID + 1
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
+	return maxID + 1
+}
 
-	// Set defaults if not provided
+// prepareNewTask assigns a new task its ID and timestamps, trims its titles
+// and fills in the default status and priority when they aren't set
+func prepareNewTask(task *Task, id int) {
+	now := time.Now().UTC()
+	task.ID = id
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	task.Title = strings.TrimSpace(task.Title)
+	task.Description = strings.TrimSpace(task.Description)
+	for i := range task.Subtasks {
+		task.Subtasks[i].Title = strings.TrimSpace(task.Subtasks[i].Title)
+		// Without stored times, subtasks would get the time of each fresh load
+		if task.Subtasks[i].CreatedAt.IsZero() {
+			task.Subtasks[i].CreatedAt = now
+		}
+		if task.Subtasks[i].UpdatedAt.IsZero() {
+			task.Subtasks[i].UpdatedAt = now
+		}
+	}
 	if task.Status == "" {
 		task.Status = DefaultTaskStatus()
 	}
 	if task.Priority == "" {
 		task.Priority = DefaultTaskPriority()
 	}
+}
+
+// AppendTask adds a task to the end of a project file without parsing the
+// whole project, for fast bulk additions. Only task headers are scanned, to
+// assign the next ID and reject duplicate titles. The overview and progress
+// summary at the top of the file aren't refreshed until the project is next
+// saved in full, and save listeners aren't notified. Returns the new task's ID.
+func (m *Manager) AppendTask(projectName string, task Task) (int, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return 0, err
+	}
+
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	filePath := m.GetTaskFilePath(projectName)
+	unlock, err := m.lockProjectFile(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return 0, NewError(ErrNotFound, "project file not found: %s", projectName)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat project file: %w", err)
+	}
+	if info.Size() > m.config.MaxFileSize {
+		return 0, NewError(ErrInvalidInput, "project file %s is %d bytes, which exceeds the maximum of %d bytes",
+			projectName, info.Size(), m.config.MaxFileSize)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read project file: %w", err)
+	}
 
-	// Add task to project
-	project.Tasks = append(project.Tasks, task)
+	task.Title = strings.TrimSpace(task.Title)
+	task.Description = strings.TrimSpace(task.Description)
 
-	// Save project
-	return m.SaveProject(project)
+	maxID := 0
+	headerPattern := m.taskHeaderPattern()
+	for _, line := range strings.Split(string(content), "\n") {
+		match := headerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		if id, err := strconv.Atoi(match[1]); err == nil && id > maxID {
+			maxID = id
+		}
+		if strings.TrimSpace(match[3]) == task.Title {
+			return 0, NewError(ErrConflict, "task with title '%s' already exists", task.Title)
+		}
+	}
+
+	prepareNewTask(&task, maxID+1)
+
+	var appended strings.Builder
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		appended.WriteString("\n")
+	}
+	appended.WriteString(m.generateTaskMarkdown(task))
+	appended.WriteString("\n---\n\n")
+
+	if err := m.recordHistory(projectName, content); err != nil {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open project file: %w", err)
+	}
+	if _, err := file.WriteString(appended.String()); err != nil {
+		file.Close()
+		return 0, fmt.Errorf("failed to append task: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to append task: %w", err)
+	}
+	m.InvalidateCache(projectName)
+
+	return task.ID, nil
 }
 
-// UpdateTaskStatus updates the status of a task or subtask
+// UpdateTaskStatus updates the status of a task or subtask, with the side
+// effects described at Task.ApplyStatus
 func (m *Manager) UpdateTaskStatus(projectName string, taskTitle string, subtaskTitle string, status TaskStatus) error {
-	project, err := m.LoadProject(projectName)
+	return m.UpdateProject(projectName, func(project *Project) error {
+		for i := range project.Tasks {
+			if project.Tasks[i].Title == taskTitle {
+				_, err := project.Tasks[i].ApplyStatus(subtaskTitle, status, false)
+				return err
+			}
+		}
+		return NewError(ErrNotFound, "task not found: %s", taskTitle)
+	})
+}
+
+// RenameTask changes a task's title. Dependencies refer to tasks by ID, so
+// they keep pointing at the renamed task; free text that mentions the old
+// title, such as descriptions and choices, is left as written.
+func (m *Manager) RenameTask(projectName string, oldTitle string, newTitle string) error {
+	newTitle, err := ValidateTaskTitle(newTitle)
 	if err != nil {
 		return err
 	}
 
-	// Find the task
-	taskFound := false
-	for i := range project.Tasks {
-		if project.Tasks[i].Title == taskTitle {
-			taskFound = true
-
-			if subtaskTitle == "" {
-				// Update main task status
-				if status == StatusDone {
-					// When marking a task as done, check if we should auto-complete subtasks
-					if len(project.Tasks[i].Subtasks) > 0 {
-						// Auto-complete all subtasks when main task is marked done
-						for j := range project.Tasks[i].Subtasks {
-							if project.Tasks[i].Subtasks[j].Status != StatusDone {
-								project.Tasks[i].Subtasks[j].Status = StatusDone
-								project.Tasks[i].Subtasks[j].UpdatedAt = time.Now()
-							}
-						}
-					}
-				}
-				project.Tasks[i].Status = status
-				project.Tasks[i].UpdatedAt = time.Now()
-			} else {
-				// Update subtask status
-				subtaskFound := false
-				for j := range project.Tasks[i].Subtasks {
-					if project.Tasks[i].Subtasks[j].Title == subtaskTitle {
-						project.Tasks[i].Subtasks[j].Status = status
-						project.Tasks[i].Subtasks[j].UpdatedAt = time.Now()
-						project.Tasks[i].UpdatedAt = time.Now()
-
-						// If this was the last subtask to be completed, check if main task should be auto-completed
-						if status == StatusDone && project.Tasks[i].Status != StatusDone {
-							if project.Tasks[i].CanBeMarkedComplete() {
-								project.Tasks[i].Status = StatusDone
-								project.Tasks[i].UpdatedAt = time.Now()
-							}
-						}
-
-						subtaskFound = true
-						break
-					}
-				}
-				if !subtaskFound {
-					return fmt.Errorf("subtask not found: %s", subtaskTitle)
-				}
+	return m.retryStaleSave(projectName, func() error {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+
+		target, _, err := ResolveTaskTitle(project, oldTitle, false)
+		if err != nil {
+			return err
+		}
+		if target.Title == newTitle {
+			return nil
+		}
+
+		for _, existingTask := range project.Tasks {
+			if existingTask.Title == newTitle {
+				return NewError(ErrConflict, "task with title '%s' already exists", newTitle)
 			}
-			break
 		}
+
+		target.Title = newTitle
+		target.UpdatedAt = time.Now().UTC()
+
+		return m.SaveProject(project)
+	})
+}
+
+// NormalizeTitles finds task and subtask titles that were written to the
+// project file with extra whitespace around them, as happens with hand
+// edits, and unless dryRun is set rewrites the file without it. Parsing
+// already trims titles, so the padding only exists on disk.
+func (m *Manager) NormalizeTitles(projectName string, dryRun bool) ([]TitleChange, error) {
+	var changes []TitleChange
+	err := m.UpdateProject(projectName, func(project *Project) error {
+		content, err := os.ReadFile(m.GetTaskFilePath(projectName))
+		if err != nil {
+			return fmt.Errorf("failed to read project file: %w", err)
+		}
+		changes = m.paddedTitles(string(content))
+		if dryRun || len(changes) == 0 {
+			return SkipSave
+		}
+		// Saving regenerates the file from the parsed, trimmed titles
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return changes, nil
+}
 
-	if !taskFound {
-		return fmt.Errorf("task not found: %s", taskTitle)
+// SetProjectDescription replaces a project's description; an empty description
+// removes it
+func (m *Manager) SetProjectDescription(projectName string, description string) error {
+	description, err := ValidateProjectDescription(description)
+	if err != nil {
+		return err
 	}
 
-	// Save project
-	return m.SaveProject(project)
+	return m.UpdateProject(projectName, func(project *Project) error {
+		project.Description = description
+		return nil
+	})
+}
+
+// ReorderTasks puts a project's tasks in the given order of task IDs, which
+// must list every task exactly once. IDs and dependencies are unchanged; the
+// order is the one tasks are written in and get_next_task follows.
+func (m *Manager) ReorderTasks(projectName string, order []int) error {
+	return m.UpdateProject(projectName, func(project *Project) error {
+		if len(order) != len(project.Tasks) {
+			return NewError(ErrInvalidInput, "order lists %d tasks but project '%s' has %d", len(order), projectName, len(project.Tasks))
+		}
+
+		byID := make(map[int]Task, len(project.Tasks))
+		for _, t := range project.Tasks {
+			byID[t.ID] = t
+		}
+
+		reordered := make([]Task, 0, len(order))
+		seen := make(map[int]bool, len(order))
+		for _, id := range order {
+			t, ok := byID[id]
+			if !ok {
+				return NewError(ErrNotFound, "task %d not found in project '%s'", id, projectName)
+			}
+			if seen[id] {
+				return NewError(ErrInvalidInput, "task %d is listed more than once", id)
+			}
+			seen[id] = true
+			reordered = append(reordered, t)
+		}
+
+		project.Tasks = reordered
+		return nil
+	})
 }
 
-// GetNextTask returns the next uncompleted task
+// GetNextTask returns the next uncompleted task whose dependencies are all done.
+// It returns ErrAllCompleted when nothing is left to do and ErrNoReadyTasks when
+// incomplete tasks remain but all of them are waiting on dependencies.
 func (m *Manager) GetNextTask(projectName string) (*Task, *Subtask, error) {
 	project, err := m.LoadProject(projectName)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Find first incomplete task/subtask
+	statusByID := make(map[int]TaskStatus, len(project.Tasks))
 	for _, task := range project.Tasks {
+		statusByID[task.ID] = task.Status
+	}
+
+	// Find first incomplete task/subtask
+	waitingOnDependencies := false
+	// Index into the slices so the returned pointers refer to the project's own tasks
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
 		// Use IsFullyCompleted to check both task and subtask completion
 		if !task.IsFullyCompleted() {
+			if !dependenciesDone(task, statusByID) {
+				waitingOnDependencies = true
+				continue
+			}
+
 			// Check for incomplete subtasks first
-			for _, subtask := range task.Subtasks {
-				if subtask.Status != StatusDone {
-					return &task, &subtask, nil
+			for j := range task.Subtasks {
+				if task.Subtasks[j].Status != StatusDone {
+					return task, &task.Subtasks[j], nil
 				}
 			}
 			// If no incomplete subtasks but task isn't done, return the main task
 			if task.Status != StatusDone {
-				return &task, nil, nil
+				return task, nil, nil
 			}
 		}
 	}
 
-	return nil, nil, fmt.Errorf("all tasks completed")
+	if waitingOnDependencies {
+		return nil, nil, ErrNoReadyTasks
+	}
+	return nil, nil, ErrAllCompleted
 }
 
-// ListProjects returns a list of all project names
-func (m *Manager) ListProjects() ([]string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+// dependenciesDone reports whether every known dependency of a task is done.
+// Dependencies on task IDs that no longer exist are ignored.
+func dependenciesDone(task *Task, statusByID map[int]TaskStatus) bool {
+	for _, depID := range task.Dependencies {
+		if status, exists := statusByID[depID]; exists && status != StatusDone {
+			return false
+		}
+	}
+	return true
+}
 
+// FilterTasks returns the tasks of a project matching the filter; nil filter
+// fields don't restrict the result, so an empty filter returns every task
+func (m *Manager) FilterTasks(projectName string, filter TaskFilter) ([]Task, error) {
+	project, err := m.LoadProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return project.FilterTasks(filter), nil
+}
+
+// ListProjects returns a sorted list of all project names.
+// Subdirectories (archives, history, templates) and hidden files are skipped
+// so they never show up as projects.
+func (m *Manager) ListProjects() ([]string, error) {
 	files, err := os.ReadDir(m.tasksDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
@@ -272,11 +871,88 @@ func (m *Manager) ListProjects() ([]string, error) {
 
 	var projects []string
 	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".md" {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		if filepath.Ext(file.Name()) == ".md" {
 			name := strings.TrimSuffix(file.Name(), ".md")
+
+			// Only list this namespace's projects, without the prefix
+			if m.config.Namespace != "" {
+				var inNamespace bool
+				name, inNamespace = strings.CutPrefix(name, m.config.Namespace+namespaceSeparator)
+				if !inNamespace {
+					continue
+				}
+			}
+
 			projects = append(projects, name)
 		}
 	}
 
+	sort.Strings(projects)
 	return projects, nil
 }
+
+// ListNonEmptyProjects lists the projects that have at least one task, leaving
+// out placeholder projects that were created but never filled. A project that
+// fails to load is kept, as ListProjects would list it: only projects known to
+// have no tasks are left out.
+func (m *Manager) ListNonEmptyProjects() ([]string, error) {
+	projects, err := m.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	nonEmpty := []string{}
+	for _, name := range projects {
+		if project, err := m.LoadProject(name); err == nil && len(project.Tasks) == 0 {
+			continue
+		}
+		nonEmpty = append(nonEmpty, name)
+	}
+	return nonEmpty, nil
+}
+
+// RecentActivity returns tasks and subtasks across the given projects ordered by
+// UpdatedAt, most recently touched first. A limit of zero or less returns everything.
+func (m *Manager) RecentActivity(projectNames []string, limit int) ([]ActivityItem, error) {
+	var items []ActivityItem
+
+	for _, projectName := range projectNames {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range project.Tasks {
+			items = append(items, ActivityItem{
+				ProjectName: projectName,
+				TaskID:      task.ID,
+				TaskTitle:   task.Title,
+				Status:      task.Status,
+				UpdatedAt:   task.UpdatedAt,
+			})
+			for _, subtask := range task.Subtasks {
+				items = append(items, ActivityItem{
+					ProjectName:  projectName,
+					TaskID:       task.ID,
+					TaskTitle:    task.Title,
+					SubtaskTitle: subtask.Title,
+					Status:       subtask.Status,
+					UpdatedAt:    subtask.UpdatedAt,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].UpdatedAt.After(items[j].UpdatedAt)
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, nil
+}
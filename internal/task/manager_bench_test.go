@@ -0,0 +1,111 @@
+package task
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// BenchmarkUpdateTaskStatusContended measures status updates to different
+// tasks of one project from concurrent callers, which retry their
+// load-modify-save whenever another caller saved first
+func BenchmarkUpdateTaskStatusContended(b *testing.B) {
+	const tasks = 50
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	newTestProject(b, m, "p", tasks)
+
+	var next atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := next.Add(1)
+			status := StatusInProgress
+			if i%2 == 0 {
+				status = StatusTodo
+			}
+			if err := m.UpdateTaskStatus("p", fmt.Sprintf("Task %d", i%tasks+1), "", status); err != nil {
+				b.Errorf("UpdateTaskStatus: %v", err)
+			}
+		}
+	})
+}
+
+// saveBenchProject writes benchProject(n) as a new project and returns its name
+func saveBenchProject(b *testing.B, m *Manager, n int) string {
+	b.Helper()
+	name := fmt.Sprintf("bench-%d", n)
+	project := benchProject(n)
+	project.Name = name
+	if err := m.SaveProject(&project); err != nil {
+		b.Fatalf("SaveProject: %v", err)
+	}
+	return name
+}
+
+// BenchmarkLoadProject measures loading a project from disk. The cache is
+// dropped before every load so each one reads and parses the file.
+func BenchmarkLoadProject(b *testing.B) {
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	for _, n := range benchSizes {
+		name := saveBenchProject(b, m, n)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.InvalidateCache(name)
+				if _, err := m.LoadProject(name); err != nil {
+					b.Fatalf("LoadProject: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLoadProjectCached measures loads of an unchanged project, which
+// are served from the cache
+func BenchmarkLoadProjectCached(b *testing.B) {
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	for _, n := range benchSizes {
+		name := saveBenchProject(b, m, n)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.LoadProject(name); err != nil {
+					b.Fatalf("LoadProject: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSaveProject measures saving a loaded project, which generates the
+// markdown, writes it atomically and parses it back into the cache
+func BenchmarkSaveProject(b *testing.B) {
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	for _, n := range benchSizes {
+		name := saveBenchProject(b, m, n)
+		project, err := m.LoadProject(name)
+		if err != nil {
+			b.Fatalf("LoadProject: %v", err)
+		}
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := m.SaveProject(project); err != nil {
+					b.Fatalf("SaveProject: %v", err)
+				}
+			}
+		})
+	}
+}
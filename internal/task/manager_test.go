@@ -0,0 +1,815 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestProject creates a project holding n tasks titled "Task 1" to "Task n"
+func newTestProject(t testing.TB, m *Manager, name string, n int) {
+	t.Helper()
+	if err := m.CreateProject(name); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	tasks := make([]Task, n)
+	for i := range tasks {
+		tasks[i] = Task{
+			Title:       fmt.Sprintf("Task %d", i+1),
+			Description: "Do the work",
+			Subtasks:    []Subtask{{Title: "Step", Status: StatusTodo}},
+		}
+	}
+	if err := m.AddTasks(name, tasks); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+}
+
+func TestUpdateTaskStatusConcurrent(t *testing.T) {
+	const n = 20
+	m := newTestManager(t)
+	newTestProject(t, m, "p", n)
+
+	// Each call loads and saves the whole project, so without retrying stale
+	// saves most of them would fail or overwrite one another
+	var wg sync.WaitGroup
+	for i := 1; i <= n; i++ {
+		wg.Add(1)
+		go func(title string) {
+			defer wg.Done()
+			if err := m.UpdateTaskStatus("p", title, "", StatusDone); err != nil {
+				t.Errorf("UpdateTaskStatus(%s): %v", title, err)
+			}
+		}(fmt.Sprintf("Task %d", i))
+	}
+	wg.Wait()
+
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	for _, task := range project.Tasks {
+		if task.Status != StatusDone {
+			t.Errorf("task %q is %s, want done", task.Title, task.Status)
+		}
+	}
+}
+
+func TestAddTaskConcurrent(t *testing.T) {
+	const n = 20
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= n; i++ {
+		wg.Add(1)
+		go func(title string) {
+			defer wg.Done()
+			if err := m.AddTask("p", Task{Title: title, Description: "Do the work"}); err != nil {
+				t.Errorf("AddTask(%s): %v", title, err)
+			}
+		}(fmt.Sprintf("Task %d", i))
+	}
+	wg.Wait()
+
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if len(project.Tasks) != n {
+		t.Errorf("project has %d tasks, want %d", len(project.Tasks), n)
+	}
+	titles := make(map[string]bool)
+	ids := make(map[int]bool)
+	for _, task := range project.Tasks {
+		titles[task.Title] = true
+		ids[task.ID] = true
+	}
+	for i := 1; i <= n; i++ {
+		if title := fmt.Sprintf("Task %d", i); !titles[title] {
+			t.Errorf("%s is missing", title)
+		}
+	}
+	if len(ids) != len(project.Tasks) {
+		t.Errorf("tasks share IDs: %d distinct IDs for %d tasks", len(ids), len(project.Tasks))
+	}
+}
+
+// editExternally changes a project's file on disk the way an editor or another
+// process would, replacing the first occurrence of from
+func editExternally(t *testing.T, m *Manager, projectName, from, to string) {
+	t.Helper()
+	path := m.GetTaskFilePath(projectName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	edited := strings.Replace(string(content), from, to, 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func TestUpdateProjectReappliesAfterExternalEdit(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 2)
+
+	attempts := 0
+	err := m.UpdateProject("p", func(project *Project) error {
+		attempts++
+		if attempts == 1 {
+			// Another process saves between this load and the save below
+			editExternally(t, m, "p", "Do the work", "Do the work, edited elsewhere")
+		}
+		project.Tasks[1].SetStatus(StatusDone)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("modify ran %d times, want 2", attempts)
+	}
+
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := project.Tasks[0].Description; got != "Do the work, edited elsewhere" {
+		t.Errorf("external edit lost: first task description is %q", got)
+	}
+	if got := project.Tasks[1].Status; got != StatusDone {
+		t.Errorf("update lost: second task is %s", got)
+	}
+}
+
+func TestSaveProjectRefusesStaleProject(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 1)
+
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	editExternally(t, m, "p", "Do the work", "Do the work, edited elsewhere")
+
+	// A plain save doesn't retry; it reports the conflict for the caller to reload
+	project.Tasks[0].SetStatus(StatusDone)
+	if err := m.SaveProject(project); !errors.Is(err, ErrConflict) {
+		t.Fatalf("SaveProject error = %v, want a conflict", err)
+	}
+}
+
+func TestUpdateProjectSkipSave(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 1)
+	before, err := os.Stat(m.GetTaskFilePath("p"))
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	err = m.UpdateProject("p", func(project *Project) error {
+		return SkipSave
+	})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+
+	after, err := os.Stat(m.GetTaskFilePath("p"))
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) {
+		t.Fatal("project was written despite SkipSave")
+	}
+}
+
+func TestMoveTaskConcurrentOppositeDirections(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "a", 5)
+	if err := m.CreateProject("b"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := m.AddTask("b", Task{Title: "From b", Description: "Do the work"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 5; i++ {
+			if _, err := m.MoveTask("a", "b", fmt.Sprintf("Task %d", i)); err != nil {
+				t.Errorf("MoveTask a->b: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := m.MoveTask("b", "a", "From b"); err != nil {
+			t.Errorf("MoveTask b->a: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	a, _ := m.LoadProject("a")
+	b, _ := m.LoadProject("b")
+	if len(a.Tasks) != 1 || len(b.Tasks) != 5 {
+		t.Fatalf("got %d tasks in a and %d in b, want 1 and 5", len(a.Tasks), len(b.Tasks))
+	}
+}
+
+func TestListNonEmptyProjects(t *testing.T) {
+	m, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{MaxFileSize: 4096})
+	if err != nil {
+		t.Fatalf("NewManagerWithConfig: %v", err)
+	}
+	newTestProject(t, m, "full", 1)
+	newTestProject(t, m, "broken", 1)
+	if err := m.CreateProject("empty"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	// Grow one project past the size limit so it no longer loads
+	editExternally(t, m, "broken", "Do the work", strings.Repeat("x", 4096))
+	for _, name := range []string{"full", "broken", "empty"} {
+		m.InvalidateCache(name)
+	}
+	if _, err := m.LoadProject("broken"); err == nil {
+		t.Fatal("LoadProject of the oversized project succeeded")
+	}
+
+	all, err := m.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	nonEmpty, err := m.ListNonEmptyProjects()
+	if err != nil {
+		t.Fatalf("ListNonEmptyProjects: %v", err)
+	}
+
+	if !slices.Equal(all, []string{"broken", "empty", "full"}) {
+		t.Errorf("ListProjects = %v, want every project", all)
+	}
+	// The freshly written empty project parses back without tasks and is left
+	// out; the broken one can't be shown to be empty, so it stays
+	if !slices.Equal(nonEmpty, []string{"broken", "full"}) {
+		t.Errorf("ListNonEmptyProjects = %v, want [broken full]", nonEmpty)
+	}
+}
+
+func TestRecentActivity(t *testing.T) {
+	m := newTestManager(t)
+	at := func(hour int) time.Time { return time.Date(2024, 5, 1, hour, 0, 0, 0, time.UTC) }
+	projects := []*Project{
+		{Name: "a", Tasks: []Task{
+			{ID: 1, Title: "Old", Description: "d", CreatedAt: at(1), UpdatedAt: at(1),
+				Subtasks: []Subtask{{Title: "Newest step", Status: StatusDone, CreatedAt: at(1), UpdatedAt: at(9)}}},
+		}},
+		{Name: "b", Tasks: []Task{
+			{ID: 1, Title: "Middle", Description: "d", CreatedAt: at(1), UpdatedAt: at(5)},
+			{ID: 2, Title: "Recent", Description: "d", CreatedAt: at(1), UpdatedAt: at(7)},
+		}},
+	}
+	for _, project := range projects {
+		if err := m.ImportProject(project, false); err != nil {
+			t.Fatalf("ImportProject(%s): %v", project.Name, err)
+		}
+	}
+
+	items, err := m.RecentActivity([]string{"a", "b"}, 3)
+	if err != nil {
+		t.Fatalf("RecentActivity: %v", err)
+	}
+	want := []ActivityItem{
+		{ProjectName: "a", TaskID: 1, TaskTitle: "Old", SubtaskTitle: "Newest step", Status: StatusDone, UpdatedAt: at(9)},
+		{ProjectName: "b", TaskID: 2, TaskTitle: "Recent", Status: StatusTodo, UpdatedAt: at(7)},
+		{ProjectName: "b", TaskID: 1, TaskTitle: "Middle", Status: StatusTodo, UpdatedAt: at(5)},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i := range want {
+		if got := items[i]; got.ProjectName != want[i].ProjectName || got.TaskID != want[i].TaskID ||
+			got.TaskTitle != want[i].TaskTitle || got.SubtaskTitle != want[i].SubtaskTitle ||
+			got.Status != want[i].Status || !got.UpdatedAt.Equal(want[i].UpdatedAt) {
+			t.Errorf("item %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	all, err := m.RecentActivity([]string{"a", "b"}, 0)
+	if err != nil {
+		t.Fatalf("RecentActivity: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("with no limit got %d items, want 4", len(all))
+	}
+}
+
+func TestListProjectsSortedAndSkipsNonProjects(t *testing.T) {
+	m := newTestManager(t)
+	for _, name := range []string{"zeta", "alpha", "Mid"} {
+		if err := m.CreateProject(name); err != nil {
+			t.Fatalf("CreateProject(%s): %v", name, err)
+		}
+	}
+	for _, name := range []string{".hidden.md", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(m.tasksDir, name), []byte("# x\n"), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(m.tasksDir, "sub.md"), 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	projects, err := m.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if want := []string{"Mid", "alpha", "zeta"}; !slices.Equal(projects, want) {
+		t.Errorf("ListProjects = %v, want %v", projects, want)
+	}
+}
+
+func TestListProjectsDuringConcurrentCreates(t *testing.T) {
+	const n = 10
+	m := newTestManager(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := m.CreateProject(fmt.Sprintf("p%02d", i)); err != nil {
+				t.Errorf("CreateProject: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			projects, err := m.ListProjects()
+			if err != nil {
+				t.Errorf("ListProjects: %v", err)
+			}
+			if !slices.IsSorted(projects) {
+				t.Errorf("ListProjects = %v, not sorted", projects)
+			}
+		}()
+	}
+	wg.Wait()
+
+	projects, err := m.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != n {
+		t.Errorf("ListProjects returned %d projects, want %d", len(projects), n)
+	}
+}
+
+func TestGetNextTaskAllCompletedVersusNoReadyTasks(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "done", 2)
+	for _, title := range []string{"Task 1", "Task 2"} {
+		if err := m.UpdateTaskStatus("done", title, "", StatusDone); err != nil {
+			t.Fatalf("UpdateTaskStatus: %v", err)
+		}
+	}
+	if _, _, err := m.GetNextTask("done"); !errors.Is(err, ErrAllCompleted) {
+		t.Errorf("all done: GetNextTask error = %v, want ErrAllCompleted", err)
+	}
+
+	// Each task waits on the other, so neither can start
+	newTestProject(t, m, "waiting", 2)
+	err := m.UpdateProject("waiting", func(project *Project) error {
+		project.Tasks[0].Dependencies = []int{project.Tasks[1].ID}
+		project.Tasks[1].Dependencies = []int{project.Tasks[0].ID}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if _, _, err := m.GetNextTask("waiting"); !errors.Is(err, ErrNoReadyTasks) {
+		t.Errorf("all waiting: GetNextTask error = %v, want ErrNoReadyTasks", err)
+	}
+}
+
+func TestGetNextTaskReturnsFirstIncompleteItem(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	err := m.AddTasks("p", []Task{
+		{Title: "Finished", Status: StatusDone, Subtasks: []Subtask{{Title: "Done step", Status: StatusDone}}},
+		{Title: "Underway", Status: StatusInProgress, Subtasks: []Subtask{
+			{Title: "First step", Status: StatusDone},
+			{Title: "Second step", Status: StatusTodo},
+			{Title: "Third step", Status: StatusInProgress},
+		}},
+		{Title: "Later", Subtasks: []Subtask{{Title: "Later step", Status: StatusTodo}}},
+		{Title: "Last"},
+	})
+	if err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+
+	next := func(wantTask, wantSubtask string) {
+		t.Helper()
+		task, subtask, err := m.GetNextTask("p")
+		if err != nil {
+			t.Fatalf("GetNextTask: %v", err)
+		}
+		gotSubtask := ""
+		if subtask != nil {
+			gotSubtask = subtask.Title
+			// The subtask must be the returned task's own element, not a copy
+			index := slices.IndexFunc(task.Subtasks, func(s Subtask) bool { return s.Title == subtask.Title })
+			if index < 0 || subtask != &task.Subtasks[index] {
+				t.Errorf("subtask %q is not an element of task %q", subtask.Title, task.Title)
+			}
+		}
+		if task.Title != wantTask || gotSubtask != wantSubtask {
+			t.Errorf("GetNextTask = %q / %q, want %q / %q", task.Title, gotSubtask, wantTask, wantSubtask)
+		}
+	}
+
+	next("Underway", "Second step")
+	for _, step := range []string{"Second step", "Third step"} {
+		if err := m.UpdateTaskStatus("p", "Underway", step, StatusDone); err != nil {
+			t.Fatalf("UpdateTaskStatus: %v", err)
+		}
+	}
+	next("Later", "Later step")
+	if err := m.UpdateTaskStatus("p", "Later", "Later step", StatusDone); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+	next("Last", "")
+}
+
+func TestLoadProjectMaxFileSize(t *testing.T) {
+	m, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{MaxFileSize: 4096})
+	if err != nil {
+		t.Fatalf("NewManagerWithConfig: %v", err)
+	}
+	newTestProject(t, m, "p", 2)
+	m.InvalidateCache("p")
+	if _, err := m.LoadProject("p"); err != nil {
+		t.Fatalf("LoadProject under the limit: %v", err)
+	}
+
+	editExternally(t, m, "p", "Do the work", strings.Repeat("x", 4096))
+	m.InvalidateCache("p")
+	if _, err := m.LoadProject("p"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("LoadProject over the limit = %v, want ErrInvalidInput", err)
+	}
+
+	// A manager without a limit falls back to the default, which the file is well under
+	unlimited, err := NewManagerWithConfig(m.tasksDir, ManagerConfig{})
+	if err != nil {
+		t.Fatalf("NewManagerWithConfig: %v", err)
+	}
+	if _, err := unlimited.LoadProject("p"); err != nil {
+		t.Errorf("LoadProject with the default limit: %v", err)
+	}
+}
+
+func TestRenumberTasksWithDuplicateAndGappedIDs(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{Title: "A"},
+		Task{Title: "B"},
+		Task{Title: "C", Dependencies: []int{2}},
+		Task{Title: "D", Dependencies: []int{5, 42}},
+	)
+	// A hand-edited file: A and B share ID 2, and the rest skip numbers
+	for i, id := range []int{2, 2, 5, 9} {
+		project.Tasks[i].ID = id
+	}
+	if err := os.WriteFile(m.GetTaskFilePath("p"), []byte(m.generateMarkdown(project)), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var changes []TaskIDChange
+	if err := m.UpdateProject("p", func(project *Project) error {
+		changes = project.RenumberTasks()
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	wantChanges := []TaskIDChange{{Title: "A", OldID: 2, NewID: 1}, {Title: "C", OldID: 5, NewID: 3}, {Title: "D", OldID: 9, NewID: 4}}
+	if !slices.Equal(changes, wantChanges) {
+		t.Errorf("changes = %+v, want %+v", changes, wantChanges)
+	}
+
+	m.InvalidateCache("p")
+	loaded, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	wantDeps := map[string][]int{"A": nil, "B": nil, "C": {1}, "D": {3}}
+	for i, tk := range loaded.Tasks {
+		if tk.ID != i+1 {
+			t.Errorf("task %q has ID %d, want %d", tk.Title, tk.ID, i+1)
+		}
+		if !slices.Equal(tk.Dependencies, wantDeps[tk.Title]) {
+			t.Errorf("task %q depends on %v, want %v", tk.Title, tk.Dependencies, wantDeps[tk.Title])
+		}
+	}
+}
+
+func TestAddTaskWithEmptyDescription(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	// Bare PRD bullets have no description
+	parsed := ParsePRD("- Set up CI\n  - Add lint job\n- Write README\n")
+	if len(parsed) != 2 || parsed[0].Description != "" {
+		t.Fatalf("ParsePRD = %+v, want two tasks without descriptions", parsed)
+	}
+	parsed[1].Description = "   "
+	for _, tk := range parsed {
+		if err := m.AddTask("p", tk); err != nil {
+			t.Fatalf("AddTask(%q): %v", tk.Title, err)
+		}
+	}
+
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if len(project.Tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(project.Tasks))
+	}
+	for _, tk := range project.Tasks {
+		if tk.Description != "" {
+			t.Errorf("task %q description = %q, want empty", tk.Title, tk.Description)
+		}
+	}
+	if subtasks := project.Tasks[0].Subtasks; len(subtasks) != 1 || subtasks[0].Title != "Add lint job" {
+		t.Errorf("subtasks after an empty description = %+v, want [Add lint job]", subtasks)
+	}
+}
+
+func TestNormalizeTitlesRewritesPaddedTitles(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 2)
+	editExternally(t, m, "p", " Task 1 (", "   Task 1\t (")
+	editExternally(t, m, "p", "- [ ] Step\n", "- [ ]   Step  \n")
+	padded, err := os.ReadFile(m.GetTaskFilePath("p"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	want := []TitleChange{
+		{TaskID: 1, OldTitle: "  Task 1\t", NewTitle: "Task 1"},
+		{TaskID: 1, Subtask: true, OldTitle: "  Step  ", NewTitle: "Step"},
+	}
+	changes, err := m.NormalizeTitles("p", true)
+	if err != nil {
+		t.Fatalf("NormalizeTitles dry run: %v", err)
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("dry run changes = %+v, want %+v", changes, want)
+	}
+	if content, _ := os.ReadFile(m.GetTaskFilePath("p")); string(content) != string(padded) {
+		t.Error("dry run rewrote the file")
+	}
+
+	if changes, err = m.NormalizeTitles("p", false); err != nil || !slices.Equal(changes, want) {
+		t.Fatalf("NormalizeTitles = %+v, %v; want %+v", changes, err, want)
+	}
+	content, err := os.ReadFile(m.GetTaskFilePath("p"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(content), "  Task 1") || strings.Contains(string(content), "Step  ") {
+		t.Errorf("padding survived normalization:\n%s", content)
+	}
+	if changes, err = m.NormalizeTitles("p", false); err != nil || len(changes) != 0 {
+		t.Errorf("second NormalizeTitles = %+v, %v; want no changes", changes, err)
+	}
+
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if project.Tasks[0].Title != "Task 1" || project.Tasks[0].Subtasks[0].Title != "Step" || project.Tasks[1].Subtasks[0].Title != "Step" {
+		t.Errorf("titles after normalization = %q / %q", project.Tasks[0].Title, project.Tasks[0].Subtasks[0].Title)
+	}
+}
+
+func TestNamespacedManagersShareATasksDir(t *testing.T) {
+	dir := t.TempDir()
+	newNamespaced := func(namespace string) *Manager {
+		t.Helper()
+		m, err := NewManagerWithConfig(dir, ManagerConfig{Namespace: namespace})
+		if err != nil {
+			t.Fatalf("NewManagerWithConfig(%q): %v", namespace, err)
+		}
+		return m
+	}
+	teamA, teamB, plain := newNamespaced("teamA"), newNamespaced("teamB"), newNamespaced("")
+
+	newTestProject(t, teamA, "api", 1)
+	newTestProject(t, teamB, "api", 2)
+	newTestProject(t, plain, "web", 1)
+
+	if _, err := os.Stat(filepath.Join(dir, "teamA__api.md")); err != nil {
+		t.Errorf("namespaced project file: %v", err)
+	}
+	for _, tt := range []struct {
+		m    *Manager
+		want []string
+	}{
+		{teamA, []string{"api"}},
+		{teamB, []string{"api"}},
+		{plain, []string{"teamA__api", "teamB__api", "web"}},
+	} {
+		if got, err := tt.m.ListProjects(); err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("ListProjects(namespace %q) = %v, %v; want %v", tt.m.config.Namespace, got, err, tt.want)
+		}
+	}
+
+	for m, wantTasks := range map[*Manager]int{teamA: 1, teamB: 2} {
+		project, err := m.LoadProject("api")
+		if err != nil {
+			t.Fatalf("namespace %q: LoadProject(api): %v", m.config.Namespace, err)
+		}
+		if len(project.Tasks) != wantTasks {
+			t.Errorf("namespace %q: api has %d tasks, want %d", m.config.Namespace, len(project.Tasks), wantTasks)
+		}
+	}
+	if teamA.ProjectExists("web") {
+		t.Error("a namespaced manager sees a project outside its namespace")
+	}
+
+	if _, err := NewManagerWithConfig(dir, ManagerConfig{Namespace: "team/a"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("invalid namespace = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestTimestampsSurviveSaveAndLoad(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 2)
+
+	load := func() *Project {
+		t.Helper()
+		m.InvalidateCache("p")
+		project, err := m.LoadProject("p")
+		if err != nil {
+			t.Fatalf("LoadProject: %v", err)
+		}
+		return project
+	}
+
+	// Two fresh parses of the file read the same times, so none of them is
+	// filled in from the time of the load
+	first, second := load().Tasks[0], load().Tasks[0]
+	if !first.CreatedAt.Equal(second.CreatedAt) || !first.UpdatedAt.Equal(second.UpdatedAt) ||
+		!first.Subtasks[0].CreatedAt.Equal(second.Subtasks[0].CreatedAt) || !first.Subtasks[0].UpdatedAt.Equal(second.Subtasks[0].UpdatedAt) {
+		t.Errorf("times differ between loads: %+v and %+v", first, second)
+	}
+
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 3, 8, 14, 30, 0, 0, time.UTC)
+	err := m.UpdateProject("p", func(project *Project) error {
+		task := &project.Tasks[0]
+		task.CreatedAt, task.UpdatedAt = created, updated
+		task.Subtasks[0].CreatedAt, task.Subtasks[0].UpdatedAt = created, updated
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+
+	// Saving a change to another task keeps these times through later saves
+	if err := m.UpdateTaskStatus("p", "Task 2", "", StatusInProgress); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+	for i := 1; i <= 2; i++ {
+		reloaded := load()
+		task := reloaded.Tasks[0]
+		if !task.CreatedAt.Equal(created) || !task.UpdatedAt.Equal(updated) ||
+			!task.Subtasks[0].CreatedAt.Equal(created) || !task.Subtasks[0].UpdatedAt.Equal(updated) {
+			t.Errorf("reload %d: task %s, %s; subtask %s, %s; want %s, %s", i,
+				task.CreatedAt, task.UpdatedAt, task.Subtasks[0].CreatedAt, task.Subtasks[0].UpdatedAt, created, updated)
+		}
+		if changed := reloaded.Tasks[1].UpdatedAt; !changed.After(updated) {
+			t.Errorf("reload %d: updated task's UpdatedAt = %s, want after %s", i, changed, updated)
+		}
+		if err := m.SaveProject(reloaded); err != nil {
+			t.Fatalf("SaveProject: %v", err)
+		}
+	}
+}
+
+func TestAppendTaskParsesBack(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	// A cached copy from before the appends must not be served afterwards
+	if _, err := m.LoadProject("p"); err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+
+	for i, task := range richTestProject().Tasks {
+		task.ID = 0
+		id, err := m.AppendTask("p", task)
+		if err != nil {
+			t.Fatalf("AppendTask(%q): %v", task.Title, err)
+		}
+		if id != i+1 {
+			t.Errorf("AppendTask(%q) = ID %d, want %d", task.Title, id, i+1)
+		}
+		if entries := m.CacheStats().Entries; entries != 0 {
+			t.Errorf("after appending %q the cache holds %d projects, want it invalidated", task.Title, entries)
+		}
+	}
+
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject after appends: %v", err)
+	}
+	checkRichRoundTrip(t, project)
+
+	// The appended file is an ordinary project: it saves and loads in full,
+	// and another append still sees the saved tasks
+	if err := m.SaveProject(project); err != nil {
+		t.Fatalf("SaveProject: %v", err)
+	}
+	if _, err := m.AppendTask("p", Task{Title: "Design schema"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("appending a duplicate title = %v, want ErrConflict", err)
+	}
+	if id, err := m.AppendTask("p", Task{Title: "Deploy"}); err != nil || id != 3 {
+		t.Errorf("AppendTask after a full save = %d, %v; want ID 3", id, err)
+	}
+	m.InvalidateCache("p")
+	reloaded, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if len(reloaded.Tasks) != 3 || reloaded.Tasks[2].Title != "Deploy" || reloaded.Tasks[2].Status != StatusTodo {
+		t.Errorf("tasks after the last append = %+v, want Deploy appended as todo", reloaded.Tasks)
+	}
+}
+
+func TestReorderTasks(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 3)
+
+	failures := []struct {
+		name  string
+		order []int
+		want  error
+	}{
+		{"too few", []int{3, 1}, ErrInvalidInput},
+		{"too many", []int{3, 1, 2, 1}, ErrInvalidInput},
+		{"duplicate", []int{3, 1, 1}, ErrInvalidInput},
+		{"unknown ID", []int{3, 1, 7}, ErrNotFound},
+	}
+	for _, tt := range failures {
+		if err := m.ReorderTasks("p", tt.order); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if err := m.ReorderTasks("p", []int{3, 1, 2}); err != nil {
+		t.Fatalf("ReorderTasks: %v", err)
+	}
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	var got []string
+	for _, task := range project.Tasks {
+		got = append(got, fmt.Sprintf("%d:%s", task.ID, task.Title))
+	}
+	if want := []string{"3:Task 3", "1:Task 1", "2:Task 2"}; !slices.Equal(got, want) {
+		t.Errorf("order after reload = %v, want %v", got, want)
+	}
+
+	next, _, err := m.GetNextTask("p")
+	if err != nil {
+		t.Fatalf("GetNextTask: %v", err)
+	}
+	if next.Title != "Task 3" {
+		t.Errorf("next task = %q, want Task 3", next.Title)
+	}
+}
@@ -12,7 +12,7 @@ import (
 func (m *Manager) generateMarkdown(project Project) string {
 	var content strings.Builder
 
-	content.WriteString("# Project Tasks\n\n")
+	content.WriteString(m.heading(-1) + " " + m.config.Labels.Title + "\n\n")
 
 	if project.Description != "" {
 		content.WriteString(fmt.Sprintf("%s\n\n", project.Description))
@@ -20,20 +20,24 @@ func (m *Manager) generateMarkdown(project Project) string {
 
 	// Add visual overview if project is complex enough
 	if m.shouldGenerateDiagram(project) {
-		content.WriteString("## Project Overview\n\n")
+		content.WriteString(m.heading(0) + " " + m.config.Labels.Overview + "\n\n")
 		content.WriteString(m.generateMermaidDiagram(project))
+		if hasComplexDependencies(project) {
+			content.WriteString(m.generateMermaidDependencyGraph(project))
+		}
 		content.WriteString("\n")
 	}
 
 	// Add task categories explanation
-	content.WriteString("## Categories\n")
+	content.WriteString(m.heading(0) + " " + m.config.Labels.Categories + "\n")
 	content.WriteString("- [MVP] Core functionality tasks\n")
 	content.WriteString("- [AI] AI-related features\n")
 	content.WriteString("- [UX] User experience improvements\n")
-	content.WriteString("- [INFRA] Infrastructure and setup\n\n")
+	content.WriteString("- [INFRA] Infrastructure and setup\n")
+	content.WriteString("- [GENERAL] Uncategorized tasks\n\n")
 
 	// Add priority levels explanation
-	content.WriteString("## Priority Levels\n")
+	content.WriteString(m.heading(0) + " " + m.config.Labels.PriorityLevels + "\n")
 	content.WriteString("- P0: Blocker/Critical\n")
 	content.WriteString("- P1: High Priority\n")
 	content.WriteString("- P2: Medium Priority\n")
@@ -48,15 +52,18 @@ func (m *Manager) generateMarkdown(project Project) string {
 	return content.String()
 }
 
+// heading returns the markdown heading marker for the configured task heading
+// level plus offset: -1 for the document title, 0 for tasks, 1 for task sections
+func (m *Manager) heading(offset int) string {
+	return strings.Repeat("#", m.config.HeadingLevel+offset)
+}
+
 // generateTaskMarkdown generates markdown for a single task
 func (m *Manager) generateTaskMarkdown(task Task) string {
 	var content strings.Builder
 
 	// Task header with ID, category, title, priority, and status
-	category := string(task.Category)
-	if category == "" {
-		category = "[GENERAL]"
-	}
+	category := string(task.EffectiveCategory())
 	priority := string(task.Priority)
 	if priority == "" {
 		priority = "P2"
@@ -66,18 +73,48 @@ func (m *Manager) generateTaskMarkdown(task Task) string {
 		status = "todo"
 	}
 
-	content.WriteString(fmt.Sprintf("## Task %d: %s %s (%s) [%s]\n\n", task.ID, category, task.Title, priority, status))
+	content.WriteString(fmt.Sprintf("%s %s %d: %s %s (%s) [%s]\n", m.heading(0), m.config.Labels.Task, task.ID, category, task.Title, priority, status))
+	content.WriteString(generateMetadataComment(taskMetadata(task.CreatedAt, task.UpdatedAt, task.CompletedAt)))
+	content.WriteString("\n")
 
 	// Task description
 	if task.Description != "" {
 		content.WriteString(fmt.Sprintf("%s\n\n", task.Description))
 	}
 
+	// Tags
+	if len(task.Tags) > 0 {
+		content.WriteString(fmt.Sprintf("%s %s: %s\n\n", m.heading(1), m.config.Labels.Tags, strings.Join(task.Tags, ", ")))
+	}
+
+	// Assignee
+	if task.Assignee != "" {
+		content.WriteString(fmt.Sprintf("%s %s: %s\n\n", m.heading(1), m.config.Labels.Assignee, task.Assignee))
+	}
+
+	// Why the task is blocked
+	if task.Status == StatusBlocked && task.BlockedReason != "" {
+		content.WriteString(fmt.Sprintf("%s %s: %s\n\n", m.heading(1), m.config.Labels.BlockedReason, task.BlockedReason))
+	}
+
 	// Dependencies
 	if len(task.Dependencies) > 0 {
-		content.WriteString("### Dependencies:\n")
+		content.WriteString(m.heading(1) + " " + m.config.Labels.Dependencies + ":\n")
 		for _, dep := range task.Dependencies {
-			content.WriteString(fmt.Sprintf("- Task %d\n", dep))
+			content.WriteString(fmt.Sprintf("- %s %d\n", m.config.Labels.Task, dep))
+		}
+		content.WriteString("\n")
+	}
+
+	// Definition of done (acceptance criteria, distinct from subtasks)
+	if len(task.DoneCriteria) > 0 {
+		content.WriteString(m.heading(1) + " " + m.config.Labels.DoneCriteria + ":\n")
+		for _, criterion := range task.DoneCriteria {
+			checked := " "
+			if task.IsCriterionAcknowledged(criterion) {
+				checked = "x"
+			}
+			content.WriteString(fmt.Sprintf("- [%s] %s\n", checked, criterion))
 		}
 		content.WriteString("\n")
 	}
@@ -85,17 +122,17 @@ func (m *Manager) generateTaskMarkdown(task Task) string {
 	// Complexity and estimated hours
 	if task.Complexity != "" || task.EstimatedHours > 0 {
 		if task.Complexity != "" {
-			content.WriteString(fmt.Sprintf("### Complexity: %s\n", task.Complexity))
+			content.WriteString(fmt.Sprintf("%s %s: %s\n", m.heading(1), m.config.Labels.Complexity, task.Complexity))
 		}
 		if task.EstimatedHours > 0 {
-			content.WriteString(fmt.Sprintf("Estimated hours: %d\n", task.EstimatedHours))
+			content.WriteString(fmt.Sprintf("%s: %d\n", m.config.Labels.EstimatedHours, task.EstimatedHours))
 		}
 		content.WriteString("\n")
 	}
 
 	// Choices
 	if len(task.Choices) > 0 {
-		content.WriteString("### Choices:\n")
+		content.WriteString(m.heading(1) + " " + m.config.Labels.Choices + ":\n")
 		for _, choice := range task.Choices {
 			content.WriteString(m.generateChoiceMarkdown(choice))
 		}
@@ -104,33 +141,241 @@ func (m *Manager) generateTaskMarkdown(task Task) string {
 
 	// Subtasks
 	if len(task.Subtasks) > 0 {
-		content.WriteString("### Subtasks:\n\n")
+		content.WriteString(m.heading(1) + " " + m.config.Labels.Subtasks + ":\n\n")
 		for _, subtask := range task.Subtasks {
 			status := " "
 			if subtask.Status == StatusDone {
 				status = "x"
 			}
 			content.WriteString(fmt.Sprintf("- [%s] %s\n", status, subtask.Title))
-
-			// Subtask choices
-			if len(subtask.Choices) > 0 {
-				for _, choice := range subtask.Choices {
-					content.WriteString(fmt.Sprintf("  %s", m.generateChoiceMarkdown(choice)))
+			if metadata := generateMetadataComment(subtaskMetadata(subtask)); metadata != "" {
+				content.WriteString("  " + metadata)
+			}
+			content.WriteString(generateSubtaskDetails(subtask))
+
+			// Subtask choices are indented under the subtask so their options
+			// aren't read back as subtasks
+			for _, choice := range subtask.Choices {
+				for _, line := range strings.SplitAfter(m.generateChoiceMarkdown(choice), "\n") {
+					if strings.TrimSpace(line) != "" {
+						line = "  " + line
+					}
+					content.WriteString(line)
 				}
 			}
 		}
 		content.WriteString("\n")
 	}
 
+	// Notes
+	if len(task.Comments) > 0 {
+		content.WriteString(m.heading(1) + " " + m.config.Labels.Notes + ":\n")
+		for _, comment := range task.Comments {
+			content.WriteString(generateCommentMarkdown(comment))
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// generateCommentMarkdown renders a comment as a list item with its time and
+// author, "- 2024-05-01T10:00:00Z **alice**: Text"
+func generateCommentMarkdown(comment Comment) string {
+	author := ""
+	if comment.Author != "" {
+		author = fmt.Sprintf(" **%s**", comment.Author)
+	}
+	return fmt.Sprintf("- %s%s: %s\n", formatMetadataTime(comment.CreatedAt), author, comment.Text)
+}
+
+// Keys of the indented detail lines written under a subtask
+const (
+	subtaskHoursKey       = "hours"
+	subtaskComplexityKey  = "complexity"
+	subtaskDescriptionKey = "desc"
+)
+
+// generateSubtaskDetails renders a subtask's estimate, complexity and description
+// as indented sub-bullets, "  - hours: 3, complexity: medium" and
+// "  - desc: Write the migration". Descriptions are kept to one line.
+// Returns an empty string when none are set.
+func generateSubtaskDetails(subtask Subtask) string {
+	var content strings.Builder
+
+	var fields []string
+	if subtask.EstimatedHours > 0 {
+		fields = append(fields, fmt.Sprintf("%s: %d", subtaskHoursKey, subtask.EstimatedHours))
+	}
+	if subtask.Complexity != "" {
+		fields = append(fields, fmt.Sprintf("%s: %s", subtaskComplexityKey, subtask.Complexity))
+	}
+	if len(fields) > 0 {
+		content.WriteString(fmt.Sprintf("  - %s\n", strings.Join(fields, ", ")))
+	}
+
+	if description := strings.Join(strings.Fields(subtask.Description), " "); description != "" {
+		content.WriteString(fmt.Sprintf("  - %s: %s\n", subtaskDescriptionKey, description))
+	}
+
 	return content.String()
 }
 
+// parseSubtaskDetails applies a detail line written by generateSubtaskDetails
+// (already trimmed, with its "- " removed) to a subtask. Returns false if the
+// line is not a detail line.
+func parseSubtaskDetails(line string, subtask *Subtask) bool {
+	if description, ok := strings.CutPrefix(line, subtaskDescriptionKey+":"); ok {
+		subtask.Description = strings.TrimSpace(description)
+		return true
+	}
+
+	parsed := false
+	for _, field := range strings.Split(line, ",") {
+		key, value, found := strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case subtaskHoursKey:
+			if hours, err := strconv.Atoi(value); err == nil {
+				subtask.EstimatedHours = hours
+				parsed = true
+			}
+		case subtaskComplexityKey:
+			if complexity, err := ValidateTaskComplexity(value); err == nil {
+				subtask.Complexity = complexity
+				parsed = true
+			}
+		}
+	}
+	return parsed
+}
+
+// metadataField is a single key/value pair stored in a metadata comment
+type metadataField struct {
+	Key   string
+	Value string
+}
+
+// taskMetadata returns the metadata fields persisted for a task or subtask;
+// unset times are left out
+func taskMetadata(createdAt, updatedAt time.Time, completedAt *time.Time) []metadataField {
+	var fields []metadataField
+	if !createdAt.IsZero() {
+		fields = append(fields, metadataField{Key: "created", Value: formatMetadataTime(createdAt)})
+	}
+	if !updatedAt.IsZero() {
+		fields = append(fields, metadataField{Key: "updated", Value: formatMetadataTime(updatedAt)})
+	}
+	if completedAt != nil {
+		fields = append(fields, metadataField{Key: "completed", Value: formatMetadataTime(*completedAt)})
+	}
+	return fields
+}
+
+// subtaskMetadata returns the metadata fields persisted for a subtask. The
+// checkbox only records done or not, so an in_progress or blocked status is
+// kept in the comment.
+func subtaskMetadata(subtask Subtask) []metadataField {
+	fields := taskMetadata(subtask.CreatedAt, subtask.UpdatedAt, subtask.CompletedAt)
+	if subtask.Status == StatusInProgress || subtask.Status == StatusBlocked {
+		fields = append(fields, metadataField{Key: "status", Value: string(subtask.Status)})
+	}
+	return fields
+}
+
+// choiceMetadata returns the metadata fields persisted for a choice, so its ID
+// and times survive a save and load; unset fields are left out
+func choiceMetadata(choice Choice) []metadataField {
+	var fields []metadataField
+	if choice.ID != "" {
+		fields = append(fields, metadataField{Key: "id", Value: choice.ID})
+	}
+	if !choice.CreatedAt.IsZero() {
+		fields = append(fields, metadataField{Key: "created", Value: formatMetadataTime(choice.CreatedAt)})
+	}
+	if choice.ResolvedAt != nil {
+		fields = append(fields, metadataField{Key: "resolved", Value: formatMetadataTime(*choice.ResolvedAt)})
+	}
+	return fields
+}
+
+// generateMetadataComment renders metadata fields as an HTML comment line,
+// e.g. <!-- created: 2024-01-02T15:04:05Z -->, which markdown viewers hide.
+// Returns an empty string when there is nothing to record.
+func generateMetadataComment(fields []metadataField) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = fmt.Sprintf("%s: %s", field.Key, field.Value)
+	}
+	return fmt.Sprintf("<!-- %s -->\n", strings.Join(parts, " "))
+}
+
+// parseMetadataComment parses a metadata comment line into its key/value pairs.
+// Returns false if the line is not a metadata comment.
+func parseMetadataComment(line string) (map[string]string, bool) {
+	if !strings.HasPrefix(line, "<!--") || !strings.HasSuffix(line, "-->") {
+		return nil, false
+	}
+
+	body := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "<!--"), "-->"))
+	fields := make(map[string]string)
+	tokens := strings.Fields(body)
+	for i := 0; i < len(tokens)-1; i++ {
+		if strings.HasSuffix(tokens[i], ":") {
+			fields[strings.TrimSuffix(tokens[i], ":")] = tokens[i+1]
+			i++
+		}
+	}
+	return fields, true
+}
+
+// formatMetadataTime formats a timestamp for a metadata comment. Times are
+// stored in UTC so files read the same whatever the server's timezone.
+func formatMetadataTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseMetadataTime parses an RFC3339 timestamp from metadata fields, returning
+// it in UTC. Hand-written timestamps without a zone are taken as UTC.
+func parseMetadataTime(fields map[string]string, key string) (time.Time, bool) {
+	value, exists := fields[key]
+	if !exists {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if parsed, err = time.Parse("2006-01-02T15:04:05", value); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return parsed.UTC(), true
+}
+
+// applyMetadataTimes restores created and updated times from metadata fields,
+// keeping the current values (the parse time) for files written without them
+func applyMetadataTimes(fields map[string]string, createdAt, updatedAt *time.Time) {
+	if created, ok := parseMetadataTime(fields, "created"); ok {
+		*createdAt = created
+	}
+	if updated, ok := parseMetadataTime(fields, "updated"); ok {
+		*updatedAt = updated
+	}
+}
+
 // generateChoiceMarkdown generates markdown for a choice
 func (m *Manager) generateChoiceMarkdown(choice Choice) string {
 	var content strings.Builder
 
-	content.WriteString(fmt.Sprintf("**Choice:** %s\n", choice.Question))
-	content.WriteString("Options:\n")
+	content.WriteString(fmt.Sprintf("**%s:** %s\n", m.config.Labels.Choice, choice.Question))
+	content.WriteString(generateMetadataComment(choiceMetadata(choice)))
+	content.WriteString(m.config.Labels.Options + ":\n")
 	for _, option := range choice.Options {
 		marker := " "
 		if choice.Selected == option {
@@ -140,38 +385,166 @@ func (m *Manager) generateChoiceMarkdown(choice Choice) string {
 	}
 
 	if choice.Reasoning != "" {
-		content.WriteString(fmt.Sprintf("Reasoning: %s\n", choice.Reasoning))
+		content.WriteString(fmt.Sprintf("%s: %s\n", m.config.Labels.Reasoning, choice.Reasoning))
 	}
 
 	content.WriteString("\n")
 	return content.String()
 }
 
+// sectionHeaderPattern matches task section headers such as "### Subtasks:"
+var sectionHeaderPattern = regexp.MustCompile(`^#{3,6}\s+(.*)$`)
+
+// checkboxItemPattern matches checklist items such as "- [x] Title", capturing the mark and text
+var checkboxItemPattern = regexp.MustCompile(`^-\s*\[(.)\]\s*(.+)$`)
+
+// criterionPattern matches a definition of done criterion with its "- " already
+// stripped, such as "[x] Criterion", capturing the mark and text
+var criterionPattern = regexp.MustCompile(`^\[(.)\]\s*(.+)$`)
+
+// commentPattern matches a comment written by generateCommentMarkdown,
+// capturing the time, the optional author and the text
+var commentPattern = regexp.MustCompile(`^-\s+(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z)(?:\s+\*\*([^*]*)\*\*)?:\s*(.*)$`)
+
+// taskHeaderPattern matches task headers such as "## Task 1: [MVP] Title (P1) [todo]",
+// capturing the ID, category, title, priority and status. Any heading level
+// from ## to ###### is accepted; "Task" is the configured label.
+func (m *Manager) taskHeaderPattern() *regexp.Regexp {
+	return m.headerPattern
+}
+
+// compileTaskHeaderPattern compiles the task header pattern for the given task label
+func compileTaskHeaderPattern(taskLabel string) *regexp.Regexp {
+	return regexp.MustCompile(`^#{2,6}\s+` + regexp.QuoteMeta(taskLabel) + `\s+(\d+):\s*(\[[\w]+\])?\s*(.+?)\s*\(([^)]+)\)\s*(?:\[([^\]]+)\])?$`)
+}
+
+// paddedSubtaskPattern matches a subtask line, capturing the whitespace around its title
+var paddedSubtaskPattern = regexp.MustCompile(`^-\s*\[.\]([ \t]*)(.*?)([ \t]*)$`)
+
+// paddedTitles scans raw project markdown for task and subtask titles written
+// with more whitespace around them than generateMarkdown uses. Parsing trims
+// titles, so this padding can only be found in the file itself.
+func (m *Manager) paddedTitles(content string) []TitleChange {
+	changes := []TitleChange{}
+	taskID := 0
+	inSubtasks := false
+
+	for _, rawLine := range strings.Split(content, "\n") {
+		rawLine = strings.TrimRight(rawLine, "\r")
+		line := strings.TrimSpace(rawLine)
+
+		if match := m.taskHeaderPattern().FindStringSubmatchIndex(line); match != nil {
+			taskID, _ = strconv.Atoi(line[match[2]:match[3]])
+			inSubtasks = false
+
+			// The title sits between the ID's colon (or the category) and the
+			// priority's opening parenthesis, each normally followed or
+			// preceded by a single space
+			start := match[3] + 1
+			if match[4] >= 0 {
+				start = match[5]
+			}
+			raw := line[start : match[8]-1]
+			title := line[match[6]:match[7]]
+			leading := len(raw) - len(strings.TrimLeft(raw, " \t"))
+			trailing := len(raw) - len(strings.TrimRight(raw, " \t"))
+			if leading > 1 || trailing > 1 {
+				changes = append(changes, TitleChange{TaskID: taskID, OldTitle: trimOneSpace(raw), NewTitle: title})
+			}
+			continue
+		}
+
+		if section := sectionHeaderPattern.FindStringSubmatch(line); section != nil {
+			inSubtasks = strings.HasPrefix(section[1], m.config.Labels.Subtasks)
+			continue
+		}
+
+		// Indented checkboxes under a subtask belong to it, not to the task
+		if !inSubtasks || !strings.HasPrefix(rawLine, "- [") {
+			continue
+		}
+		if match := paddedSubtaskPattern.FindStringSubmatch(rawLine); match != nil && (len(match[1]) > 1 || match[3] != "") {
+			changes = append(changes, TitleChange{TaskID: taskID, Subtask: true, OldTitle: strings.TrimPrefix(match[1], " ") + match[2] + match[3], NewTitle: match[2]})
+		}
+	}
+
+	return changes
+}
+
+// trimOneSpace removes the single separating space generateMarkdown writes
+// on each side of a title, leaving any extra padding
+func trimOneSpace(s string) string {
+	s = strings.TrimPrefix(s, " ")
+	return strings.TrimSuffix(s, " ")
+}
+
 // parseMarkdown parses markdown content into a project
 func (m *Manager) parseMarkdown(content string) (*Project, error) {
 	project := &Project{
 		Tasks:     []Task{},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
 	}
 
 	lines := strings.Split(content, "\n")
 	var currentTask *Task
 	var currentChoice *Choice
+	var subtaskChoice bool
 	var inSubtasks bool
 	var inChoices bool
+	var inDoneCriteria bool
+	var inNotes bool
+
+	// A description line continues the description of the line before it,
+	// after a paragraph break if blank lines came in between
+	var afterDescription bool
+	var blankLines int
+
+	// The project description is the text between the title heading and the
+	// heading after it
+	var seenProjectTitle bool
+	var inProjectDescription bool
+
+	labels := m.config.Labels
+	taskHeaderPattern := m.taskHeaderPattern()
+	estimatedHoursPrefix := labels.EstimatedHours + ":"
+	dependencyPrefix := "- " + labels.Task + " "
+	choicePrefix := "**" + labels.Choice + ":**"
+	reasoningPrefix := labels.Reasoning + ":"
+
+	// flushChoice attaches the choice being parsed to the current task, or to
+	// its last subtask for a choice indented in the subtask list. A choice
+	// closes at its reasoning line or when the next choice, subtask, section or
+	// task starts, so choices without reasoning are kept too.
+	flushChoice := func() {
+		if currentChoice != nil && currentTask != nil {
+			if subtaskChoice {
+				subtask := &currentTask.Subtasks[len(currentTask.Subtasks)-1]
+				subtask.Choices = append(subtask.Choices, *currentChoice)
+			} else {
+				currentTask.Choices = append(currentTask.Choices, *currentChoice)
+			}
+		}
+		currentChoice = nil
+	}
 
 	for _, line := range lines {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
 		line = strings.TrimSpace(line)
 
-		// Skip empty lines
+		// Skip empty lines, counting them in case they separate description paragraphs
 		if line == "" {
+			blankLines++
 			continue
 		}
+		paragraphBreak := afterDescription && blankLines > 0
+		afterDescription = false
+		blankLines = 0
 
 		// Parse task header: ## Task 1: [MVP] Task Title (P1) [status]
-		if taskMatch := regexp.MustCompile(`^##\s+Task\s+(\d+):\s*(\[[\w]+\])?\s*(.+?)\s*\(([^)]+)\)\s*(?:\[([^\]]+)\])?$`).FindStringSubmatch(line); taskMatch != nil {
+		if taskMatch := taskHeaderPattern.FindStringSubmatch(line); taskMatch != nil {
 			// Save previous task
+			flushChoice()
 			if currentTask != nil {
 				project.Tasks = append(project.Tasks, *currentTask)
 			}
@@ -188,12 +561,12 @@ func (m *Manager) parseMarkdown(content string) (*Project, error) {
 				Title:     strings.TrimSpace(taskMatch[3]),
 				Status:    StatusTodo, // Default, will be overridden if status is present
 				Priority:  TaskPriority(taskMatch[4]),
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
 			}
 
-			// Parse category if present
-			if taskMatch[2] != "" {
+			// Parse category if present; [GENERAL] means the task has none
+			if taskMatch[2] != "" && TaskCategory(taskMatch[2]) != CategoryGeneral {
 				currentTask.Category = TaskCategory(taskMatch[2])
 			}
 
@@ -206,20 +579,113 @@ func (m *Manager) parseMarkdown(content string) (*Project, error) {
 
 			inSubtasks = false
 			inChoices = false
+			inDoneCriteria = false
+			inNotes = false
 			continue
 		}
 
-		// Parse section headers
-		if strings.HasPrefix(line, "### ") {
-			section := strings.TrimPrefix(line, "### ")
+		// Parse the project description
+		if currentTask == nil {
+			if strings.HasPrefix(line, "#") {
+				inProjectDescription = !seenProjectTitle
+				seenProjectTitle = true
+			} else if inProjectDescription {
+				switch {
+				case project.Description == "":
+					project.Description = line
+				case paragraphBreak:
+					project.Description += "\n\n" + line
+				default:
+					project.Description += "\n" + line
+				}
+				afterDescription = true
+				continue
+			}
+		}
+
+		// Parse metadata comments; a comment with an id belongs to the choice being
+		// parsed, and inside a subtask list others belong to the last subtask
+		if fields, ok := parseMetadataComment(line); ok {
+			if id, hasID := fields["id"]; hasID && currentChoice != nil {
+				currentChoice.ID = id
+				if createdAt, ok := parseMetadataTime(fields, "created"); ok {
+					currentChoice.CreatedAt = createdAt
+				}
+				if resolvedAt, ok := parseMetadataTime(fields, "resolved"); ok {
+					currentChoice.ResolvedAt = &resolvedAt
+				}
+			} else if currentTask != nil {
+				if inSubtasks && len(currentTask.Subtasks) > 0 {
+					subtask := &currentTask.Subtasks[len(currentTask.Subtasks)-1]
+					applyMetadataTimes(fields, &subtask.CreatedAt, &subtask.UpdatedAt)
+					if completedAt, ok := parseMetadataTime(fields, "completed"); ok && subtask.Status == StatusDone {
+						subtask.CompletedAt = &completedAt
+					}
+					// A checked box wins over a stale status left in the comment
+					if status := TaskStatus(fields["status"]); subtask.Status == StatusTodo && (status == StatusInProgress || status == StatusBlocked) {
+						subtask.Status = status
+					}
+				} else {
+					applyMetadataTimes(fields, &currentTask.CreatedAt, &currentTask.UpdatedAt)
+					if completedAt, ok := parseMetadataTime(fields, "completed"); ok && currentTask.Status == StatusDone {
+						currentTask.CompletedAt = &completedAt
+					}
+				}
+			}
+			continue
+		}
+
+		// Parse section headers (### or deeper)
+		if sectionMatch := sectionHeaderPattern.FindStringSubmatch(line); sectionMatch != nil {
+			flushChoice()
+			section := sectionMatch[1]
+			inDoneCriteria = false
+			inNotes = false
 			switch {
-			case strings.HasPrefix(section, "Subtasks"):
+			case strings.HasPrefix(section, labels.Subtasks):
 				inSubtasks = true
 				inChoices = false
-			case strings.HasPrefix(section, "Choices"):
+			case strings.HasPrefix(section, labels.Choices):
 				inChoices = true
 				inSubtasks = false
-			case strings.HasPrefix(section, "Complexity"):
+			case strings.HasPrefix(section, labels.DoneCriteria):
+				inDoneCriteria = true
+				inSubtasks = false
+				inChoices = false
+			case strings.HasPrefix(section, labels.Notes):
+				inNotes = true
+				inSubtasks = false
+				inChoices = false
+			case strings.HasPrefix(section, labels.Tags):
+				if currentTask != nil && strings.Contains(section, ":") {
+					parts := strings.SplitN(section, ":", 2)
+					for _, tag := range strings.Split(parts[1], ",") {
+						if normalized, err := ValidateTag(tag); err == nil {
+							currentTask.AddTag(normalized)
+						}
+					}
+				}
+				inSubtasks = false
+				inChoices = false
+			case strings.HasPrefix(section, labels.Assignee):
+				if currentTask != nil && strings.Contains(section, ":") {
+					parts := strings.SplitN(section, ":", 2)
+					if assignee, err := ValidateAssignee(parts[1]); err == nil {
+						currentTask.Assignee = assignee
+					}
+				}
+				inSubtasks = false
+				inChoices = false
+			case strings.HasPrefix(section, labels.BlockedReason):
+				if currentTask != nil && currentTask.Status == StatusBlocked && strings.Contains(section, ":") {
+					parts := strings.SplitN(section, ":", 2)
+					if reason, err := ValidateBlockedReason(parts[1]); err == nil {
+						currentTask.BlockedReason = reason
+					}
+				}
+				inSubtasks = false
+				inChoices = false
+			case strings.HasPrefix(section, labels.Complexity):
 				if currentTask != nil && strings.Contains(section, ":") {
 					parts := strings.SplitN(section, ":", 2)
 					if len(parts) == 2 {
@@ -236,26 +702,58 @@ func (m *Manager) parseMarkdown(content string) (*Project, error) {
 		}
 
 		// Parse estimated hours
-		if strings.HasPrefix(line, "Estimated hours:") && currentTask != nil {
-			hoursStr := strings.TrimSpace(strings.TrimPrefix(line, "Estimated hours:"))
+		if strings.HasPrefix(line, estimatedHoursPrefix) && currentTask != nil {
+			hoursStr := strings.TrimSpace(strings.TrimPrefix(line, estimatedHoursPrefix))
 			if hours, err := strconv.Atoi(hoursStr); err == nil {
 				currentTask.EstimatedHours = hours
 			}
 			continue
 		}
 
+		// Parse definition of done criteria
+		// ("- [x] criterion" when acknowledged; plain "- criterion" is read as unacknowledged)
+		if inDoneCriteria && strings.HasPrefix(line, "- ") && currentTask != nil {
+			criterion := strings.TrimSpace(strings.TrimPrefix(line, "- "))
+			acknowledged := false
+			if criterionMatch := criterionPattern.FindStringSubmatch(criterion); criterionMatch != nil {
+				criterion = strings.TrimSpace(criterionMatch[2])
+				acknowledged = criterionMatch[1] == "x"
+			}
+			if criterion != "" {
+				currentTask.DoneCriteria = append(currentTask.DoneCriteria, criterion)
+				if acknowledged {
+					currentTask.MetCriteria = append(currentTask.MetCriteria, criterion)
+				}
+			}
+			continue
+		}
+
+		// Parse notes
+		if inNotes && strings.HasPrefix(line, "- ") && currentTask != nil {
+			if commentMatch := commentPattern.FindStringSubmatch(line); commentMatch != nil {
+				createdAt, _ := time.Parse(time.RFC3339, commentMatch[1])
+				currentTask.Comments = append(currentTask.Comments, Comment{
+					Author:    strings.TrimSpace(commentMatch[2]),
+					Text:      strings.TrimSpace(commentMatch[3]),
+					CreatedAt: createdAt.UTC(),
+				})
+			}
+			continue
+		}
+
 		// Parse dependencies
-		if strings.HasPrefix(line, "- Task ") && !inSubtasks && !inChoices && currentTask != nil {
-			depStr := strings.TrimSpace(strings.TrimPrefix(line, "- Task "))
+		if strings.HasPrefix(line, dependencyPrefix) && !inSubtasks && !inChoices && currentTask != nil {
+			depStr := strings.TrimSpace(strings.TrimPrefix(line, dependencyPrefix))
 			if dep, err := strconv.Atoi(depStr); err == nil {
 				currentTask.Dependencies = append(currentTask.Dependencies, dep)
 			}
 			continue
 		}
 
-		// Parse subtasks
-		if inSubtasks && strings.HasPrefix(line, "- [") && currentTask != nil {
-			subtaskMatch := regexp.MustCompile(`^-\s*\[(.)\]\s*(.+)$`).FindStringSubmatch(line)
+		// Parse subtasks; indented checkboxes under a subtask's choice are its options
+		if inSubtasks && strings.HasPrefix(line, "- [") && currentTask != nil && !(subtaskChoice && currentChoice != nil && indented) {
+			flushChoice()
+			subtaskMatch := checkboxItemPattern.FindStringSubmatch(line)
 			if subtaskMatch != nil {
 				status := StatusTodo
 				if subtaskMatch[1] == "x" {
@@ -265,8 +763,8 @@ func (m *Manager) parseMarkdown(content string) (*Project, error) {
 				subtask := Subtask{
 					Title:     strings.TrimSpace(subtaskMatch[2]),
 					Status:    status,
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
+					CreatedAt: time.Now().UTC(),
+					UpdatedAt: time.Now().UTC(),
 				}
 
 				currentTask.Subtasks = append(currentTask.Subtasks, subtask)
@@ -274,59 +772,71 @@ func (m *Manager) parseMarkdown(content string) (*Project, error) {
 			continue
 		}
 
+		// Parse subtask details (estimate, complexity and description)
+		if inSubtasks && currentChoice == nil && strings.HasPrefix(line, "- ") && currentTask != nil && len(currentTask.Subtasks) > 0 {
+			parseSubtaskDetails(strings.TrimPrefix(line, "- "), &currentTask.Subtasks[len(currentTask.Subtasks)-1])
+			continue
+		}
+
 		// Parse choice questions
-		if strings.HasPrefix(line, "**Choice:**") && currentTask != nil {
-			question := strings.TrimSpace(strings.TrimPrefix(line, "**Choice:**"))
+		if strings.HasPrefix(line, choicePrefix) && currentTask != nil {
+			question := strings.TrimSpace(strings.TrimPrefix(line, choicePrefix))
+			flushChoice()
+			subtaskChoice = inSubtasks && indented && len(currentTask.Subtasks) > 0
 			currentChoice = &Choice{
 				ID:        GenerateChoiceID(),
 				Question:  question,
 				Options:   []string{},
-				CreatedAt: time.Now(),
+				CreatedAt: time.Now().UTC(),
 			}
 			continue
 		}
 
 		// Parse choice options
 		if currentChoice != nil && strings.HasPrefix(line, "- [") {
-			optionMatch := regexp.MustCompile(`^-\s*\[(.)\]\s*(.+)$`).FindStringSubmatch(line)
+			optionMatch := checkboxItemPattern.FindStringSubmatch(line)
 			if optionMatch != nil {
 				option := strings.TrimSpace(optionMatch[2])
 				currentChoice.Options = append(currentChoice.Options, option)
 
 				if optionMatch[1] == "x" {
 					currentChoice.Selected = option
-					now := time.Now()
-					currentChoice.ResolvedAt = &now
+					if currentChoice.ResolvedAt == nil {
+						now := time.Now().UTC()
+						currentChoice.ResolvedAt = &now
+					}
 				}
 			}
 			continue
 		}
 
 		// Parse choice reasoning
-		if currentChoice != nil && strings.HasPrefix(line, "Reasoning:") {
-			currentChoice.Reasoning = strings.TrimSpace(strings.TrimPrefix(line, "Reasoning:"))
+		if currentChoice != nil && strings.HasPrefix(line, reasoningPrefix) {
+			currentChoice.Reasoning = strings.TrimSpace(strings.TrimPrefix(line, reasoningPrefix))
 
 			// Add choice to current task
-			if currentTask != nil {
-				currentTask.Choices = append(currentTask.Choices, *currentChoice)
-			}
-			currentChoice = nil
+			flushChoice()
 			continue
 		}
 
 		// Parse task description (any line that's not a special format)
 		if currentTask != nil && !inSubtasks && !inChoices && currentChoice == nil &&
 			!strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "-") &&
-			!strings.HasPrefix(line, "Estimated hours:") && line != "---" {
-			if currentTask.Description == "" {
+			!strings.HasPrefix(line, estimatedHoursPrefix) && line != "---" {
+			switch {
+			case currentTask.Description == "":
 				currentTask.Description = line
-			} else {
+			case paragraphBreak:
+				currentTask.Description += "\n\n" + line
+			default:
 				currentTask.Description += "\n" + line
 			}
+			afterDescription = true
 		}
 	}
 
 	// Save last task
+	flushChoice()
 	if currentTask != nil {
 		project.Tasks = append(project.Tasks, *currentTask)
 	}
@@ -355,18 +865,18 @@ func (m *Manager) shouldGenerateDiagram(project Project) bool {
 		return true
 	}
 
-	// Check for complex dependencies (multiple tasks with dependencies)
+	return hasComplexDependencies(project)
+}
+
+// hasComplexDependencies reports whether two or more tasks have dependencies
+func hasComplexDependencies(project Project) bool {
 	tasksWithDeps := 0
 	for _, task := range project.Tasks {
 		if len(task.Dependencies) > 0 {
 			tasksWithDeps++
 		}
 	}
-	if tasksWithDeps >= 2 {
-		return true
-	}
-
-	return false
+	return tasksWithDeps >= 2
 }
 
 // generateMermaidDiagram creates a simple Mermaid diagram showing project progress
@@ -409,21 +919,11 @@ func (m *Manager) generateMermaidDiagram(project Project) string {
 
 	// Use pie chart for simple progress visualization
 	content.WriteString("```mermaid\n")
-	content.WriteString("pie title Project Progress\n")
-
-	if completedItems > 0 {
-		content.WriteString(fmt.Sprintf("    \"Completed\" : %d\n", completedItems))
-	}
-
-	remainingItems := totalItems - completedItems
-	if remainingItems > 0 {
-		content.WriteString(fmt.Sprintf("    \"Remaining\" : %d\n", remainingItems))
-	}
-
+	content.WriteString(progressPieChart(completedItems, totalItems))
 	content.WriteString("```\n\n")
 
 	// Add a simple progress table for more detail
-	content.WriteString("### Progress Summary\n\n")
+	content.WriteString(m.heading(1) + " " + m.config.Labels.ProgressSummary + "\n\n")
 	content.WriteString("| Metric | Count | Percentage |\n")
 	content.WriteString("|--------|-------|------------|\n")
 
@@ -449,3 +949,104 @@ func (m *Manager) generateMermaidDiagram(project Project) string {
 
 	return content.String()
 }
+
+// maxGraphLabelLength is the longest task title shown in a dependency graph node
+const maxGraphLabelLength = 30
+
+// generateMermaidDependencyGraph creates a Mermaid flowchart with a node per
+// task, colored by status, and an edge from each dependency to the task that
+// depends on it. Dependencies on tasks that no longer exist are left out.
+func (m *Manager) generateMermaidDependencyGraph(project Project) string {
+	var content strings.Builder
+
+	content.WriteString("```mermaid\n")
+	content.WriteString("graph TD\n")
+
+	taskIDs := make(map[int]bool, len(project.Tasks))
+	for _, task := range project.Tasks {
+		taskIDs[task.ID] = true
+		content.WriteString(fmt.Sprintf("    T%d[\"%d. %s\"]:::%s\n", task.ID, task.ID, graphLabel(task.Title), task.Status))
+	}
+
+	for _, task := range project.Tasks {
+		for _, depID := range task.Dependencies {
+			if taskIDs[depID] {
+				content.WriteString(fmt.Sprintf("    T%d --> T%d\n", depID, task.ID))
+			}
+		}
+	}
+
+	content.WriteString("    classDef todo fill:#eaeef2,stroke:#6e7781\n")
+	content.WriteString("    classDef in_progress fill:#ddf4ff,stroke:#0969da\n")
+	content.WriteString("    classDef done fill:#dafbe1,stroke:#1a7f37\n")
+	content.WriteString("    classDef blocked fill:#ffebe9,stroke:#cf222e\n")
+	content.WriteString("```\n")
+
+	return content.String()
+}
+
+// graphLabelEscaper escapes the quotes Mermaid would read as the end of a label,
+// and the hashes it would read as the start of an entity code such as "#12;"
+var graphLabelEscaper = strings.NewReplacer("#", "#35;", "\"", "#quot;")
+
+// graphLabel shortens a task title for a dependency graph node and escapes it
+func graphLabel(title string) string {
+	runes := []rune(title)
+	if len(runes) > maxGraphLabelLength {
+		title = strings.TrimSpace(string(runes[:maxGraphLabelLength-3])) + "..."
+	}
+	return graphLabelEscaper.Replace(title)
+}
+
+// progressPieChart returns the Mermaid source of a pie chart of completed and
+// remaining items
+func progressPieChart(completedItems, totalItems int) string {
+	var content strings.Builder
+	content.WriteString("pie title Project Progress\n")
+
+	if completedItems > 0 {
+		content.WriteString(fmt.Sprintf("    \"Completed\" : %d\n", completedItems))
+	}
+
+	remainingItems := totalItems - completedItems
+	if remainingItems > 0 {
+		content.WriteString(fmt.Sprintf("    \"Remaining\" : %d\n", remainingItems))
+	}
+
+	return content.String()
+}
+
+// GenerateChecklist renders a project as a flat GitHub-flavored markdown
+// checklist: one checkbox per task with its subtasks nested beneath, and no
+// category/priority boilerplate, suitable for pasting into a PR or issue.
+// Unfinished items that are in progress or blocked are annotated.
+func GenerateChecklist(project Project) string {
+	var content strings.Builder
+
+	for _, task := range project.Tasks {
+		content.WriteString(checklistItem("", task.Title, task.Status))
+		for _, subtask := range task.Subtasks {
+			content.WriteString(checklistItem("  ", subtask.Title, subtask.Status))
+		}
+	}
+
+	return content.String()
+}
+
+// checklistItem renders a single checkbox line
+func checklistItem(indent, title string, status TaskStatus) string {
+	box := "[ ]"
+	if status == StatusDone {
+		box = "[x]"
+	}
+
+	note := ""
+	switch status {
+	case StatusInProgress:
+		note = " _(in progress)_"
+	case StatusBlocked:
+		note = " _(blocked)_"
+	}
+
+	return fmt.Sprintf("%s- %s %s%s\n", indent, box, title, note)
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchSizes are the project sizes, in tasks, the markdown and manager
+// benchmarks run at
+var benchSizes = []int{10, 100, 1000}
+
+// benchProject returns a project of n tasks, each with subtasks, done
+// criteria and a choice so every parser branch is exercised
+func benchProject(n int) Project {
+	project := Project{Name: "bench"}
+	for i := 1; i <= n; i++ {
+		project.Tasks = append(project.Tasks, Task{
+			ID:           i,
+			Title:        fmt.Sprintf("Task number %d", i),
+			Description:  "Do the work",
+			Priority:     PriorityP1,
+			Status:       StatusTodo,
+			DoneCriteria: []string{"Tests pass", "Docs updated"},
+			Subtasks: []Subtask{
+				{Title: "First step", Status: StatusDone},
+				{Title: "Second step", Status: StatusTodo},
+			},
+			Choices: []Choice{{Question: "Which store?", Options: []string{"Files", "Database"}}},
+		})
+	}
+	return project
+}
+
+func BenchmarkParseMarkdown(b *testing.B) {
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	for _, n := range benchSizes {
+		content := m.generateMarkdown(benchProject(n))
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.parseMarkdown(content); err != nil {
+					b.Fatalf("parseMarkdown: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateMarkdown(b *testing.B) {
+	m, err := NewManager(b.TempDir())
+	if err != nil {
+		b.Fatalf("NewManager: %v", err)
+	}
+	for _, n := range benchSizes {
+		project := benchProject(n)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.generateMarkdown(project)
+			}
+		})
+	}
+}
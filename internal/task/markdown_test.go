@@ -0,0 +1,614 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestManager returns a manager storing its projects in a temporary directory
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m
+}
+
+// roundTrip renders project as markdown and parses it back
+func roundTrip(t *testing.T, m *Manager, project Project) *Project {
+	t.Helper()
+	parsed, err := m.parseMarkdown(m.generateMarkdown(project))
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	if len(parsed.Tasks) != len(project.Tasks) {
+		t.Fatalf("got %d tasks after round trip, want %d", len(parsed.Tasks), len(project.Tasks))
+	}
+	return parsed
+}
+
+// testProject returns a project holding the given tasks, numbered from 1
+func testProject(tasks ...Task) Project {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for i := range tasks {
+		tasks[i].ID = i + 1
+		if tasks[i].Status == "" {
+			tasks[i].Status = StatusTodo
+		}
+		if tasks[i].Priority == "" {
+			tasks[i].Priority = DefaultTaskPriority()
+		}
+		tasks[i].CreatedAt = now
+		tasks[i].UpdatedAt = now
+	}
+	return Project{Name: "p", CreatedAt: now, UpdatedAt: now, Tasks: tasks}
+}
+
+func TestMarkdownRoundTripSubtaskStatus(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(Task{
+		Title:  "Ship it",
+		Status: StatusInProgress,
+		Subtasks: []Subtask{
+			{Title: "Todo", Status: StatusTodo},
+			{Title: "Started", Status: StatusInProgress},
+			{Title: "Waiting", Status: StatusBlocked},
+			{Title: "Done", Status: StatusDone},
+		},
+	})
+
+	parsed := roundTrip(t, m, project)
+	for i, want := range project.Tasks[0].Subtasks {
+		if got := parsed.Tasks[0].Subtasks[i]; got.Title != want.Title || got.Status != want.Status {
+			t.Errorf("subtask %d: got %q %s, want %q %s", i, got.Title, got.Status, want.Title, want.Status)
+		}
+	}
+}
+
+func TestMarkdownCheckedSubtaskIgnoresCommentStatus(t *testing.T) {
+	m := newTestManager(t)
+	content := m.generateMarkdown(testProject(Task{
+		Title:    "Ship it",
+		Subtasks: []Subtask{{Title: "Started", Status: StatusInProgress}},
+	}))
+
+	// Ticking the box by hand leaves the old status in the comment
+	content = replaceOnce(t, content, "- [ ] Started", "- [x] Started")
+	parsed, err := m.parseMarkdown(content)
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	if got := parsed.Tasks[0].Subtasks[0].Status; got != StatusDone {
+		t.Fatalf("got status %s, want done", got)
+	}
+}
+
+// replaceOnce replaces the single occurrence of old in s
+func replaceOnce(t *testing.T, s, old, new string) string {
+	t.Helper()
+	before, after, found := strings.Cut(s, old)
+	if !found {
+		t.Fatalf("%q not found in:\n%s", old, s)
+	}
+	return before + new + after
+}
+
+func TestMarkdownRoundTripComments(t *testing.T) {
+	m := newTestManager(t)
+	at := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)
+	comments := []Comment{
+		{Author: "alice", Text: "Blocked on the API review", CreatedAt: at},
+		{Text: "Review done: ship it", CreatedAt: at.Add(time.Hour)},
+	}
+	project := testProject(
+		Task{Title: "Ship it", Comments: comments, Subtasks: []Subtask{{Title: "Tag release", Status: StatusTodo}}},
+		Task{Title: "Announce"},
+	)
+
+	parsed := roundTrip(t, m, project)
+	got := parsed.Tasks[0].Comments
+	if len(got) != len(comments) {
+		t.Fatalf("got %d comments after round trip, want %d", len(got), len(comments))
+	}
+	for i, want := range comments {
+		if got[i].Author != want.Author || got[i].Text != want.Text || !got[i].CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("comment %d: got %+v, want %+v", i, got[i], want)
+		}
+	}
+	// The Notes section ends at the next section or task
+	if subtasks := parsed.Tasks[0].Subtasks; len(subtasks) != 1 || subtasks[0].Title != "Tag release" {
+		t.Errorf("subtasks after round trip = %+v", subtasks)
+	}
+	if n := len(parsed.Tasks[1].Comments); n != 0 {
+		t.Errorf("second task has %d comments, want none", n)
+	}
+}
+
+func TestMarkdownRoundTripMultiParagraphDescription(t *testing.T) {
+	m := newTestManager(t)
+	descriptions := []string{
+		"One line",
+		"First line\nsecond line",
+		"First paragraph.\n\nSecond paragraph,\nover two lines.\n\nThird paragraph.",
+	}
+	tasks := make([]Task, len(descriptions))
+	for i, description := range descriptions {
+		tasks[i] = Task{Title: fmt.Sprintf("Task %d", i+1), Description: description}
+	}
+
+	parsed := roundTrip(t, m, testProject(tasks...))
+	for i, want := range descriptions {
+		if got := parsed.Tasks[i].Description; got != want {
+			t.Errorf("task %d description = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestMarkdownRoundTripProjectDescription(t *testing.T) {
+	m := newTestManager(t)
+	for _, want := range []string{"", "A task tracker", "Goals.\n\nBuild it,\nthen ship it."} {
+		project := testProject(Task{Title: "Ship it", Description: "Release the build"})
+		project.Description = want
+
+		parsed := roundTrip(t, m, project)
+		if got := parsed.Description; got != want {
+			t.Errorf("project description = %q, want %q", got, want)
+		}
+		if got := parsed.Tasks[0].Description; got != "Release the build" {
+			t.Errorf("with project description %q, task description = %q", want, got)
+		}
+	}
+}
+
+func TestMarkdownRoundTripAssignee(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{Title: "Ship it", Assignee: "alice", Subtasks: []Subtask{{Title: "Tag release", Status: StatusTodo}}},
+		Task{Title: "Announce"},
+		Task{Title: "Celebrate", Assignee: "Bob Smith"},
+	)
+
+	parsed := roundTrip(t, m, project)
+	for i, want := range project.Tasks {
+		if got := parsed.Tasks[i].Assignee; got != want.Assignee {
+			t.Errorf("task %q assignee = %q, want %q", want.Title, got, want.Assignee)
+		}
+	}
+	if subtasks := parsed.Tasks[0].Subtasks; len(subtasks) != 1 || subtasks[0].Title != "Tag release" {
+		t.Errorf("subtasks after round trip = %+v", subtasks)
+	}
+}
+
+func TestMarkdownRoundTripDoneCriteria(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{
+			Title:        "Ship it",
+			DoneCriteria: []string{"Changelog updated", "Tagged in git", "Announced"},
+			MetCriteria:  []string{"Tagged in git"},
+			Subtasks:     []Subtask{{Title: "Tag release", Status: StatusDone}},
+		},
+		Task{Title: "Celebrate"},
+	)
+
+	content := m.generateMarkdown(project)
+	if !strings.Contains(content, "### Definition of Done:\n- [ ] Changelog updated\n- [x] Tagged in git\n- [ ] Announced\n") {
+		t.Errorf("generated criteria section missing or wrong:\n%s", content)
+	}
+	if strings.Count(content, "Definition of Done") != 1 {
+		t.Errorf("a task without criteria got a Definition of Done section:\n%s", content)
+	}
+
+	parsed := roundTrip(t, m, project)
+	for i, want := range project.Tasks {
+		got := parsed.Tasks[i]
+		if !slices.Equal(got.DoneCriteria, want.DoneCriteria) || !slices.Equal(got.MetCriteria, want.MetCriteria) {
+			t.Errorf("task %q criteria = %v met %v, want %v met %v", want.Title, got.DoneCriteria, got.MetCriteria, want.DoneCriteria, want.MetCriteria)
+		}
+	}
+	// Criteria are kept apart from subtasks
+	if subtasks := parsed.Tasks[0].Subtasks; len(subtasks) != 1 || subtasks[0].Title != "Tag release" {
+		t.Errorf("subtasks after round trip = %+v", subtasks)
+	}
+
+	// Criteria written by hand without a checkbox are read as not yet met
+	content = replaceOnce(t, content, "- [ ] Announced", "- Announced")
+	parsed, err := m.parseMarkdown(content)
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	if got := parsed.Tasks[0]; !slices.Equal(got.DoneCriteria, project.Tasks[0].DoneCriteria) || got.IsCriterionAcknowledged("Announced") {
+		t.Errorf("plain criterion: criteria = %v met %v", got.DoneCriteria, got.MetCriteria)
+	}
+}
+
+func TestMarkdownRoundTripGeneralCategory(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{Title: "Uncategorized"},
+		Task{Title: "Explicitly general", Category: CategoryGeneral},
+		Task{Title: "Themed", Category: CategoryUX},
+	)
+
+	content := m.generateMarkdown(project)
+	for _, header := range []string{"1: [GENERAL] Uncategorized", "2: [GENERAL] Explicitly general", "3: [UX] Themed"} {
+		if !strings.Contains(content, header) {
+			t.Errorf("generated markdown lacks %q:\n%s", header, content)
+		}
+	}
+
+	parsed := roundTrip(t, m, project)
+	want := []TaskCategory{"", "", CategoryUX}
+	for i, task := range parsed.Tasks {
+		if task.Category != want[i] {
+			t.Errorf("task %q category = %q, want %q", task.Title, task.Category, want[i])
+		}
+		// What the file shows must pass validation, so tools can set it back
+		if _, err := ValidateTaskCategory(string(task.EffectiveCategory())); err != nil {
+			t.Errorf("task %q: effective category %q fails validation: %v", task.Title, task.EffectiveCategory(), err)
+		}
+	}
+
+	// A second round trip leaves the file unchanged
+	if again := m.generateMarkdown(*parsed); again != content {
+		t.Errorf("second round trip changed the file:\n%s\nwant:\n%s", again, content)
+	}
+}
+
+func TestMarkdownRoundTripCompletedAt(t *testing.T) {
+	m := newTestManager(t)
+	completed := time.Date(2024, 5, 3, 16, 45, 0, 0, time.UTC)
+	project := testProject(Task{
+		Title:       "Ship it",
+		Status:      StatusDone,
+		CompletedAt: &completed,
+		Subtasks:    []Subtask{{Title: "Step", Status: StatusDone, CompletedAt: &completed}},
+	})
+
+	parsed := roundTrip(t, m, project)
+	if got := parsed.Tasks[0].CompletedAt; got == nil || !got.Equal(completed) {
+		t.Errorf("task CompletedAt = %v, want %v", got, completed)
+	}
+	if got := parsed.Tasks[0].Subtasks[0].CompletedAt; got == nil || !got.Equal(completed) {
+		t.Errorf("subtask CompletedAt = %v, want %v", got, completed)
+	}
+}
+
+func TestMarkdownRoundTripCreatedUpdatedAt(t *testing.T) {
+	m := newTestManager(t)
+	created := time.Date(2024, 4, 2, 8, 30, 0, 0, time.UTC)
+	updated := time.Date(2024, 4, 9, 17, 5, 12, 0, time.FixedZone("CEST", 2*60*60))
+	subtaskCreated := created.Add(time.Hour)
+	subtaskUpdated := updated.Add(-time.Hour)
+
+	project := testProject(Task{Title: "Ship it", Subtasks: []Subtask{
+		{Title: "Step", Status: StatusInProgress, CreatedAt: subtaskCreated, UpdatedAt: subtaskUpdated},
+	}})
+	project.Tasks[0].CreatedAt = created
+	project.Tasks[0].UpdatedAt = updated
+
+	parsed := roundTrip(t, m, project)
+	got := parsed.Tasks[0]
+	if !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(updated) {
+		t.Errorf("task times = %s, %s; want %s, %s", got.CreatedAt, got.UpdatedAt, created, updated)
+	}
+	if sub := got.Subtasks[0]; !sub.CreatedAt.Equal(subtaskCreated) || !sub.UpdatedAt.Equal(subtaskUpdated) {
+		t.Errorf("subtask times = %s, %s; want %s, %s", sub.CreatedAt, sub.UpdatedAt, subtaskCreated, subtaskUpdated)
+	}
+
+	// Files written before timestamps were stored get the parse time
+	content := m.generateMarkdown(project)
+	var legacy []string
+	for _, line := range strings.Split(content, "\n") {
+		if !strings.Contains(line, "<!--") {
+			legacy = append(legacy, line)
+		}
+	}
+	before := time.Now()
+	parsed, err := m.parseMarkdown(strings.Join(legacy, "\n"))
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	after := time.Now()
+	within := func(ts time.Time) bool { return !ts.Before(before) && !ts.After(after) }
+	if got := parsed.Tasks[0]; !within(got.CreatedAt) || !within(got.UpdatedAt) ||
+		!within(got.Subtasks[0].CreatedAt) || !within(got.Subtasks[0].UpdatedAt) {
+		t.Errorf("legacy times = task %s, %s; subtask %s, %s; want the parse time",
+			got.CreatedAt, got.UpdatedAt, got.Subtasks[0].CreatedAt, got.Subtasks[0].UpdatedAt)
+	}
+}
+
+func TestGenerateChecklist(t *testing.T) {
+	project := testProject(
+		Task{Title: "Design API", Status: StatusDone, Subtasks: []Subtask{
+			{Title: "Endpoints", Status: StatusDone},
+			{Title: "Errors", Status: StatusDone},
+		}},
+		Task{Title: "Build API", Status: StatusInProgress, Subtasks: []Subtask{
+			{Title: "Handlers", Status: StatusDone},
+			{Title: "Auth", Status: StatusBlocked},
+			{Title: "Tests", Status: StatusTodo},
+		}},
+		Task{Title: "Deploy [prod]", Status: StatusBlocked},
+		Task{Title: "Announce"},
+	)
+
+	want := `- [x] Design API
+  - [x] Endpoints
+  - [x] Errors
+- [ ] Build API _(in progress)_
+  - [x] Handlers
+  - [ ] Auth _(blocked)_
+  - [ ] Tests
+- [ ] Deploy [prod] _(blocked)_
+- [ ] Announce
+`
+	if got := GenerateChecklist(project); got != want {
+		t.Errorf("GenerateChecklist =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := GenerateChecklist(Project{Name: "empty"}); got != "" {
+		t.Errorf("GenerateChecklist of an empty project = %q, want empty", got)
+	}
+}
+
+// richTestProject returns a project using every task field the markdown format stores
+func richTestProject() Project {
+	resolved := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	return testProject(
+		Task{
+			Title:          "Design schema",
+			Description:    "Tables and indexes",
+			Category:       CategoryMVP,
+			Priority:       PriorityP0,
+			Status:         StatusDone,
+			Complexity:     ComplexityHigh,
+			EstimatedHours: 6,
+			Assignee:       "dana",
+			Tags:           []string{"backend", "db"},
+			DoneCriteria:   []string{"Migrations run", "Reviewed"},
+			MetCriteria:    []string{"Migrations run"},
+			Subtasks:       []Subtask{{Title: "Users table", Status: StatusDone}, {Title: "Indexes", Status: StatusDone}},
+			Choices: []Choice{{
+				Question:   "Which database?",
+				Options:    []string{"Postgres", "SQLite"},
+				Selected:   "Postgres",
+				Reasoning:  "Needs concurrent writers",
+				ResolvedAt: &resolved,
+			}},
+		},
+		Task{
+			Title:         "Write handlers",
+			Description:   "HTTP endpoints",
+			Priority:      PriorityP1,
+			Status:        StatusBlocked,
+			BlockedReason: "Waiting on schema review",
+			Dependencies:  []int{1},
+			Subtasks:      []Subtask{{Title: "List", Status: StatusInProgress}, {Title: "Create", Status: StatusTodo}},
+			Choices:       []Choice{{Question: "Pagination style?", Options: []string{"Cursor", "Offset"}}},
+		},
+	)
+}
+
+// checkRichRoundTrip compares a parsed project against richTestProject
+func checkRichRoundTrip(t *testing.T, got *Project) {
+	t.Helper()
+	want := richTestProject()
+	if len(got.Tasks) != len(want.Tasks) {
+		t.Fatalf("got %d tasks, want %d", len(got.Tasks), len(want.Tasks))
+	}
+	for i := range want.Tasks {
+		g, w := got.Tasks[i], want.Tasks[i]
+		if g.ID != w.ID || g.Title != w.Title || g.Description != w.Description || g.Category != w.Category ||
+			g.Priority != w.Priority || g.Status != w.Status || g.BlockedReason != w.BlockedReason ||
+			g.Complexity != w.Complexity || g.EstimatedHours != w.EstimatedHours || g.Assignee != w.Assignee {
+			t.Errorf("task %d fields:\n got %+v\nwant %+v", w.ID, g, w)
+		}
+		if !slices.Equal(g.Tags, w.Tags) || !slices.Equal(g.Dependencies, w.Dependencies) ||
+			!slices.Equal(g.DoneCriteria, w.DoneCriteria) || !slices.Equal(g.MetCriteria, w.MetCriteria) {
+			t.Errorf("task %d lists: tags %v deps %v criteria %v met %v", w.ID, g.Tags, g.Dependencies, g.DoneCriteria, g.MetCriteria)
+		}
+		if len(g.Subtasks) != len(w.Subtasks) {
+			t.Fatalf("task %d has %d subtasks, want %d", w.ID, len(g.Subtasks), len(w.Subtasks))
+		}
+		for j := range w.Subtasks {
+			if g.Subtasks[j].Title != w.Subtasks[j].Title || g.Subtasks[j].Status != w.Subtasks[j].Status {
+				t.Errorf("task %d subtask %d = %+v, want %+v", w.ID, j, g.Subtasks[j], w.Subtasks[j])
+			}
+		}
+		if len(g.Choices) != len(w.Choices) {
+			t.Fatalf("task %d has %d choices, want %d", w.ID, len(g.Choices), len(w.Choices))
+		}
+		for j := range w.Choices {
+			gc, wc := g.Choices[j], w.Choices[j]
+			if gc.Question != wc.Question || !slices.Equal(gc.Options, wc.Options) || gc.Selected != wc.Selected ||
+				gc.Reasoning != wc.Reasoning || (gc.ResolvedAt == nil) != (wc.ResolvedAt == nil) {
+				t.Errorf("task %d choice %d = %+v, want %+v", w.ID, j, gc, wc)
+			}
+		}
+	}
+}
+
+func TestMarkdownRoundTripHeadingLevels(t *testing.T) {
+	for level := 2; level <= 5; level++ {
+		t.Run(fmt.Sprintf("level %d", level), func(t *testing.T) {
+			m, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{HeadingLevel: level})
+			if err != nil {
+				t.Fatalf("NewManagerWithConfig: %v", err)
+			}
+			content := m.generateMarkdown(richTestProject())
+			taskHeading := strings.Repeat("#", level) + " Task 1:"
+			sectionHeading := strings.Repeat("#", level+1) + " Subtasks:"
+			if !strings.Contains(content, "\n"+taskHeading) || !strings.Contains(content, "\n"+sectionHeading) {
+				t.Fatalf("level %d output lacks %q or %q:\n%s", level, taskHeading, sectionHeading, content)
+			}
+
+			parsed, err := m.parseMarkdown(content)
+			if err != nil {
+				t.Fatalf("parseMarkdown: %v", err)
+			}
+			checkRichRoundTrip(t, parsed)
+		})
+	}
+
+	for _, level := range []int{1, 6} {
+		if _, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{HeadingLevel: level}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("heading level %d = %v, want ErrInvalidInput", level, err)
+		}
+	}
+}
+
+func TestMarkdownRoundTripCustomLabels(t *testing.T) {
+	labels := MarkdownLabels{
+		Task:           "Aufgabe",
+		Subtasks:       "Teilaufgaben",
+		Choices:        "Entscheidungen",
+		Choice:         "Entscheidung",
+		Options:        "Optionen",
+		Reasoning:      "Begründung",
+		Tags:           "Schlagworte",
+		Dependencies:   "Abhängigkeiten",
+		DoneCriteria:   "Fertig wenn",
+		BlockedReason:  "Blockiert weil",
+		Assignee:       "Zuständig",
+		Complexity:     "Aufwand",
+		EstimatedHours: "Geschätzte Stunden",
+	}
+	m, err := NewManagerWithConfig(t.TempDir(), ManagerConfig{Labels: labels})
+	if err != nil {
+		t.Fatalf("NewManagerWithConfig: %v", err)
+	}
+
+	content := m.generateMarkdown(richTestProject())
+	for _, english := range []string{"## Task ", "### Subtasks", "### Choices", "**Choice:**", "Reasoning:", "Tags:", "Blocked reason"} {
+		if strings.Contains(content, english) {
+			t.Errorf("output still uses the default label %q:\n%s", english, content)
+		}
+	}
+	if !strings.Contains(content, "## Aufgabe 1:") || !strings.Contains(content, "### Teilaufgaben:") {
+		t.Fatalf("output lacks the custom labels:\n%s", content)
+	}
+
+	parsed, err := m.parseMarkdown(content)
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	checkRichRoundTrip(t, parsed)
+
+	// A manager with the default labels doesn't recognize the tasks
+	if parsed, err := newTestManager(t).parseMarkdown(content); err == nil && len(parsed.Tasks) != 0 {
+		t.Errorf("default labels parsed %d tasks from a file with custom labels", len(parsed.Tasks))
+	}
+}
+
+func TestMarkdownRoundTripSubtaskDetails(t *testing.T) {
+	m := newTestManager(t)
+	subtasks := []Subtask{
+		{Title: "Migration", Status: StatusDone, EstimatedHours: 3, Complexity: ComplexityMedium, Description: "Add the users table"},
+		{Title: "Hours only", Status: StatusTodo, EstimatedHours: 12},
+		{Title: "Complexity only", Status: StatusInProgress, Complexity: ComplexityHigh},
+		// A description that reads like the estimate line stays a description
+		{Title: "Tricky", Status: StatusTodo, Description: "hours: 5, complexity: low"},
+		{Title: "Plain", Status: StatusTodo},
+	}
+	project := testProject(Task{
+		Title:    "Ship it",
+		Subtasks: subtasks,
+		Choices:  []Choice{{Question: "Which host?", Options: []string{"VM", "PaaS"}}},
+	})
+
+	check := func(t *testing.T, got []Subtask) {
+		t.Helper()
+		if len(got) != len(subtasks) {
+			t.Fatalf("got %d subtasks, want %d: %+v", len(got), len(subtasks), got)
+		}
+		for i, want := range subtasks {
+			g := got[i]
+			if g.Title != want.Title || g.Status != want.Status || g.EstimatedHours != want.EstimatedHours ||
+				g.Complexity != want.Complexity || g.Description != want.Description {
+				t.Errorf("subtask %d:\n got %+v\nwant %+v", i, g, want)
+			}
+		}
+	}
+
+	parsed := roundTrip(t, m, project)
+	check(t, parsed.Tasks[0].Subtasks)
+	if choices := parsed.Tasks[0].Choices; len(choices) != 1 || len(choices[0].Options) != 2 {
+		t.Errorf("choices after subtask details = %+v", choices)
+	}
+
+	// Through a save and load by the manager too
+	if err := m.CreateProject("p"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := m.AddTasks("p", project.Tasks); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+	m.InvalidateCache("p")
+	loaded, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	check(t, loaded.Tasks[0].Subtasks)
+
+	// Descriptions are written on one line
+	project.Tasks[0].Subtasks = []Subtask{{Title: "Multi", Status: StatusTodo, Description: "First line\n  second   line"}}
+	if got := roundTrip(t, m, project).Tasks[0].Subtasks[0].Description; got != "First line second line" {
+		t.Errorf("multi-line description = %q, want it joined on one line", got)
+	}
+}
+
+func TestMarkdownRoundTripSubtaskChoices(t *testing.T) {
+	resolved := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	project := testProject(Task{
+		Title:       "Add caching",
+		Description: "Cache hot reads",
+		Subtasks: []Subtask{
+			{Title: "Pick a store", Status: StatusDone, Choices: []Choice{{
+				ID: "choice_1", Question: "Which store?", Options: []string{"Redis", "Memcached"},
+				Selected: "Redis", Reasoning: "Already deployed", ResolvedAt: &resolved,
+			}}},
+			{Title: "Set expiry", Status: StatusTodo, EstimatedHours: 2, Choices: []Choice{
+				{ID: "choice_2", Question: "Expire by?", Options: []string{"TTL", "Event"}},
+				{ID: "choice_3", Question: "Warm on start?", Options: []string{"Yes", "No"}},
+			}},
+			{Title: "Measure hit rate", Status: StatusTodo},
+		},
+		Choices: []Choice{{ID: "choice_4", Question: "Cache writes too?", Options: []string{"Yes", "No"}}},
+	})
+
+	m := newTestManager(t)
+	got := roundTrip(t, m, project).Tasks[0]
+	var titles []string
+	for _, subtask := range got.Subtasks {
+		titles = append(titles, subtask.Title)
+	}
+	if want := []string{"Pick a store", "Set expiry", "Measure hit rate"}; !slices.Equal(titles, want) {
+		t.Fatalf("subtasks = %v, want %v", titles, want)
+	}
+	if got.Subtasks[1].EstimatedHours != 2 || got.Subtasks[0].Status != StatusDone {
+		t.Errorf("subtask fields changed: %+v", got.Subtasks)
+	}
+
+	for i, subtask := range project.Tasks[0].Subtasks {
+		if len(got.Subtasks[i].Choices) != len(subtask.Choices) {
+			t.Errorf("subtask '%s' has %d choices, want %d", subtask.Title, len(got.Subtasks[i].Choices), len(subtask.Choices))
+			continue
+		}
+		for j, want := range subtask.Choices {
+			gc := got.Subtasks[i].Choices[j]
+			if gc.ID != want.ID || gc.Question != want.Question || !slices.Equal(gc.Options, want.Options) ||
+				gc.Selected != want.Selected || gc.Reasoning != want.Reasoning || (gc.ResolvedAt == nil) != (want.ResolvedAt == nil) {
+				t.Errorf("subtask '%s' choice %d = %+v, want %+v", subtask.Title, j, gc, want)
+			}
+		}
+	}
+	if len(got.Choices) != 1 || got.Choices[0].ID != "choice_4" || len(got.Choices[0].Options) != 2 {
+		t.Errorf("task choices = %+v, want only 'Cache writes too?'", got.Choices)
+	}
+}
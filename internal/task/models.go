@@ -1,6 +1,7 @@
 package task
 
 import (
+	"strings"
 	"time"
 )
 
@@ -22,8 +23,18 @@ const (
 	CategoryAI    TaskCategory = "[AI]"
 	CategoryUX    TaskCategory = "[UX]"
 	CategoryInfra TaskCategory = "[INFRA]"
+
+	// CategoryGeneral is written for tasks without a themed category. It is the
+	// "no category" sentinel: it is read back as an empty category.
+	CategoryGeneral TaskCategory = "[GENERAL]"
 )
 
+// AllCategories returns every category in the order they are listed in
+// markdown files, with CategoryGeneral last
+func AllCategories() []TaskCategory {
+	return []TaskCategory{CategoryMVP, CategoryAI, CategoryUX, CategoryInfra, CategoryGeneral}
+}
+
 // TaskPriority represents the priority level of a task
 type TaskPriority string
 
@@ -43,6 +54,18 @@ const (
 	ComplexityHigh   TaskComplexity = "high"
 )
 
+// complexityRank orders complexity levels from lowest to highest
+var complexityRank = map[TaskComplexity]int{
+	ComplexityLow:    1,
+	ComplexityMedium: 2,
+	ComplexityHigh:   3,
+}
+
+// AtLeast reports whether c is as complex as minimum or more
+func (c TaskComplexity) AtLeast(minimum TaskComplexity) bool {
+	return complexityRank[c] >= complexityRank[minimum]
+}
+
 // Choice represents a choice that needs to be made for a task
 type Choice struct {
 	ID         string     `json:"id"`
@@ -54,6 +77,14 @@ type Choice struct {
 	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
 }
 
+// Comment is a dated note on a task, such as progress or the reasoning behind
+// a change, recorded by an agent or a person
+type Comment struct {
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Subtask represents a subtask within a task
 type Subtask struct {
 	Title          string         `json:"title"`
@@ -64,6 +95,7 @@ type Subtask struct {
 	Choices        []Choice       `json:"choices,omitempty"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	CompletedAt    *time.Time     `json:"completed_at,omitempty"`
 }
 
 // Task represents a main task
@@ -74,13 +106,20 @@ type Task struct {
 	Category       TaskCategory   `json:"category,omitempty"`
 	Priority       TaskPriority   `json:"priority"`
 	Status         TaskStatus     `json:"status"`
+	BlockedReason  string         `json:"blocked_reason,omitempty"`
 	Complexity     TaskComplexity `json:"complexity,omitempty"`
 	EstimatedHours int            `json:"estimated_hours,omitempty"`
+	Assignee       string         `json:"assignee,omitempty"`
 	Dependencies   []int          `json:"dependencies,omitempty"`
+	Tags           []string       `json:"tags,omitempty"`
+	DoneCriteria   []string       `json:"done_criteria,omitempty"`
+	MetCriteria    []string       `json:"met_criteria,omitempty"`
 	Subtasks       []Subtask      `json:"subtasks,omitempty"`
 	Choices        []Choice       `json:"choices,omitempty"`
+	Comments       []Comment      `json:"comments,omitempty"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	CompletedAt    *time.Time     `json:"completed_at,omitempty"`
 }
 
 // Project represents a project containing multiple tasks
@@ -90,6 +129,16 @@ type Project struct {
 	Tasks       []Task    `json:"tasks"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// loadedVersion identifies the file contents this project was loaded from,
+	// so a save can detect edits made to the file in the meantime
+	loadedVersion fileVersion
+}
+
+// fileVersion identifies a version of a project file by modification time and size
+type fileVersion struct {
+	modTime time.Time
+	size    int64
 }
 
 // ComplexityAnalysis represents complexity analysis data provided by the calling LLM
@@ -108,6 +157,67 @@ type TaskFilter struct {
 	Category   *TaskCategory   `json:"category,omitempty"`
 	Priority   *TaskPriority   `json:"priority,omitempty"`
 	Complexity *TaskComplexity `json:"complexity,omitempty"`
+	// Assignee matches the task's assignee ignoring case
+	Assignee *string `json:"assignee,omitempty"`
+	// MinProgress and MaxProgress bound the task's progress as returned by
+	// GetProgress (inclusive)
+	MinProgress *float64 `json:"min_progress,omitempty"`
+	MaxProgress *float64 `json:"max_progress,omitempty"`
+	// Tags selects tasks carrying any of the tags, or all of them when MatchAllTags is set
+	Tags         []string `json:"tags,omitempty"`
+	MatchAllTags bool     `json:"match_all_tags,omitempty"`
+}
+
+// Matches reports whether a task satisfies every non-nil field of the filter
+func (f TaskFilter) Matches(t *Task) bool {
+	if f.Status != nil && t.Status != *f.Status {
+		return false
+	}
+	if f.Category != nil && t.EffectiveCategory() != *f.Category {
+		return false
+	}
+	if f.Priority != nil && t.Priority != *f.Priority {
+		return false
+	}
+	if f.Complexity != nil && t.Complexity != *f.Complexity {
+		return false
+	}
+	if f.Assignee != nil && !strings.EqualFold(t.Assignee, *f.Assignee) {
+		return false
+	}
+	if f.MinProgress != nil || f.MaxProgress != nil {
+		progress := t.GetProgress()
+		if f.MinProgress != nil && progress < *f.MinProgress {
+			return false
+		}
+		if f.MaxProgress != nil && progress > *f.MaxProgress {
+			return false
+		}
+	}
+	if len(f.Tags) > 0 && !f.matchesTags(t) {
+		return false
+	}
+	return true
+}
+
+// matchesTags reports whether the task carries any of the filter's tags, or all of them with MatchAllTags
+func (f TaskFilter) matchesTags(t *Task) bool {
+	for _, tag := range f.Tags {
+		hasTag := t.HasTag(tag)
+		if hasTag && !f.MatchAllTags {
+			return true
+		}
+		if !hasTag && f.MatchAllTags {
+			return false
+		}
+	}
+	return f.MatchAllTags
+}
+
+// IsEmpty reports whether the filter has no criteria set
+func (f TaskFilter) IsEmpty() bool {
+	return f.Status == nil && f.Category == nil && f.Priority == nil && f.Complexity == nil && f.Assignee == nil &&
+		f.MinProgress == nil && f.MaxProgress == nil && len(f.Tags) == 0
 }
 
 // AttentionType represents the type of attention a task needs
@@ -129,18 +239,30 @@ type TaskAttention struct {
 	Severity int           `json:"severity"` // 1-5, 5 being most urgent
 }
 
+// ValidationIssue describes a structural problem found in a project file
+type ValidationIssue struct {
+	TaskID    int    `json:"task_id,omitempty"`
+	TaskTitle string `json:"task_title,omitempty"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+}
+
 // TaskSummary provides a summary view of a task for LLM consumption
 type TaskSummary struct {
 	ID                int            `json:"id"`
 	Title             string         `json:"title"`
 	Status            TaskStatus     `json:"status"`
+	BlockedReason     string         `json:"blocked_reason,omitempty"`
 	Category          TaskCategory   `json:"category,omitempty"`
 	Priority          TaskPriority   `json:"priority"`
 	Complexity        TaskComplexity `json:"complexity,omitempty"`
 	EstimatedHours    int            `json:"estimated_hours,omitempty"`
+	Assignee          string         `json:"assignee,omitempty"`
 	SubtaskCount      int            `json:"subtask_count"`
 	CompletedSubtasks int            `json:"completed_subtasks"`
 	PendingChoices    int            `json:"pending_choices"`
+	CommentCount      int            `json:"comment_count"`
+	Tags              []string       `json:"tags,omitempty"`
 }
 
 // ProjectSummary provides a summary view of a project
@@ -154,6 +276,16 @@ type ProjectSummary struct {
 	UpdatedAt      time.Time     `json:"updated_at"`
 }
 
+// ActivityItem represents a recently touched task or subtask
+type ActivityItem struct {
+	ProjectName  string     `json:"project_name"`
+	TaskID       int        `json:"task_id"`
+	TaskTitle    string     `json:"task_title"`
+	SubtaskTitle string     `json:"subtask_title,omitempty"`
+	Status       TaskStatus `json:"status"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
 // ChoiceRequest represents a request for the LLM to make a choice
 type ChoiceRequest struct {
 	ProjectName  string `json:"project_name"`
@@ -162,6 +294,79 @@ type ChoiceRequest struct {
 	Choice       Choice `json:"choice"`
 }
 
+// SetStatus changes the subtask status, bumping UpdatedAt and recording
+// CompletedAt on completion (or clearing it when the subtask is reopened)
+func (s *Subtask) SetStatus(status TaskStatus) {
+	now := time.Now().UTC()
+	s.CompletedAt = completionTime(s.CompletedAt, status, now)
+	s.Status = status
+	s.UpdatedAt = now
+}
+
+// SetStatus changes the task status, bumping UpdatedAt and recording
+// CompletedAt on completion (or clearing it when the task is reopened). The
+// blocked reason is cleared when the task is no longer blocked.
+func (t *Task) SetStatus(status TaskStatus) {
+	now := time.Now().UTC()
+	t.CompletedAt = completionTime(t.CompletedAt, status, now)
+	t.Status = status
+	t.UpdatedAt = now
+	if status != StatusBlocked {
+		t.BlockedReason = ""
+	}
+}
+
+// completionTime returns the completion timestamp an item should carry after moving to status
+func completionTime(current *time.Time, status TaskStatus, now time.Time) *time.Time {
+	if status != StatusDone {
+		return nil
+	}
+	if current != nil {
+		return current
+	}
+	return &now
+}
+
+// EffectiveCategory returns the task's category, or CategoryGeneral when it has none
+func (t *Task) EffectiveCategory() TaskCategory {
+	if t.Category == "" {
+		return CategoryGeneral
+	}
+	return t.Category
+}
+
+// HasTag reports whether the task carries the given tag (case-insensitive)
+func (t *Task) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds a tag to the task, returning false if it was already present
+func (t *Task) AddTag(tag string) bool {
+	if t.HasTag(tag) {
+		return false
+	}
+	t.Tags = append(t.Tags, NormalizeTag(tag))
+	return true
+}
+
+// RemoveTag removes a tag from the task, returning false if it was not present
+func (t *Task) RemoveTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for i, existing := range t.Tags {
+		if existing == tag {
+			t.Tags = append(t.Tags[:i], t.Tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Helper methods for Task
 func (t *Task) IsCompleted() bool {
 	return t.Status == StatusDone
@@ -213,6 +418,102 @@ func (t *Task) GetSubtaskProgress() (completed int, total int, percentage float6
 	return completed, total, percentage
 }
 
+// GetProgress returns the task's completion percentage: the share of its
+// subtasks that are done, or for a task without subtasks 100 when it is done
+// and 0 otherwise
+func (t *Task) GetProgress() float64 {
+	if len(t.Subtasks) == 0 {
+		if t.Status == StatusDone {
+			return 100.0
+		}
+		return 0.0
+	}
+
+	_, _, percentage := t.GetSubtaskProgress()
+	return percentage
+}
+
+// DefaultCriteriaWeight is the share of a task's completion contributed by its
+// done criteria when it has both subtasks and criteria
+const DefaultCriteriaWeight = 0.3
+
+// IsCriterionAcknowledged reports whether a done criterion has been confirmed as met
+func (t *Task) IsCriterionAcknowledged(criterion string) bool {
+	for _, acknowledged := range t.MetCriteria {
+		if acknowledged == criterion {
+			return true
+		}
+	}
+	return false
+}
+
+// AcknowledgeCriterion marks a done criterion as met (or not met), returning
+// false if the task has no such criterion
+func (t *Task) AcknowledgeCriterion(criterion string, acknowledged bool) bool {
+	found := false
+	for _, existing := range t.DoneCriteria {
+		if existing == criterion {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	remaining := []string{}
+	for _, existing := range t.MetCriteria {
+		if existing != criterion {
+			remaining = append(remaining, existing)
+		}
+	}
+	if acknowledged {
+		remaining = append(remaining, criterion)
+	}
+	t.MetCriteria = remaining
+	return true
+}
+
+// GetCriteriaProgress returns acknowledgment progress for done criteria
+func (t *Task) GetCriteriaProgress() (acknowledged int, total int, percentage float64) {
+	total = len(t.DoneCriteria)
+	if total == 0 {
+		return 0, 0, 100.0 // No criteria means nothing left to acknowledge
+	}
+
+	for _, criterion := range t.DoneCriteria {
+		if t.IsCriterionAcknowledged(criterion) {
+			acknowledged++
+		}
+	}
+	percentage = float64(acknowledged) / float64(total) * 100.0
+	return acknowledged, total, percentage
+}
+
+// GetTaskCompletion returns the task's completion percentage. With both
+// subtasks and done criteria it blends the two, giving criteria the share
+// criteriaWeight (0-1) and subtasks the rest; with only one of them it is that
+// one's progress, and with neither it is 100 when the task is done and 0 otherwise.
+func (t *Task) GetTaskCompletion(criteriaWeight float64) float64 {
+	hasSubtasks := len(t.Subtasks) > 0
+	hasCriteria := len(t.DoneCriteria) > 0
+	_, _, subtaskProgress := t.GetSubtaskProgress()
+	_, _, criteriaProgress := t.GetCriteriaProgress()
+
+	switch {
+	case hasSubtasks && hasCriteria:
+		return subtaskProgress*(1-criteriaWeight) + criteriaProgress*criteriaWeight
+	case hasSubtasks:
+		return subtaskProgress
+	case hasCriteria:
+		return criteriaProgress
+	case t.Status == StatusDone:
+		return 100.0
+	default:
+		return 0.0
+	}
+}
+
 func (t *Task) HasPendingChoices() bool {
 	for _, choice := range t.Choices {
 		if choice.ResolvedAt == nil {
@@ -229,6 +530,68 @@ func (t *Task) HasPendingChoices() bool {
 	return false
 }
 
+// FindChoice returns the task's or one of its subtasks' choice with the given
+// ID, or nil when there is none
+func (t *Task) FindChoice(id string) *Choice {
+	for i := range t.Choices {
+		if t.Choices[i].ID == id {
+			return &t.Choices[i]
+		}
+	}
+	for i := range t.Subtasks {
+		for j := range t.Subtasks[i].Choices {
+			if t.Subtasks[i].Choices[j].ID == id {
+				return &t.Subtasks[i].Choices[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ResolvedChoices returns the task's resolved choices, followed by those of its subtasks
+func (t *Task) ResolvedChoices() []Choice {
+	resolved := []Choice{}
+	for _, choice := range t.Choices {
+		if choice.ResolvedAt != nil {
+			resolved = append(resolved, choice)
+		}
+	}
+	for _, subtask := range t.Subtasks {
+		for _, choice := range subtask.Choices {
+			if choice.ResolvedAt != nil {
+				resolved = append(resolved, choice)
+			}
+		}
+	}
+	return resolved
+}
+
+// WithoutResolvedChoices returns a copy of the task keeping only pending choices
+// on the task and its subtasks
+func (t *Task) WithoutResolvedChoices() Task {
+	pendingOnly := func(choices []Choice) []Choice {
+		var pending []Choice
+		for _, choice := range choices {
+			if choice.ResolvedAt == nil {
+				pending = append(pending, choice)
+			}
+		}
+		return pending
+	}
+
+	copied := *t
+	copied.Choices = pendingOnly(t.Choices)
+	copied.Subtasks = make([]Subtask, len(t.Subtasks))
+	for i, subtask := range t.Subtasks {
+		subtask.Choices = pendingOnly(subtask.Choices)
+		copied.Subtasks[i] = subtask
+	}
+	if t.Subtasks == nil {
+		copied.Subtasks = nil
+	}
+	return copied
+}
+
 func (t *Task) GetCompletedSubtaskCount() int {
 	count := 0
 	for _, subtask := range t.Subtasks {
@@ -260,13 +623,17 @@ func (t *Task) ToSummary() TaskSummary {
 		ID:                t.ID,
 		Title:             t.Title,
 		Status:            t.Status,
+		BlockedReason:     t.BlockedReason,
 		Category:          t.Category,
 		Priority:          t.Priority,
 		Complexity:        t.Complexity,
 		EstimatedHours:    t.EstimatedHours,
+		Assignee:          t.Assignee,
 		SubtaskCount:      len(t.Subtasks),
 		CompletedSubtasks: t.GetCompletedSubtaskCount(),
 		PendingChoices:    pendingChoices,
+		CommentCount:      len(t.Comments),
+		Tags:              t.Tags,
 	}
 }
 
@@ -323,17 +690,146 @@ func (p *Project) GetProgressSummary() map[string]interface{} {
 	totalItems := p.GetTotalItemCount()
 	completedItems := p.GetCompletedItemCount()
 
+	// Avoid NaN (which JSON can't encode) for projects without tasks
+	taskProgress := 0.0
+	if totalTasks > 0 {
+		taskProgress = float64(completedTasks) / float64(totalTasks) * 100
+	}
+
 	return map[string]interface{}{
 		"total_tasks":      totalTasks,
 		"completed_tasks":  completedTasks,
 		"total_items":      totalItems,
 		"completed_items":  completedItems,
-		"task_progress":    float64(completedTasks) / float64(totalTasks) * 100,
+		"task_progress":    taskProgress,
 		"overall_progress": p.GetProgressPercentage(),
 		"pending_choices":  p.GetPendingChoicesCount(),
 	}
 }
 
+// CategoryProgress counts a category's tasks and how many of them are completed
+type CategoryProgress struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+// GetCategoryBreakdown returns the task and completed task counts of each
+// category with tasks. Tasks without a category count toward CategoryGeneral.
+func (p *Project) GetCategoryBreakdown() map[TaskCategory]CategoryProgress {
+	breakdown := make(map[TaskCategory]CategoryProgress)
+	for _, t := range p.Tasks {
+		category := t.EffectiveCategory()
+		progress := breakdown[category]
+		progress.Total++
+		if t.IsCompleted() {
+			progress.Completed++
+		}
+		breakdown[category] = progress
+	}
+	return breakdown
+}
+
+// CompletionStats summarizes the work that went into a project
+type CompletionStats struct {
+	TotalTasks          int        `json:"total_tasks"`
+	TotalSubtasks       int        `json:"total_subtasks"`
+	TotalEstimatedHours int        `json:"total_estimated_hours"`
+	StartedAt           *time.Time `json:"started_at,omitempty"`
+	FinishedAt          *time.Time `json:"finished_at,omitempty"`
+	Duration            string     `json:"duration,omitempty"`
+}
+
+// GetCompletionStats returns task counts, total estimated hours and the span
+// from the first task created to the last task completed. Tasks completed
+// before completion times were recorded fall back to their UpdatedAt.
+func (p *Project) GetCompletionStats() CompletionStats {
+	stats := CompletionStats{TotalTasks: len(p.Tasks)}
+
+	var started, finished time.Time
+	for _, t := range p.Tasks {
+		stats.TotalSubtasks += len(t.Subtasks)
+		stats.TotalEstimatedHours += t.EstimatedHours
+
+		if !t.CreatedAt.IsZero() && (started.IsZero() || t.CreatedAt.Before(started)) {
+			started = t.CreatedAt
+		}
+
+		completed := t.UpdatedAt
+		if t.CompletedAt != nil {
+			completed = *t.CompletedAt
+		}
+		if completed.After(finished) {
+			finished = completed
+		}
+	}
+
+	if !started.IsZero() {
+		stats.StartedAt = &started
+	}
+	if !finished.IsZero() {
+		stats.FinishedAt = &finished
+	}
+	if !started.IsZero() && finished.After(started) {
+		stats.Duration = finished.Sub(started).Round(time.Minute).String()
+	}
+
+	return stats
+}
+
+// PriorityWeights maps each priority to how much its tasks count toward weighted progress
+type PriorityWeights map[TaskPriority]float64
+
+// DefaultPriorityWeights returns the default weights: P0 counts four times as much as P3
+func DefaultPriorityWeights() PriorityWeights {
+	return PriorityWeights{
+		PriorityP0: 4,
+		PriorityP1: 3,
+		PriorityP2: 2,
+		PriorityP3: 1,
+	}
+}
+
+// weight returns the weight for a priority, treating unknown priorities as P2
+func (w PriorityWeights) weight(priority TaskPriority) float64 {
+	if value, exists := w[priority]; exists {
+		return value
+	}
+	if value, exists := w[DefaultTaskPriority()]; exists {
+		return value
+	}
+	return 1
+}
+
+// GetWeightedByPriorityProgress returns completion percentage weighted by task
+// priority using the default weights, so finishing critical work moves the
+// needle more than finishing low-priority work
+func (p *Project) GetWeightedByPriorityProgress() float64 {
+	return p.GetWeightedProgress(DefaultPriorityWeights())
+}
+
+// GetWeightedProgress returns completion percentage weighted by the given priority
+// weights. Done tasks count fully; unfinished tasks count by their subtask progress.
+func (p *Project) GetWeightedProgress(weights PriorityWeights) float64 {
+	totalWeight := 0.0
+	completedWeight := 0.0
+
+	for _, task := range p.Tasks {
+		weight := weights.weight(task.Priority)
+		totalWeight += weight
+
+		if task.IsCompleted() {
+			completedWeight += weight
+		} else if len(task.Subtasks) > 0 {
+			completedWeight += weight * float64(task.GetCompletedSubtaskCount()) / float64(len(task.Subtasks))
+		}
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return completedWeight / totalWeight * 100
+}
+
 func (p *Project) GetPendingChoicesCount() int {
 	count := 0
 	for _, task := range p.Tasks {
@@ -355,6 +851,18 @@ func (p *Project) GetPendingChoicesCount() int {
 	return count
 }
 
+// FilterTasks returns the project's tasks matching the filter, in file order.
+// An empty filter returns every task.
+func (p *Project) FilterTasks(filter TaskFilter) []Task {
+	matches := []Task{}
+	for i := range p.Tasks {
+		if filter.Matches(&p.Tasks[i]) {
+			matches = append(matches, p.Tasks[i])
+		}
+	}
+	return matches
+}
+
 func (p *Project) ToSummary(includeTasks bool) ProjectSummary {
 	summary := ProjectSummary{
 		Name:           p.Name,
@@ -374,3 +882,54 @@ func (p *Project) ToSummary(includeTasks bool) ProjectSummary {
 
 	return summary
 }
+
+// TaskIDChange records a task whose ID was changed by RenumberTasks
+type TaskIDChange struct {
+	Title string `json:"title"`
+	OldID int    `json:"old_id"`
+	NewID int    `json:"new_id"`
+}
+
+// RenumberTasks reassigns sequential IDs 1..N in file order and rewrites
+// dependency references to match. Dependencies on IDs that no longer exist
+// are dropped, since they would otherwise point at an unrelated task; a
+// dependency on a duplicated ID is kept on the first task that had it.
+// It returns the tasks whose ID changed.
+func (p *Project) RenumberTasks() []TaskIDChange {
+	newIDs := make(map[int]int, len(p.Tasks))
+	changes := []TaskIDChange{}
+	for i := range p.Tasks {
+		oldID := p.Tasks[i].ID
+		newID := i + 1
+		if _, seen := newIDs[oldID]; !seen {
+			newIDs[oldID] = newID
+		}
+		if oldID != newID {
+			changes = append(changes, TaskIDChange{Title: p.Tasks[i].Title, OldID: oldID, NewID: newID})
+		}
+	}
+
+	// IDs are assigned by position, so tasks that shared an ID get distinct ones
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		t.ID = i + 1
+
+		var deps []int
+		for _, dep := range t.Dependencies {
+			if newID, exists := newIDs[dep]; exists {
+				deps = append(deps, newID)
+			}
+		}
+		t.Dependencies = deps
+	}
+
+	return changes
+}
+
+// TitleChange records a task or subtask title whose padding NormalizeTitles removed
+type TitleChange struct {
+	TaskID   int    `json:"task_id"`
+	Subtask  bool   `json:"subtask,omitempty"`
+	OldTitle string `json:"old_title"`
+	NewTitle string `json:"new_title"`
+}
@@ -0,0 +1,437 @@
+package task
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTaskFilterProgressWithoutSubtasks(t *testing.T) {
+	low, high := 1.0, 99.0
+	full := 100.0
+	tasks := map[string]Task{
+		"todo":        {Status: StatusTodo},
+		"in progress": {Status: StatusInProgress},
+		"done":        {Status: StatusDone},
+		"half done": {Status: StatusInProgress, Subtasks: []Subtask{
+			{Title: "a", Status: StatusDone},
+			{Title: "b", Status: StatusTodo},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   map[string]bool
+	}{
+		{"complete", TaskFilter{MinProgress: &full}, map[string]bool{"done": true}},
+		{"not started", TaskFilter{MaxProgress: new(float64)}, map[string]bool{"todo": true, "in progress": true}},
+		{"partly done", TaskFilter{MinProgress: &low, MaxProgress: &high}, map[string]bool{"half done": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, task := range tasks {
+				if got := tt.filter.Matches(&task); got != tt.want[name] {
+					t.Errorf("Matches(%s) = %v, want %v", name, got, tt.want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskFilterMatches(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	status, priority, complexity := StatusInProgress, PriorityP0, ComplexityHigh
+	assignee := "Dana"
+	tasks := map[string]Task{
+		"api": {Status: StatusInProgress, Priority: PriorityP0, Complexity: ComplexityHigh, Assignee: "dana", Tags: []string{"backend", "api"},
+			Subtasks: []Subtask{{Title: "a", Status: StatusDone}, {Title: "b", Status: StatusDone}, {Title: "c", Status: StatusTodo}, {Title: "d", Status: StatusTodo}}},
+		"db":   {Status: StatusTodo, Priority: PriorityP0, Assignee: "lee", Tags: []string{"backend"}},
+		"logo": {Status: StatusInProgress, Priority: PriorityP2, Tags: []string{"design"}},
+		"docs": {Status: StatusDone, Priority: PriorityP3},
+	}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   []string
+	}{
+		{"empty filter", TaskFilter{}, []string{"api", "db", "docs", "logo"}},
+		{"P0 in progress", TaskFilter{Status: &status, Priority: &priority}, []string{"api"}},
+		{"complexity", TaskFilter{Complexity: &complexity}, []string{"api"}},
+		{"assignee ignores case", TaskFilter{Assignee: &assignee}, []string{"api"}},
+		{"any tag", TaskFilter{Tags: []string{"api", "design"}}, []string{"api", "logo"}},
+		{"all tags", TaskFilter{Tags: []string{"backend", "api"}, MatchAllTags: true}, []string{"api"}},
+		{"all tags, one missing everywhere", TaskFilter{Tags: []string{"backend", "design"}, MatchAllTags: true}, nil},
+		{"tags with another field", TaskFilter{Tags: []string{"backend"}, Status: &status}, []string{"api"}},
+		{"progress range", TaskFilter{MinProgress: ptr(25), MaxProgress: ptr(75)}, []string{"api"}},
+		{"progress bounds are inclusive", TaskFilter{MinProgress: ptr(50), MaxProgress: ptr(50)}, []string{"api"}},
+		{"min progress", TaskFilter{MinProgress: ptr(51)}, []string{"docs"}},
+		{"max progress", TaskFilter{MaxProgress: ptr(0)}, []string{"db", "logo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for name, task := range tasks {
+				if tt.filter.Matches(&task) {
+					got = append(got, name)
+				}
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+			if empty := tt.filter.IsEmpty(); empty != (tt.name == "empty filter") {
+				t.Errorf("IsEmpty = %v", empty)
+			}
+		})
+	}
+}
+
+func TestTaskFilterGeneralCategory(t *testing.T) {
+	general, ux := CategoryGeneral, CategoryUX
+	uncategorized, themed := Task{Title: "Tidy up"}, Task{Title: "Logo", Category: CategoryUX}
+
+	if !(TaskFilter{Category: &general}).Matches(&uncategorized) || (TaskFilter{Category: &general}).Matches(&themed) {
+		t.Error("a [GENERAL] filter should match only the task without a category")
+	}
+	if (TaskFilter{Category: &ux}).Matches(&uncategorized) || !(TaskFilter{Category: &ux}).Matches(&themed) {
+		t.Error("a [UX] filter should match only the [UX] task")
+	}
+}
+
+func TestSetStatusCompletedAt(t *testing.T) {
+	earlier := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		completed *time.Time
+		status    TaskStatus
+		wantSet   bool
+		wantKept  bool
+	}{
+		{"todo to done records completion", nil, StatusDone, true, false},
+		{"done again keeps first completion", &earlier, StatusDone, true, true},
+		{"reopened clears completion", &earlier, StatusInProgress, false, false},
+		{"blocked has no completion", nil, StatusBlocked, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{Title: "Ship it", CompletedAt: tt.completed}
+			subtask := Subtask{Title: "Step", CompletedAt: tt.completed}
+			before := time.Now()
+			task.SetStatus(tt.status)
+			subtask.SetStatus(tt.status)
+
+			for name, got := range map[string]*time.Time{"task": task.CompletedAt, "subtask": subtask.CompletedAt} {
+				switch {
+				case !tt.wantSet && got != nil:
+					t.Errorf("%s CompletedAt = %v, want nil", name, got)
+				case tt.wantSet && got == nil:
+					t.Errorf("%s CompletedAt is nil, want set", name)
+				case tt.wantKept && !got.Equal(earlier):
+					t.Errorf("%s CompletedAt = %v, want the earlier %v", name, got, earlier)
+				case tt.wantSet && !tt.wantKept && got.Before(before):
+					t.Errorf("%s CompletedAt = %v, want the time of the change", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSetStatusClearsBlockedReason(t *testing.T) {
+	for _, status := range []TaskStatus{StatusTodo, StatusInProgress, StatusDone, StatusBlocked} {
+		task := Task{Title: "Ship it", Status: StatusBlocked, BlockedReason: "Waiting on review"}
+		task.SetStatus(status)
+		want := ""
+		if status == StatusBlocked {
+			want = "Waiting on review"
+		}
+		if task.BlockedReason != want {
+			t.Errorf("blocked to %s: BlockedReason = %q, want %q", status, task.BlockedReason, want)
+		}
+	}
+}
+
+func TestTaskTimeline(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 5, 1, hour, 0, 0, 0, time.UTC) }
+	ptr := func(v time.Time) *time.Time { return &v }
+
+	type event struct {
+		Type        TimelineEventType
+		Subtask     string
+		Description string
+	}
+	tests := []struct {
+		name string
+		task Task
+		want []event
+	}{
+		{
+			name: "created only",
+			task: Task{Title: "Build", CreatedAt: at(1), UpdatedAt: at(1)},
+			want: []event{{TimelineCreated, "", "Task 'Build' created"}},
+		},
+		{
+			name: "update after creation",
+			task: Task{Title: "Build", CreatedAt: at(1), UpdatedAt: at(3)},
+			want: []event{
+				{TimelineCreated, "", "Task 'Build' created"},
+				{TimelineUpdated, "", "Task 'Build' last updated"},
+			},
+		},
+		{
+			name: "update at completion is not repeated",
+			task: Task{Title: "Build", CreatedAt: at(1), UpdatedAt: at(4), CompletedAt: ptr(at(4))},
+			want: []event{
+				{TimelineCreated, "", "Task 'Build' created"},
+				{TimelineCompleted, "", "Task 'Build' completed"},
+			},
+		},
+		{
+			name: "subtasks and choices interleaved by time",
+			task: Task{
+				Title:     "Build",
+				CreatedAt: at(1),
+				UpdatedAt: at(1),
+				Choices: []Choice{{
+					Question:   "Which DB?",
+					Selected:   "Postgres",
+					Reasoning:  "team knows it",
+					CreatedAt:  at(2),
+					ResolvedAt: ptr(at(5)),
+				}},
+				Subtasks: []Subtask{{
+					Title:       "Schema",
+					CreatedAt:   at(3),
+					UpdatedAt:   at(4),
+					CompletedAt: ptr(at(4)),
+					Choices:     []Choice{{Question: "Naming?", CreatedAt: at(6)}},
+				}},
+			},
+			want: []event{
+				{TimelineCreated, "", "Task 'Build' created"},
+				{TimelineChoiceCreated, "", "Choice raised: Which DB?"},
+				{TimelineCreated, "Schema", "Subtask 'Schema' created"},
+				{TimelineCompleted, "Schema", "Subtask 'Schema' completed"},
+				{TimelineChoiceResolved, "", "Choice resolved: Which DB? -> Postgres (team knows it)"},
+				{TimelineChoiceCreated, "Schema", "Choice raised: Naming?"},
+			},
+		},
+		{
+			name: "zero timestamps are skipped",
+			task: Task{Title: "Imported"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []event
+			timeline := tt.task.Timeline()
+			for i, e := range timeline {
+				if i > 0 && e.Time.Before(timeline[i-1].Time) {
+					t.Errorf("event %d at %v is before event %d at %v", i, e.Time, i-1, timeline[i-1].Time)
+				}
+				got = append(got, event{e.Type, e.SubtaskTitle, e.Description})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Timeline() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTaskCompletion(t *testing.T) {
+	subtasks := func(done, total int) []Subtask {
+		list := make([]Subtask, total)
+		for i := range list {
+			list[i] = Subtask{Title: fmt.Sprintf("s%d", i), Status: StatusTodo}
+			if i < done {
+				list[i].Status = StatusDone
+			}
+		}
+		return list
+	}
+	criteria := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name   string
+		task   Task
+		weight float64
+		want   float64
+	}{
+		{"neither, todo", Task{Status: StatusTodo}, DefaultCriteriaWeight, 0},
+		{"neither, in progress", Task{Status: StatusInProgress}, DefaultCriteriaWeight, 0},
+		{"neither, done", Task{Status: StatusDone}, DefaultCriteriaWeight, 100},
+		{"only subtasks", Task{Subtasks: subtasks(1, 4)}, DefaultCriteriaWeight, 25},
+		{"only subtasks ignores the weight", Task{Subtasks: subtasks(1, 4)}, 0.9, 25},
+		{"only criteria", Task{DoneCriteria: criteria, MetCriteria: []string{"a", "c", "d"}}, DefaultCriteriaWeight, 75},
+		{"only criteria ignores the status", Task{Status: StatusDone, DoneCriteria: criteria}, DefaultCriteriaWeight, 0},
+		// 50% subtasks and 100% criteria, blended 70/30
+		{"both, default blend", Task{Subtasks: subtasks(1, 2), DoneCriteria: criteria, MetCriteria: criteria}, DefaultCriteriaWeight, 65},
+		// 50% subtasks and 25% criteria, blended 50/50
+		{"both, even blend", Task{Subtasks: subtasks(2, 4), DoneCriteria: criteria, MetCriteria: []string{"b"}}, 0.5, 37.5},
+		{"both, criteria only", Task{Subtasks: subtasks(2, 2), DoneCriteria: criteria}, 1, 0},
+		{"both, subtasks only", Task{Subtasks: subtasks(2, 2), DoneCriteria: criteria}, 0, 100},
+		{"both complete", Task{Subtasks: subtasks(3, 3), DoneCriteria: criteria, MetCriteria: criteria}, DefaultCriteriaWeight, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.GetTaskCompletion(tt.weight); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GetTaskCompletion(%v) = %v, want %v", tt.weight, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWeightedProgress(t *testing.T) {
+	halfDone := []Subtask{{Title: "a", Status: StatusDone}, {Title: "b", Status: StatusTodo}}
+	tests := []struct {
+		name    string
+		weights PriorityWeights
+		tasks   []Task
+		want    float64
+	}{
+		{"no tasks", DefaultPriorityWeights(), nil, 0},
+		{"all done", DefaultPriorityWeights(), []Task{{Priority: PriorityP0, Status: StatusDone}, {Priority: PriorityP3, Status: StatusDone}}, 100},
+		// P0 done (4) out of P0 + P3 (5)
+		{"critical task done", DefaultPriorityWeights(), []Task{{Priority: PriorityP0, Status: StatusDone}, {Priority: PriorityP3}}, 80},
+		// P3 done (1) out of P0 + P3 (5)
+		{"low task done", DefaultPriorityWeights(), []Task{{Priority: PriorityP0}, {Priority: PriorityP3, Status: StatusDone}}, 20},
+		// Half of a P1 task (1.5) out of P1 + P3 (4)
+		{"subtask progress counts", DefaultPriorityWeights(), []Task{{Priority: PriorityP1, Subtasks: halfDone}, {Priority: PriorityP3}}, 37.5},
+		// Unknown priority weighs as P2 (2); P2 done out of P2 + P0 (6)
+		{"unknown priority uses default", DefaultPriorityWeights(), []Task{{Priority: "urgent", Status: StatusDone}, {Priority: PriorityP0}}, 100.0 / 3},
+		{"custom weights", PriorityWeights{PriorityP0: 1, PriorityP1: 1, PriorityP2: 1, PriorityP3: 1}, []Task{{Priority: PriorityP0, Status: StatusDone}, {Priority: PriorityP3}}, 50},
+		// No weight for P2 either, so every task weighs 1
+		{"empty weights", PriorityWeights{}, []Task{{Priority: PriorityP0, Status: StatusDone}, {Priority: PriorityP3}, {Priority: PriorityP1}, {Priority: PriorityP2}}, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &Project{Tasks: tt.tasks}
+			if got := project.GetWeightedProgress(tt.weights); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GetWeightedProgress = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCategoryBreakdown(t *testing.T) {
+	// Subtasks don't count toward completion; only the task's own status does
+	allStepsDone := []Subtask{{Title: "a", Status: StatusDone}}
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  map[TaskCategory]CategoryProgress
+	}{
+		{"no tasks", nil, map[TaskCategory]CategoryProgress{}},
+		{"uncategorized tasks are general", []Task{{Status: StatusDone}, {}}, map[TaskCategory]CategoryProgress{
+			CategoryGeneral: {Total: 2, Completed: 1},
+		}},
+		{"explicit general shares the bucket", []Task{{Category: CategoryGeneral, Status: StatusDone}, {}}, map[TaskCategory]CategoryProgress{
+			CategoryGeneral: {Total: 2, Completed: 1},
+		}},
+		{"mixed categories", []Task{
+			{Category: CategoryMVP, Status: StatusDone},
+			{Category: CategoryMVP, Status: StatusInProgress},
+			{Category: CategoryMVP, Status: StatusDone},
+			{Category: CategoryInfra, Status: StatusBlocked},
+			{Category: CategoryInfra, Subtasks: allStepsDone},
+			{Category: CategoryUX, Status: StatusDone},
+			{Status: StatusTodo},
+		}, map[TaskCategory]CategoryProgress{
+			CategoryMVP:     {Total: 3, Completed: 2},
+			CategoryInfra:   {Total: 2, Completed: 0},
+			CategoryUX:      {Total: 1, Completed: 1},
+			CategoryGeneral: {Total: 1, Completed: 0},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &Project{Tasks: tt.tasks}
+			if got := project.GetCategoryBreakdown(); !maps.Equal(got, tt.want) {
+				t.Errorf("GetCategoryBreakdown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompletionStats(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2024, 5, day, 9, 0, 0, 0, time.UTC) }
+	ptr := func(v time.Time) *time.Time { return &v }
+
+	project := &Project{Tasks: []Task{
+		{CreatedAt: at(3), UpdatedAt: at(5), CompletedAt: ptr(at(4)), EstimatedHours: 3, Subtasks: []Subtask{{Title: "a"}, {Title: "b"}}},
+		// Completed before completion times were recorded
+		{CreatedAt: at(1), UpdatedAt: at(6), EstimatedHours: 5},
+		{CreatedAt: at(2), UpdatedAt: at(2), CompletedAt: ptr(at(3)), Subtasks: []Subtask{{Title: "c"}}},
+	}}
+
+	stats := project.GetCompletionStats()
+	if stats.TotalTasks != 3 || stats.TotalSubtasks != 3 || stats.TotalEstimatedHours != 8 {
+		t.Errorf("counts = %+v, want 3 tasks, 3 subtasks, 8 hours", stats)
+	}
+	if stats.StartedAt == nil || !stats.StartedAt.Equal(at(1)) || stats.FinishedAt == nil || !stats.FinishedAt.Equal(at(6)) {
+		t.Errorf("span = %v - %v, want %v - %v", stats.StartedAt, stats.FinishedAt, at(1), at(6))
+	}
+	if stats.Duration != "120h0m0s" {
+		t.Errorf("Duration = %q, want 120h0m0s", stats.Duration)
+	}
+
+	if empty := (&Project{}).GetCompletionStats(); empty.StartedAt != nil || empty.FinishedAt != nil || empty.Duration != "" {
+		t.Errorf("stats of an empty project = %+v, want no span", empty)
+	}
+}
+
+func TestResolvedChoices(t *testing.T) {
+	resolvedAt := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	task := Task{
+		Title: "Pick a stack",
+		Choices: []Choice{
+			{ID: "db", Question: "Which database?", Options: []string{"Postgres", "SQLite"}, Selected: "Postgres", Reasoning: "Concurrent writers", ResolvedAt: &resolvedAt},
+			{ID: "cache", Question: "Which cache?", Options: []string{"Redis", "None"}},
+		},
+		Subtasks: []Subtask{
+			{Title: "Hosting", Choices: []Choice{{ID: "host", Question: "Where?", Options: []string{"VM", "PaaS"}, Selected: "PaaS", ResolvedAt: &resolvedAt}}},
+			{Title: "Logging", Choices: []Choice{{ID: "logs", Question: "Format?", Options: []string{"JSON", "Text"}}}},
+			{Title: "Docs"},
+		},
+	}
+	choiceIDs := func(choices []Choice) []string {
+		var ids []string
+		for _, choice := range choices {
+			ids = append(ids, choice.ID)
+		}
+		return ids
+	}
+
+	resolved := task.ResolvedChoices()
+	if ids := choiceIDs(resolved); !slices.Equal(ids, []string{"db", "host"}) {
+		t.Errorf("ResolvedChoices = %v, want [db host]", ids)
+	}
+	if resolved[0].Selected != "Postgres" || resolved[0].Reasoning != "Concurrent writers" {
+		t.Errorf("resolved choice lost its decision: %+v", resolved[0])
+	}
+	if got := (&Task{Title: "No choices"}).ResolvedChoices(); got == nil || len(got) != 0 {
+		t.Errorf("ResolvedChoices without choices = %#v, want an empty list", got)
+	}
+
+	pending := task.WithoutResolvedChoices()
+	if ids := choiceIDs(pending.Choices); !slices.Equal(ids, []string{"cache"}) {
+		t.Errorf("task choices without resolved = %v, want [cache]", ids)
+	}
+	var subtaskIDs [][]string
+	for _, subtask := range pending.Subtasks {
+		subtaskIDs = append(subtaskIDs, choiceIDs(subtask.Choices))
+	}
+	if len(subtaskIDs) != 3 || subtaskIDs[0] != nil || !slices.Equal(subtaskIDs[1], []string{"logs"}) || subtaskIDs[2] != nil {
+		t.Errorf("subtask choices without resolved = %v, want [[] [logs] []]", subtaskIDs)
+	}
+
+	// The original task keeps every choice
+	if len(task.Choices) != 2 || len(task.Subtasks[0].Choices) != 1 {
+		t.Errorf("WithoutResolvedChoices changed the task: %+v", task)
+	}
+}
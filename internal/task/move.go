@@ -0,0 +1,129 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TaskMove describes a task moved between projects by MoveTask
+type TaskMove struct {
+	Title       string `json:"title"`
+	FromProject string `json:"from_project"`
+	ToProject   string `json:"to_project"`
+	OldID       int    `json:"old_id"`
+	NewID       int    `json:"new_id"`
+	// ClearedDependents are the source tasks that depended on the moved task
+	// and lost that dependency
+	ClearedDependents []string `json:"cleared_dependents"`
+	// DroppedDependencies are the source tasks the moved task depended on.
+	// Dependencies are IDs within one project, so they can't follow the task.
+	DroppedDependencies []string `json:"dropped_dependencies"`
+}
+
+// MoveTask moves a task, with its subtasks and history, from one project to
+// the end of another, giving it the next free ID there. Dependencies between
+// the moved task and tasks left behind are removed on both sides.
+//
+// The destination is saved first, so a failure can leave the task in both
+// projects but never in neither.
+func (m *Manager) MoveTask(fromProject string, toProject string, taskTitle string) (*TaskMove, error) {
+	if err := ValidateProjectName(fromProject); err != nil {
+		return nil, err
+	}
+	if err := ValidateProjectName(toProject); err != nil {
+		return nil, err
+	}
+	if fromProject == toProject || m.GetTaskFilePath(fromProject) == m.GetTaskFilePath(toProject) {
+		return nil, NewError(ErrInvalidInput, "task '%s' is already in project '%s'", taskTitle, toProject)
+	}
+
+	// Hold both update locks, in path order so opposite moves can't deadlock
+	first, second := m.updateLock(fromProject), m.updateLock(toProject)
+	if m.GetTaskFilePath(toProject) < m.GetTaskFilePath(fromProject) {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	var move *TaskMove
+	err := retryStale(func() error {
+		source, err := m.LoadProject(fromProject)
+		if err != nil {
+			return err
+		}
+		destination, err := m.LoadProject(toProject)
+		if err != nil {
+			return err
+		}
+
+		moved, _, err := ResolveTaskTitle(source, taskTitle, false)
+		if err != nil {
+			return err
+		}
+		for _, existingTask := range destination.Tasks {
+			if existingTask.Title == moved.Title {
+				return NewError(ErrConflict, "task with title '%s' already exists in project '%s'", moved.Title, toProject)
+			}
+		}
+
+		move = &TaskMove{
+			Title:               moved.Title,
+			FromProject:         fromProject,
+			ToProject:           toProject,
+			OldID:               moved.ID,
+			NewID:               nextTaskID(destination),
+			ClearedDependents:   []string{},
+			DroppedDependencies: []string{},
+		}
+
+		for _, t := range source.Tasks {
+			if slices.Contains(moved.Dependencies, t.ID) {
+				move.DroppedDependencies = append(move.DroppedDependencies, t.Title)
+			}
+		}
+
+		task := *moved
+		task.ID = move.NewID
+		task.Dependencies = nil
+		task.UpdatedAt = time.Now().UTC()
+		destination.Tasks = append(destination.Tasks, task)
+
+		return m.SaveProject(destination)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The destination is saved, so only the source is reloaded from here on
+	err = retryStale(func() error {
+		source, err := m.LoadProject(fromProject)
+		if err != nil {
+			return err
+		}
+
+		move.ClearedDependents = []string{}
+		source.Tasks = slices.DeleteFunc(source.Tasks, func(t Task) bool {
+			return t.ID == move.OldID && t.Title == move.Title
+		})
+		for i := range source.Tasks {
+			if slices.Contains(source.Tasks[i].Dependencies, move.OldID) {
+				source.Tasks[i].Dependencies = slices.DeleteFunc(source.Tasks[i].Dependencies, func(id int) bool {
+					return id == move.OldID
+				})
+				source.Tasks[i].UpdatedAt = time.Now().UTC()
+				move.ClearedDependents = append(move.ClearedDependents, source.Tasks[i].Title)
+			}
+		}
+
+		return m.SaveProject(source)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("task '%s' was added to project '%s' but could not be removed from '%s': %w",
+			move.Title, toProject, fromProject, err)
+	}
+
+	return move, nil
+}
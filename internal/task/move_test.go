@@ -0,0 +1,108 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMoveTask(t *testing.T) {
+	m := newTestManager(t)
+	for _, name := range []string{"backend", "frontend"} {
+		if err := m.CreateProject(name); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+	err := m.AddTasks("backend", []Task{
+		{Title: "Schema"},
+		{Title: "API", Priority: PriorityP0, Tags: []string{"http"}, Dependencies: []int{1},
+			Subtasks: []Subtask{{Title: "List", Status: StatusDone}, {Title: "Create", Status: StatusTodo}}},
+		{Title: "Client", Dependencies: []int{1, 2}},
+	})
+	if err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+	if err := m.AddTasks("frontend", []Task{{Title: "Layout"}, {Title: "Forms"}}); err != nil {
+		t.Fatalf("AddTasks: %v", err)
+	}
+	titles := func(project string) []string {
+		t.Helper()
+		m.InvalidateCache(project)
+		loaded, err := m.LoadProject(project)
+		if err != nil {
+			t.Fatalf("LoadProject(%s): %v", project, err)
+		}
+		var titles []string
+		for _, task := range loaded.Tasks {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+
+	move, err := m.MoveTask("backend", "frontend", "API")
+	if err != nil {
+		t.Fatalf("MoveTask: %v", err)
+	}
+	if move.OldID != 2 || move.NewID != 3 ||
+		!slices.Equal(move.ClearedDependents, []string{"Client"}) || !slices.Equal(move.DroppedDependencies, []string{"Schema"}) {
+		t.Errorf("move = %+v", move)
+	}
+
+	source, err := m.LoadProject("backend")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := titles("backend"); !slices.Equal(got, []string{"Schema", "Client"}) {
+		t.Errorf("source tasks = %v, want [Schema Client]", got)
+	}
+	if deps := source.Tasks[1].Dependencies; !slices.Equal(deps, []int{1}) {
+		t.Errorf("Client depends on %v, want only Schema", deps)
+	}
+
+	destination, err := m.LoadProject("frontend")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := titles("frontend"); !slices.Equal(got, []string{"Layout", "Forms", "API"}) {
+		t.Fatalf("destination tasks = %v, want API appended", got)
+	}
+	moved := destination.Tasks[2]
+	if moved.ID != 3 || len(moved.Dependencies) != 0 || moved.Priority != PriorityP0 || !slices.Equal(moved.Tags, []string{"http"}) ||
+		len(moved.Subtasks) != 2 || moved.Subtasks[0].Status != StatusDone {
+		t.Errorf("moved task = %+v", moved)
+	}
+
+	// A title already in the destination is refused, leaving both projects alone
+	if err := m.AddTask("backend", Task{Title: "Layout"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if _, err := m.MoveTask("backend", "frontend", "Layout"); !errors.Is(err, ErrConflict) {
+		t.Errorf("moving onto an existing title = %v, want ErrConflict", err)
+	}
+	if got := titles("backend"); !slices.Equal(got, []string{"Schema", "Client", "Layout"}) {
+		t.Errorf("source after a refused move = %v", got)
+	}
+	if got := titles("frontend"); !slices.Equal(got, []string{"Layout", "Forms", "API"}) {
+		t.Errorf("destination after a refused move = %v", got)
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		title    string
+		want     error
+	}{
+		{"same project", "backend", "backend", "Schema", ErrInvalidInput},
+		{"invalid destination name", "backend", "../frontend", "Schema", ErrInvalidInput},
+		{"missing task", "backend", "frontend", "Deploy", ErrNotFound},
+		{"missing destination", "backend", "mobile", "Schema", ErrNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := m.MoveTask(tt.from, tt.to, tt.title); !errors.Is(err, tt.want) {
+			t.Errorf("%s: MoveTask = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if got := titles("backend"); !slices.Equal(got, []string{"Schema", "Client", "Layout"}) {
+		t.Errorf("source after failed moves = %v", got)
+	}
+}
@@ -0,0 +1,349 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// prdHeadingPattern matches the # and ## headings that can become tasks
+var prdHeadingPattern = regexp.MustCompile(`^(#{1,2})\s+(.+?)(?:\s+#+)?\s*$`)
+
+// prdBulletPattern matches "-", "*", "+" and numbered list items, capturing the indentation
+var prdBulletPattern = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.+)$`)
+
+// prdPriorityPattern matches inline priority hints such as "(P0)"
+var prdPriorityPattern = regexp.MustCompile(`(?i)\(\s*(p[0-3])\s*\)`)
+
+// prdCategoryPattern matches bracketed words that may be category tags such as "[MVP]"
+var prdCategoryPattern = regexp.MustCompile(`\[(\w+)\]`)
+
+// prdHints are the priority and category found inline in a PRD line
+type prdHints struct {
+	priority TaskPriority
+	category TaskCategory
+}
+
+// extractPRDHints strips priority and category hints from text, returning the
+// remaining text and the hints found
+func extractPRDHints(text string) (string, prdHints) {
+	var hints prdHints
+
+	if match := prdPriorityPattern.FindStringSubmatch(text); match != nil {
+		hints.priority = TaskPriority(strings.ToUpper(match[1]))
+		text = prdPriorityPattern.ReplaceAllString(text, "")
+	}
+
+	text = prdCategoryPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		category, err := ValidateTaskCategory(strings.ToUpper(tag))
+		if err != nil {
+			return tag
+		}
+		if hints.category == "" {
+			hints.category = category
+		}
+		return ""
+	})
+
+	return strings.Join(strings.Fields(text), " "), hints
+}
+
+// prdLine is a classified line of PRD markdown
+type prdLine struct {
+	kind   string // heading, bullet, text or blank
+	level  int    // heading level, or bullet indentation
+	text   string
+	hints  prdHints
+	nested bool // bullet indented below a top-level bullet
+}
+
+// ParsePRD deterministically extracts tasks from PRD markdown:
+//   - a top-level bullet becomes a task, and bullets nested under it its subtasks
+//   - a # or ## heading becomes a task, with the paragraph following it as its
+//     description, unless it groups bullets or ## headings of its own, in which
+//     case it is a section whose hints its tasks inherit
+//   - "(P0)"-"(P3)" and "[MVP]"-style tags set the priority and category
+//
+// Tasks are returned in document order without IDs.
+func ParsePRD(content string) []Task {
+	lines := classifyPRDLines(content)
+
+	var tasks []Task
+	var current *Task
+	// sections holds the hints of the enclosing # and ## section headings
+	var sections [3]prdHints
+	collectDescription := false
+
+	flush := func() {
+		if current != nil {
+			tasks = append(tasks, *current)
+		}
+		current = nil
+	}
+
+	newTask := func(title string, hints prdHints) *Task {
+		t := &Task{Title: title, Priority: hints.priority, Category: hints.category}
+		for level := 2; level >= 1; level-- {
+			if t.Priority == "" {
+				t.Priority = sections[level].priority
+			}
+			if t.Category == "" {
+				t.Category = sections[level].category
+			}
+		}
+		return t
+	}
+
+	for i, line := range lines {
+		switch line.kind {
+		case "heading":
+			flush()
+			collectDescription = false
+			sections[line.level] = prdHints{}
+			if line.level == 1 {
+				sections[2] = prdHints{}
+			}
+			if isPRDSection(lines, i) {
+				sections[line.level] = line.hints
+				continue
+			}
+			current = newTask(line.text, line.hints)
+			collectDescription = true
+		case "bullet":
+			collectDescription = false
+			if line.nested && current != nil {
+				current.Subtasks = append(current.Subtasks, Subtask{Title: line.text, Status: DefaultTaskStatus()})
+				continue
+			}
+			flush()
+			current = newTask(line.text, line.hints)
+		case "text":
+			if collectDescription && current != nil {
+				if current.Description != "" {
+					current.Description += " "
+				}
+				current.Description += line.text
+			}
+		case "blank":
+			// The description is the first paragraph only
+			if current != nil && current.Description != "" {
+				collectDescription = false
+			}
+		}
+	}
+	flush()
+
+	return tasks
+}
+
+// isPRDSection reports whether the heading at index i groups other tasks:
+// bullets or deeper headings before the next heading at its level or above
+func isPRDSection(lines []prdLine, i int) bool {
+	for _, line := range lines[i+1:] {
+		if line.kind == "heading" {
+			return line.level > lines[i].level
+		}
+		if line.kind == "bullet" {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyPRDLines splits PRD markdown into headings, bullets, text and blank
+// lines. Deeper headings and fenced code blocks are skipped.
+func classifyPRDLines(content string) []prdLine {
+	var lines []prdLine
+	inCodeBlock := false
+	topIndent := -1
+
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\t", "    "), "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		if trimmed == "" {
+			lines = append(lines, prdLine{kind: "blank"})
+			continue
+		}
+
+		if match := prdHeadingPattern.FindStringSubmatch(trimmed); match != nil {
+			text, hints := extractPRDHints(match[2])
+			topIndent = -1
+			if text != "" {
+				lines = append(lines, prdLine{kind: "heading", level: len(match[1]), text: text, hints: hints})
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if match := prdBulletPattern.FindStringSubmatch(raw); match != nil {
+			text, hints := extractPRDHints(match[2])
+			if text == "" {
+				continue
+			}
+			indent := len(match[1])
+			if topIndent < 0 || indent < topIndent {
+				topIndent = indent
+			}
+			lines = append(lines, prdLine{kind: "bullet", level: indent, text: text, hints: hints, nested: indent > topIndent})
+			continue
+		}
+
+		lines = append(lines, prdLine{kind: "text", text: trimmed})
+	}
+
+	return lines
+}
+
+// PRDTaskUpdate records the changes ApplyPRD made to an existing task
+type PRDTaskUpdate struct {
+	ID      int      `json:"id"`
+	Title   string   `json:"title"`
+	Changes []string `json:"changes"`
+}
+
+// PRDChanges reports what ApplyPRD changed in a project
+type PRDChanges struct {
+	Added     []string        `json:"added"`
+	Updated   []PRDTaskUpdate `json:"updated"`
+	Removed   []string        `json:"removed"`
+	Unchanged int             `json:"unchanged"`
+}
+
+// HasChanges reports whether ApplyPRD changed anything
+func (c PRDChanges) HasChanges() bool {
+	return len(c.Added) > 0 || len(c.Updated) > 0 || len(c.Removed) > 0
+}
+
+// ApplyPRD reconciles the project with tasks extracted from a revised PRD.
+// Tasks are matched by title: matching tasks take the PRD's description,
+// priority and category where the PRD gives one, and gain its new subtasks;
+// unmatched PRD tasks are added. With prune set, tasks and subtasks missing
+// from the PRD are removed, along with dependencies on removed tasks.
+// Statuses are never changed.
+func (p *Project) ApplyPRD(tasks []Task, prune bool) PRDChanges {
+	changes := PRDChanges{Added: []string{}, Updated: []PRDTaskUpdate{}, Removed: []string{}}
+	now := time.Now().UTC()
+
+	inPRD := make(map[string]bool, len(tasks))
+	maxID := 0
+	for _, t := range p.Tasks {
+		maxID = max(maxID, t.ID)
+	}
+
+	for _, prdTask := range tasks {
+		inPRD[prdTask.Title] = true
+
+		existing, _, err := ResolveTaskTitle(p, prdTask.Title, false)
+		if err != nil {
+			maxID++
+			prdTask.ID = maxID
+			prdTask.Status = DefaultTaskStatus()
+			if prdTask.Priority == "" {
+				prdTask.Priority = DefaultTaskPriority()
+			}
+			prdTask.CreatedAt = now
+			prdTask.UpdatedAt = now
+			for i := range prdTask.Subtasks {
+				prdTask.Subtasks[i].Status = DefaultTaskStatus()
+				prdTask.Subtasks[i].CreatedAt = now
+				prdTask.Subtasks[i].UpdatedAt = now
+			}
+			p.Tasks = append(p.Tasks, prdTask)
+			changes.Added = append(changes.Added, prdTask.Title)
+			continue
+		}
+
+		if updates := existing.applyPRDTask(prdTask, prune, now); len(updates) > 0 {
+			changes.Updated = append(changes.Updated, PRDTaskUpdate{ID: existing.ID, Title: existing.Title, Changes: updates})
+		} else {
+			changes.Unchanged++
+		}
+	}
+
+	if prune {
+		removedIDs := make(map[int]bool)
+		kept := p.Tasks[:0]
+		for _, t := range p.Tasks {
+			if inPRD[t.Title] {
+				kept = append(kept, t)
+				continue
+			}
+			removedIDs[t.ID] = true
+			changes.Removed = append(changes.Removed, t.Title)
+		}
+		p.Tasks = kept
+
+		for i := range p.Tasks {
+			var deps []int
+			for _, dep := range p.Tasks[i].Dependencies {
+				if !removedIDs[dep] {
+					deps = append(deps, dep)
+				}
+			}
+			p.Tasks[i].Dependencies = deps
+		}
+	}
+
+	if changes.HasChanges() {
+		p.UpdatedAt = now
+	}
+	return changes
+}
+
+// applyPRDTask updates a task from its PRD entry, returning a description of each change
+func (t *Task) applyPRDTask(prdTask Task, prune bool, now time.Time) []string {
+	var updates []string
+
+	if prdTask.Description != "" && prdTask.Description != t.Description {
+		t.Description = prdTask.Description
+		updates = append(updates, "description updated")
+	}
+	if prdTask.Priority != "" && prdTask.Priority != t.Priority {
+		updates = append(updates, fmt.Sprintf("priority %s -> %s", t.Priority, prdTask.Priority))
+		t.Priority = prdTask.Priority
+	}
+	if prdTask.Category != "" && prdTask.Category != t.Category {
+		updates = append(updates, fmt.Sprintf("category %s -> %s", t.Category, prdTask.Category))
+		t.Category = prdTask.Category
+	}
+
+	inPRD := make(map[string]bool, len(prdTask.Subtasks))
+	for _, subtask := range prdTask.Subtasks {
+		inPRD[subtask.Title] = true
+	}
+
+	existing := make(map[string]bool, len(t.Subtasks))
+	var subtasks []Subtask
+	for _, subtask := range t.Subtasks {
+		existing[subtask.Title] = true
+		if prune && !inPRD[subtask.Title] {
+			updates = append(updates, fmt.Sprintf("removed subtask '%s'", subtask.Title))
+			continue
+		}
+		subtasks = append(subtasks, subtask)
+	}
+	for _, subtask := range prdTask.Subtasks {
+		if existing[subtask.Title] {
+			continue
+		}
+		subtasks = append(subtasks, Subtask{Title: subtask.Title, Status: DefaultTaskStatus(), CreatedAt: now, UpdatedAt: now})
+		updates = append(updates, fmt.Sprintf("added subtask '%s'", subtask.Title))
+	}
+	t.Subtasks = subtasks
+
+	if len(updates) > 0 {
+		t.UpdatedAt = now
+	}
+	return updates
+}
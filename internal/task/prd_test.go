@@ -0,0 +1,50 @@
+package task
+
+import "testing"
+
+func TestApplyPRDRoundTrip(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{Title: "Login", Description: "Old text", Status: StatusInProgress},
+		Task{Title: "Legacy export", Description: "Export to CSV", Dependencies: []int{1}},
+	)
+
+	changes := project.ApplyPRD([]Task{
+		{Title: "Login", Description: "Email and password login", Priority: PriorityP0, Subtasks: []Subtask{{Title: "Rate limiting"}}},
+		{Title: "Signup", Description: "Create an account"},
+	}, false)
+	if len(changes.Added) != 1 || len(changes.Updated) != 1 || len(changes.Removed) != 0 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	parsed := roundTrip(t, m, project)
+	login := parsed.Tasks[0]
+	if login.Description != "Email and password login" || login.Priority != PriorityP0 || login.Status != StatusInProgress {
+		t.Errorf("updated task did not round-trip: %+v", login)
+	}
+	if len(login.Subtasks) != 1 || login.Subtasks[0].Title != "Rate limiting" || login.Subtasks[0].Status != StatusTodo {
+		t.Errorf("added subtask did not round-trip: %+v", login.Subtasks)
+	}
+	if signup := parsed.Tasks[2]; signup.Title != "Signup" || signup.ID != 3 || signup.Status != StatusTodo {
+		t.Errorf("added task did not round-trip: %+v", signup)
+	}
+}
+
+func TestApplyPRDPruneRoundTrip(t *testing.T) {
+	m := newTestManager(t)
+	project := testProject(
+		Task{Title: "Login", Description: "Login"},
+		Task{Title: "Legacy export", Description: "Export to CSV"},
+		Task{Title: "Reports", Description: "Reports", Dependencies: []int{2}},
+	)
+
+	changes := project.ApplyPRD([]Task{{Title: "Login"}, {Title: "Reports"}}, true)
+	if len(changes.Removed) != 1 || changes.Removed[0] != "Legacy export" {
+		t.Fatalf("Removed = %v, want [Legacy export]", changes.Removed)
+	}
+
+	parsed := roundTrip(t, m, project)
+	if parsed.Tasks[1].Title != "Reports" || len(parsed.Tasks[1].Dependencies) != 0 {
+		t.Errorf("dependency on removed task survived: %+v", parsed.Tasks[1])
+	}
+}
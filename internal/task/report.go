@@ -0,0 +1,155 @@
+package task
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// reportTemplate renders a self-contained HTML progress report. The Mermaid
+// chart is drawn in the browser by the Mermaid script from its CDN; without
+// network access the report still shows everything else.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} – Project Report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem auto; max-width: 960px; color: #24292f; }
+table { border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.35rem 0.75rem; text-align: left; }
+th { background: #f6f8fa; }
+.task { border: 1px solid #d0d7de; border-left-width: 6px; border-radius: 4px; margin: 0.75rem 0; padding: 0.5rem 1rem; }
+.task h3 { margin: 0.25rem 0; }
+.meta { color: #57606a; font-size: 0.9em; }
+.subtasks { list-style: none; padding-left: 0.5rem; }
+.status { border-radius: 1em; color: #fff; font-size: 0.8em; padding: 0.1em 0.6em; }
+.status-done { background: #1a7f37; border-color: #1a7f37; }
+.status-in_progress { background: #0969da; border-color: #0969da; }
+.status-blocked { background: #cf222e; border-color: #cf222e; }
+.status-todo { background: #6e7781; border-color: #6e7781; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}<p class="meta">Generated {{.GeneratedAt}}</p>
+
+<h2>Progress</h2>
+<pre class="mermaid">
+{{.PieChart}}</pre>
+<table>
+<tr><th>Metric</th><th>Count</th><th>Percentage</th></tr>
+<tr><td>Tasks Completed</td><td>{{.CompletedTasks}}/{{.TotalTasks}}</td><td>{{.TaskProgress}}</td></tr>
+<tr><td>Overall Progress</td><td>{{.CompletedItems}}/{{.TotalItems}}</td><td>{{.OverallProgress}}</td></tr>
+<tr><td>In Progress</td><td>{{.InProgressTasks}}</td><td>-</td></tr>
+<tr><td>Blocked</td><td>{{.BlockedTasks}}</td><td>-</td></tr>
+</table>
+
+<h2>By Category</h2>
+<table>
+<tr><th>Category</th><th>Tasks</th><th>Completed</th><th>Progress</th></tr>
+{{range .Categories}}<tr><td>{{.Category}}</td><td>{{.Total}}</td><td>{{.Completed}}</td><td>{{.Progress}}</td></tr>
+{{end}}</table>
+
+<h2>Tasks</h2>
+{{range .Tasks}}<div class="task status-{{.Status}}">
+<h3>{{.ID}}. {{.Title}} <span class="status status-{{.Status}}">{{.Status}}</span></h3>
+<p class="meta">{{.Priority}}{{if .Category}} · {{.Category}}{{end}}{{if .Complexity}} · {{.Complexity}} complexity{{end}}</p>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}{{if .Subtasks}}<ul class="subtasks">
+{{range .Subtasks}}<li><input type="checkbox" disabled{{if eq .Status "done"}} checked{{end}}> {{.Title}}{{if or (eq .Status "in_progress") (eq .Status "blocked")}} <span class="status status-{{.Status}}">{{.Status}}</span>{{end}}</li>
+{{end}}</ul>
+{{end}}</div>
+{{end}}
+<script type="module">
+import mermaid from "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.esm.min.mjs";
+mermaid.initialize({ startOnLoad: true });
+</script>
+</body>
+</html>
+`))
+
+// reportCategory is one row of a report's per-category breakdown
+type reportCategory struct {
+	Category  TaskCategory
+	Total     int
+	Completed int
+	Progress  string
+}
+
+// reportData is what reportTemplate renders
+type reportData struct {
+	Name            string
+	Description     string
+	GeneratedAt     string
+	PieChart        string
+	TotalTasks      int
+	CompletedTasks  int
+	TotalItems      int
+	CompletedItems  int
+	TaskProgress    string
+	OverallProgress string
+	InProgressTasks int
+	BlockedTasks    int
+	Categories      []reportCategory
+	Tasks           []Task
+}
+
+// GenerateHTMLReport renders a project as a standalone HTML page with the
+// progress table and pie chart, a per-category breakdown and every task with
+// its subtasks as a checklist, color-coded by status
+func GenerateHTMLReport(project *Project) (string, error) {
+	data := reportData{
+		Name:           project.Name,
+		Description:    project.Description,
+		GeneratedAt:    time.Now().Format("2006-01-02 15:04"),
+		TotalTasks:     len(project.Tasks),
+		CompletedTasks: project.GetCompletedTaskCount(),
+		TotalItems:     project.GetTotalItemCount(),
+		CompletedItems: project.GetCompletedItemCount(),
+		Tasks:          project.Tasks,
+	}
+	data.PieChart = progressPieChart(data.CompletedItems, data.TotalItems)
+	data.TaskProgress = formatPercentage(data.CompletedTasks, data.TotalTasks)
+	data.OverallProgress = formatPercentage(data.CompletedItems, data.TotalItems)
+
+	for _, t := range project.Tasks {
+		switch t.Status {
+		case StatusInProgress:
+			data.InProgressTasks++
+		case StatusBlocked:
+			data.BlockedTasks++
+		}
+	}
+
+	breakdown := project.GetCategoryBreakdown()
+	for _, category := range AllCategories() {
+		progress, exists := breakdown[category]
+		if !exists {
+			continue
+		}
+		data.Categories = append(data.Categories, reportCategory{
+			Category:  category,
+			Total:     progress.Total,
+			Completed: progress.Completed,
+			Progress:  formatPercentage(progress.Completed, progress.Total),
+		})
+	}
+
+	var content strings.Builder
+	if err := reportTemplate.Execute(&content, data); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return content.String(), nil
+}
+
+// formatPercentage formats part of total as a percentage with one decimal,
+// as in the markdown progress table
+func formatPercentage(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)/float64(total)*100)
+}
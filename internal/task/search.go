@@ -0,0 +1,154 @@
+package task
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Task fields SearchTasks can match against
+const (
+	SearchFieldTitle       = "title"
+	SearchFieldDescription = "description"
+	SearchFieldSubtasks    = "subtasks"
+)
+
+// searchSnippetRadius is how many characters of context a description snippet
+// keeps on each side of the match
+const searchSnippetRadius = 40
+
+// SearchMatch is one place a search query was found in a task
+type SearchMatch struct {
+	Field string `json:"field"`
+	// Text is the matching title or subtask title, or a snippet of the description
+	Text string `json:"text"`
+}
+
+// SearchResult is a task matching a search query, with where it matched
+type SearchResult struct {
+	Project string        `json:"project"`
+	TaskID  int           `json:"task_id"`
+	Title   string        `json:"title"`
+	Status  TaskStatus    `json:"status"`
+	Matches []SearchMatch `json:"matches"`
+	// ExactTitle is set when the query is the whole task title
+	ExactTitle bool `json:"exact_title,omitempty"`
+}
+
+// ValidateSearchFields checks the fields to search, returning every field when none are given
+func ValidateSearchFields(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return []string{SearchFieldTitle, SearchFieldDescription, SearchFieldSubtasks}, nil
+	}
+	for _, field := range fields {
+		switch field {
+		case SearchFieldTitle, SearchFieldDescription, SearchFieldSubtasks:
+		default:
+			return nil, NewError(ErrInvalidInput, "invalid search field: %s. Valid options: title, description, subtasks", field)
+		}
+	}
+	return fields, nil
+}
+
+// SearchTasks finds tasks whose title, description or subtask titles contain
+// the query, case-insensitively, in the given projects (every project when
+// none are given). fields restricts where to look; empty means everywhere.
+// Exact title matches come first, then other title matches, then the rest,
+// each in project and file order.
+func (m *Manager) SearchTasks(query string, projects []string, fields []string) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, NewError(ErrInvalidInput, "search query cannot be empty")
+	}
+
+	fields, err := ValidateSearchFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	searchField := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		searchField[field] = true
+	}
+
+	if len(projects) == 0 {
+		if projects, err = m.ListProjects(); err != nil {
+			return nil, err
+		}
+	}
+
+	needle := strings.ToLower(query)
+	results := []SearchResult{}
+	for _, projectName := range projects {
+		project, err := m.LoadProject(projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range project.Tasks {
+			result := SearchResult{Project: projectName, TaskID: t.ID, Title: t.Title, Status: t.Status}
+
+			if searchField[SearchFieldTitle] && strings.Contains(strings.ToLower(t.Title), needle) {
+				result.Matches = append(result.Matches, SearchMatch{Field: SearchFieldTitle, Text: t.Title})
+				result.ExactTitle = strings.EqualFold(t.Title, query)
+			}
+			if searchField[SearchFieldDescription] {
+				if index := strings.Index(strings.ToLower(t.Description), needle); index >= 0 {
+					result.Matches = append(result.Matches, SearchMatch{Field: SearchFieldDescription, Text: searchSnippet(t.Description, index, len(needle))})
+				}
+			}
+			if searchField[SearchFieldSubtasks] {
+				for _, subtask := range t.Subtasks {
+					if strings.Contains(strings.ToLower(subtask.Title), needle) {
+						result.Matches = append(result.Matches, SearchMatch{Field: SearchFieldSubtasks, Text: subtask.Title})
+					}
+				}
+			}
+
+			if len(result.Matches) > 0 {
+				results = append(results, result)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return searchRank(results[i]) < searchRank(results[j])
+	})
+	return results, nil
+}
+
+// searchRank orders results: exact title matches, then title matches, then the rest
+func searchRank(result SearchResult) int {
+	switch {
+	case result.ExactTitle:
+		return 0
+	case result.Matches[0].Field == SearchFieldTitle:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// searchSnippet returns the text around a match, marking cut ends with "..."
+func searchSnippet(text string, index, length int) string {
+	// The index comes from the lowercased text, which can differ in length for some scripts
+	index = min(index, len(text))
+	start := max(index-searchSnippetRadius, 0)
+	end := min(index+length+searchSnippetRadius, len(text))
+
+	// Don't cut multi-byte characters in half
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	snippet := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(text) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotsDirName is the subdirectory of the tasks directory holding progress snapshots
+const snapshotsDirName = "snapshots"
+
+// SnapshotTask is the state of one task captured in a progress snapshot
+type SnapshotTask struct {
+	ID     int        `json:"id"`
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+// ProgressSnapshot records the status of every task in a project at a point in time
+type ProgressSnapshot struct {
+	Time  time.Time      `json:"time"`
+	Tasks []SnapshotTask `json:"tasks"`
+}
+
+// NewProgressSnapshot captures the current task statuses of a project
+func NewProgressSnapshot(project *Project, at time.Time) ProgressSnapshot {
+	snapshot := ProgressSnapshot{Time: at, Tasks: make([]SnapshotTask, 0, len(project.Tasks))}
+	for _, t := range project.Tasks {
+		snapshot.Tasks = append(snapshot.Tasks, SnapshotTask{ID: t.ID, Title: t.Title, Status: t.Status})
+	}
+	return snapshot
+}
+
+// SnapshotStatusChange describes a task whose status differs between two snapshots
+type SnapshotStatusChange struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	OldStatus TaskStatus `json:"old_status"`
+	NewStatus TaskStatus `json:"new_status"`
+}
+
+// ProgressDelta is what changed in a project between two snapshots
+type ProgressDelta struct {
+	From          time.Time              `json:"from"`
+	To            time.Time              `json:"to"`
+	Completed     []SnapshotTask         `json:"completed"`
+	Added         []SnapshotTask         `json:"added"`
+	Removed       []SnapshotTask         `json:"removed"`
+	StatusChanged []SnapshotStatusChange `json:"status_changed"`
+}
+
+// DiffSnapshots reports the tasks completed, added, removed and status-changed
+// between from and to. Tasks are matched by ID; a task added already done
+// counts as both added and completed.
+func DiffSnapshots(from, to ProgressSnapshot) ProgressDelta {
+	delta := ProgressDelta{
+		From:          from.Time,
+		To:            to.Time,
+		Completed:     []SnapshotTask{},
+		Added:         []SnapshotTask{},
+		Removed:       []SnapshotTask{},
+		StatusChanged: []SnapshotStatusChange{},
+	}
+
+	before := make(map[int]SnapshotTask, len(from.Tasks))
+	for _, t := range from.Tasks {
+		before[t.ID] = t
+	}
+	after := make(map[int]bool, len(to.Tasks))
+
+	for _, t := range to.Tasks {
+		after[t.ID] = true
+		old, existed := before[t.ID]
+		if !existed {
+			delta.Added = append(delta.Added, t)
+		} else if old.Status != t.Status {
+			delta.StatusChanged = append(delta.StatusChanged, SnapshotStatusChange{
+				ID:        t.ID,
+				Title:     t.Title,
+				OldStatus: old.Status,
+				NewStatus: t.Status,
+			})
+		}
+		if t.Status == StatusDone && (!existed || old.Status != StatusDone) {
+			delta.Completed = append(delta.Completed, t)
+		}
+	}
+
+	for _, t := range from.Tasks {
+		if !after[t.ID] {
+			delta.Removed = append(delta.Removed, t)
+		}
+	}
+
+	return delta
+}
+
+// SnapshotAt returns the latest snapshot taken at or before t. Snapshots must be
+// sorted by time, as returned by LoadSnapshots.
+func SnapshotAt(snapshots []ProgressSnapshot, t time.Time) (ProgressSnapshot, bool) {
+	i := sort.Search(len(snapshots), func(i int) bool {
+		return snapshots[i].Time.After(t)
+	})
+	if i == 0 {
+		return ProgressSnapshot{}, false
+	}
+	return snapshots[i-1], true
+}
+
+// snapshotFilePath returns the path of a project's snapshot file, named after its task file
+func (m *Manager) snapshotFilePath(projectName string) string {
+	base := strings.TrimSuffix(filepath.Base(m.GetTaskFilePath(projectName)), ".md")
+	return filepath.Join(m.tasksDir, snapshotsDirName, base+".json")
+}
+
+// LoadSnapshots returns a project's recorded snapshots, oldest first.
+// A project without any snapshots returns an empty list.
+func (m *Manager) LoadSnapshots(projectName string) ([]ProgressSnapshot, error) {
+	lock := m.projectLock(projectName)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return m.readSnapshots(projectName)
+}
+
+// readSnapshots reads a project's snapshot file. Caller must hold the project lock.
+func (m *Manager) readSnapshots(projectName string) ([]ProgressSnapshot, error) {
+	content, err := os.ReadFile(m.snapshotFilePath(projectName))
+	if os.IsNotExist(err) {
+		return []ProgressSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var snapshots []ProgressSnapshot
+	if err := json.Unmarshal(content, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file: %w", err)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// RecordSnapshot captures the project's current task statuses and appends
+// them to its snapshot file
+func (m *Manager) RecordSnapshot(projectName string) (*ProgressSnapshot, error) {
+	project, err := m.LoadProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := NewProgressSnapshot(project, time.Now().UTC())
+
+	lock := m.projectLock(projectName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	snapshots, err := m.readSnapshots(projectName)
+	if err != nil {
+		return nil, err
+	}
+	snapshots = append(snapshots, snapshot)
+
+	content, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshots: %w", err)
+	}
+
+	filePath := m.snapshotFilePath(projectName)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// snapshotIDs returns the IDs of snapshot tasks, in order
+func snapshotIDs(tasks []SnapshotTask) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	from := ProgressSnapshot{Time: monday, Tasks: []SnapshotTask{
+		{ID: 1, Title: "Schema", Status: StatusTodo},
+		{ID: 2, Title: "Handlers", Status: StatusInProgress},
+		{ID: 3, Title: "Spike", Status: StatusTodo},
+		{ID: 6, Title: "Docs", Status: StatusDone},
+	}}
+	to := ProgressSnapshot{Time: monday.AddDate(0, 0, 7), Tasks: []SnapshotTask{
+		{ID: 1, Title: "Schema", Status: StatusDone},
+		{ID: 2, Title: "Handlers", Status: StatusBlocked},
+		{ID: 4, Title: "Hotfix", Status: StatusDone},
+		{ID: 5, Title: "Release", Status: StatusTodo},
+		{ID: 6, Title: "Docs", Status: StatusDone},
+	}}
+
+	delta := DiffSnapshots(from, to)
+	if !delta.From.Equal(from.Time) || !delta.To.Equal(to.Time) {
+		t.Errorf("window = %s to %s, want %s to %s", delta.From, delta.To, from.Time, to.Time)
+	}
+	if got := snapshotIDs(delta.Completed); !slices.Equal(got, []int{1, 4}) {
+		t.Errorf("completed = %v, want [1 4]", got)
+	}
+	if got := snapshotIDs(delta.Added); !slices.Equal(got, []int{4, 5}) {
+		t.Errorf("added = %v, want [4 5]", got)
+	}
+	if got := snapshotIDs(delta.Removed); !slices.Equal(got, []int{3}) {
+		t.Errorf("removed = %v, want [3]", got)
+	}
+	want := []SnapshotStatusChange{
+		{ID: 1, Title: "Schema", OldStatus: StatusTodo, NewStatus: StatusDone},
+		{ID: 2, Title: "Handlers", OldStatus: StatusInProgress, NewStatus: StatusBlocked},
+	}
+	if len(delta.StatusChanged) != len(want) {
+		t.Fatalf("status changes = %+v, want %+v", delta.StatusChanged, want)
+	}
+	for i := range want {
+		if delta.StatusChanged[i] != want[i] {
+			t.Errorf("status change %d = %+v, want %+v", i, delta.StatusChanged[i], want[i])
+		}
+	}
+
+	// Identical snapshots have an empty, non-nil delta so it encodes as []
+	empty := DiffSnapshots(to, to)
+	if empty.Completed == nil || len(empty.Completed)+len(empty.Added)+len(empty.Removed)+len(empty.StatusChanged) != 0 {
+		t.Errorf("delta of identical snapshots = %+v, want empty lists", empty)
+	}
+}
+
+func TestSnapshotAt(t *testing.T) {
+	first := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 7)
+	snapshots := []ProgressSnapshot{{Time: first}, {Time: second}}
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		want  time.Time
+		found bool
+	}{
+		{"before the first snapshot", first.Add(-time.Hour), time.Time{}, false},
+		{"at the first snapshot", first, first, true},
+		{"between snapshots", first.AddDate(0, 0, 3), first, true},
+		{"after the last snapshot", second.Add(time.Hour), second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := SnapshotAt(snapshots, tt.at)
+			if found != tt.found || !got.Time.Equal(tt.want) {
+				t.Errorf("SnapshotAt(%s) = %s, %v; want %s, %v", tt.at, got.Time, found, tt.want, tt.found)
+			}
+		})
+	}
+
+	if _, found := SnapshotAt(nil, first); found {
+		t.Error("SnapshotAt found a snapshot in an empty list")
+	}
+}
+
+func TestRecordSnapshotAppendsInOrder(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 2)
+
+	if snapshots, err := m.LoadSnapshots("p"); err != nil || len(snapshots) != 0 {
+		t.Fatalf("LoadSnapshots before recording = %v, %v; want an empty list", snapshots, err)
+	}
+
+	first, err := m.RecordSnapshot("p")
+	if err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+	if err := m.UpdateTaskStatus("p", "Task 1", "", StatusDone); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+	second, err := m.RecordSnapshot("p")
+	if err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+
+	snapshots, err := m.LoadSnapshots("p")
+	if err != nil || len(snapshots) != 2 {
+		t.Fatalf("LoadSnapshots = %d snapshots, %v; want 2", len(snapshots), err)
+	}
+	if !snapshots[0].Time.Equal(first.Time) || !snapshots[1].Time.Equal(second.Time) {
+		t.Errorf("snapshot times = %s, %s; want %s, %s", snapshots[0].Time, snapshots[1].Time, first.Time, second.Time)
+	}
+
+	delta := DiffSnapshots(snapshots[0], snapshots[1])
+	if got := snapshotIDs(delta.Completed); !slices.Equal(got, []int{1}) || len(delta.StatusChanged) != 1 {
+		t.Errorf("delta between recorded snapshots = %+v, want task 1 completed", delta)
+	}
+}
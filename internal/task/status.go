@@ -0,0 +1,76 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ApplyStatus sets the status of the task, or of one of its subtasks when
+// subtaskTitle is set, and keeps the two consistent: marking the task done
+// completes its subtasks, completing its last subtask completes the task, and
+// reopening a subtask of a done task moves the task back to in progress. These
+// side effects are returned as messages. With requireSubtasksDone set, marking
+// the task done while it has unfinished subtasks fails with ErrConflict
+// instead of completing them.
+func (t *Task) ApplyStatus(subtaskTitle string, status TaskStatus, requireSubtasksDone bool) ([]string, error) {
+	var sideEffects []string
+
+	if subtaskTitle == "" {
+		if status == StatusDone && requireSubtasksDone {
+			if incomplete := incompleteSubtaskTitles(t); len(incomplete) > 0 {
+				return nil, NewError(ErrConflict,
+					"task '%s' has %d incomplete subtasks (%s); complete them first or pass force=true to complete them along with the task",
+					t.Title, len(incomplete), strings.Join(incomplete, ", "))
+			}
+		}
+
+		// When marking a task as done, auto-complete all of its subtasks
+		if status == StatusDone {
+			for i := range t.Subtasks {
+				if t.Subtasks[i].Status != StatusDone {
+					t.Subtasks[i].SetStatus(StatusDone)
+					sideEffects = append(sideEffects, fmt.Sprintf("Auto-completed subtask '%s'", t.Subtasks[i].Title))
+				}
+			}
+		}
+		t.SetStatus(status)
+		return sideEffects, nil
+	}
+
+	for i := range t.Subtasks {
+		if t.Subtasks[i].Title != subtaskTitle {
+			continue
+		}
+
+		t.Subtasks[i].SetStatus(status)
+		t.UpdatedAt = time.Now().UTC()
+
+		// If this was the last subtask to be completed, the task is done too
+		if status == StatusDone && t.Status != StatusDone && t.CanBeMarkedComplete() {
+			t.SetStatus(StatusDone)
+			sideEffects = append(sideEffects, fmt.Sprintf("Auto-completed main task '%s' (all subtasks done)", t.Title))
+		}
+
+		// A done task with unfinished work is back in progress
+		if status != StatusDone && t.Status == StatusDone {
+			t.SetStatus(StatusInProgress)
+			sideEffects = append(sideEffects,
+				fmt.Sprintf("Moved main task '%s' back to in_progress (subtask '%s' is %s)", t.Title, subtaskTitle, status))
+		}
+		return sideEffects, nil
+	}
+
+	return nil, NewError(ErrNotFound, "subtask '%s' not found in task '%s'", subtaskTitle, t.Title)
+}
+
+// incompleteSubtaskTitles returns the titles of a task's subtasks that are not done
+func incompleteSubtaskTitles(t *Task) []string {
+	var titles []string
+	for _, subtask := range t.Subtasks {
+		if subtask.Status != StatusDone {
+			titles = append(titles, subtask.Title)
+		}
+	}
+	return titles
+}
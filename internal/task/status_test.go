@@ -0,0 +1,88 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+// statusTestTask returns an in-progress task with a done and a todo subtask
+func statusTestTask() Task {
+	return Task{
+		Title:  "Ship it",
+		Status: StatusInProgress,
+		Subtasks: []Subtask{
+			{Title: "Build", Status: StatusDone},
+			{Title: "Tag release", Status: StatusTodo},
+		},
+	}
+}
+
+func TestApplyStatus(t *testing.T) {
+	tests := []struct {
+		name                string
+		subtask             string
+		status              TaskStatus
+		requireSubtasksDone bool
+		start               TaskStatus
+		wantTask            TaskStatus
+		wantSubtasks        []TaskStatus
+		wantSideEffects     int
+		wantErr             error
+	}{
+		{"task done completes subtasks", "", StatusDone, false, StatusInProgress, StatusDone, []TaskStatus{StatusDone, StatusDone}, 1, nil},
+		{"task done refused with open subtasks", "", StatusDone, true, StatusInProgress, StatusInProgress, []TaskStatus{StatusDone, StatusTodo}, 0, ErrConflict},
+		{"task blocked leaves subtasks", "", StatusBlocked, true, StatusInProgress, StatusBlocked, []TaskStatus{StatusDone, StatusTodo}, 0, nil},
+		{"last subtask completes task", "Tag release", StatusDone, true, StatusInProgress, StatusDone, []TaskStatus{StatusDone, StatusDone}, 1, nil},
+		{"subtask blocked keeps task open", "Tag release", StatusBlocked, false, StatusInProgress, StatusInProgress, []TaskStatus{StatusDone, StatusBlocked}, 0, nil},
+		{"reopened subtask reopens done task", "Build", StatusInProgress, false, StatusDone, StatusInProgress, []TaskStatus{StatusInProgress, StatusTodo}, 1, nil},
+		{"missing subtask", "Deploy", StatusDone, false, StatusInProgress, StatusInProgress, []TaskStatus{StatusDone, StatusTodo}, 0, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := statusTestTask()
+			task.Status = tt.start
+
+			sideEffects, err := task.ApplyStatus(tt.subtask, tt.status, tt.requireSubtasksDone)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ApplyStatus error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ApplyStatus: %v", err)
+			}
+			if task.Status != tt.wantTask {
+				t.Errorf("task status = %s, want %s", task.Status, tt.wantTask)
+			}
+			for i, want := range tt.wantSubtasks {
+				if got := task.Subtasks[i].Status; got != want {
+					t.Errorf("subtask %q status = %s, want %s", task.Subtasks[i].Title, got, want)
+				}
+			}
+			if len(sideEffects) != tt.wantSideEffects {
+				t.Errorf("side effects = %q, want %d", sideEffects, tt.wantSideEffects)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskStatusPersistsBlockedSubtask(t *testing.T) {
+	m := newTestManager(t)
+	newTestProject(t, m, "p", 1)
+
+	if err := m.UpdateTaskStatus("p", "Task 1", "Step", StatusBlocked); err != nil {
+		t.Fatalf("UpdateTaskStatus: %v", err)
+	}
+
+	// Re-parse the file rather than reading the cached project
+	m.InvalidateCache("p")
+	project, err := m.LoadProject("p")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if got := project.Tasks[0].Subtasks[0].Status; got != StatusBlocked {
+		t.Errorf("subtask status after reload = %s, want blocked", got)
+	}
+	if got := project.Tasks[0].Status; got != StatusTodo {
+		t.Errorf("task status after reload = %s, want todo", got)
+	}
+}
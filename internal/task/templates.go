@@ -0,0 +1,148 @@
+package task
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// templateFiles holds the project templates, one markdown file per template
+// named after it
+//
+//go:embed templates/*.md
+var templateFiles embed.FS
+
+// templateParser parses templates, which are written with the default labels
+// and heading level whatever the manager is configured with
+var templateParser = &Manager{
+	config:        DefaultManagerConfig(),
+	headerPattern: compileTaskHeaderPattern(DefaultMarkdownLabels().Task),
+}
+
+// ProjectTemplate describes a template a project can be created from
+type ProjectTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TaskCount   int    `json:"task_count"`
+}
+
+// ListTemplates returns the available project templates sorted by name
+func ListTemplates() ([]ProjectTemplate, error) {
+	entries, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+
+	templates := make([]ProjectTemplate, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		project, err := loadTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, ProjectTemplate{
+			Name:        name,
+			Description: project.Description,
+			TaskCount:   len(project.Tasks),
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// loadTemplate parses the named template into a project
+func loadTemplate(name string) (*Project, error) {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return nil, NewError(ErrNotFound, "template '%s' not found", name)
+	}
+
+	content, err := templateFiles.ReadFile(path.Join("templates", name+".md"))
+	if err != nil {
+		return nil, NewError(ErrNotFound, "template '%s' not found", name)
+	}
+
+	project, err := templateParser.parseMarkdown(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", name, err)
+	}
+	return project, nil
+}
+
+// CreateProjectFromTemplate creates a project pre-populated with the tasks
+// and subtasks of the named template. It fails if the project already exists.
+func (m *Manager) CreateProjectFromTemplate(projectName, templateName string) (*Project, error) {
+	project, err := loadTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	if m.ProjectExists(projectName) {
+		return nil, NewError(ErrConflict, "project '%s' already exists", projectName)
+	}
+
+	now := time.Now().UTC()
+	project.Name = projectName
+	project.CreatedAt = now
+	project.UpdatedAt = now
+	for i := range project.Tasks {
+		t := &project.Tasks[i]
+		t.CreatedAt = now
+		t.UpdatedAt = now
+		for j := range t.Subtasks {
+			t.Subtasks[j].CreatedAt = now
+			t.Subtasks[j].UpdatedAt = now
+		}
+	}
+
+	if err := m.ImportProject(project, false); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// subtaskTemplatesJSON holds the subtask templates: named checklists of
+// subtasks that can be applied to any task
+//
+//go:embed subtask_templates.json
+var subtaskTemplatesJSON []byte
+
+// SubtaskTemplate is a named checklist of subtasks
+type SubtaskTemplate struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Subtasks    []string `json:"subtasks"`
+}
+
+// ListSubtaskTemplates returns the available subtask templates sorted by name
+func ListSubtaskTemplates() ([]SubtaskTemplate, error) {
+	var templates []SubtaskTemplate
+	if err := json.Unmarshal(subtaskTemplatesJSON, &templates); err != nil {
+		return nil, fmt.Errorf("failed to read subtask templates: %w", err)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// GetSubtaskTemplate returns the named subtask template
+func GetSubtaskTemplate(name string) (SubtaskTemplate, error) {
+	templates, err := ListSubtaskTemplates()
+	if err != nil {
+		return SubtaskTemplate{}, err
+	}
+
+	for _, template := range templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+	return SubtaskTemplate{}, NewError(ErrNotFound, "subtask template '%s' not found", name)
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTemplatesParse(t *testing.T) {
+	templates, err := ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates: %v", err)
+	}
+	entries, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(templates) == 0 || len(templates) != len(entries) {
+		t.Fatalf("ListTemplates returned %d templates for %d files", len(templates), len(entries))
+	}
+
+	for _, template := range templates {
+		project, err := loadTemplate(template.Name)
+		if err != nil {
+			t.Errorf("%s: %v", template.Name, err)
+			continue
+		}
+		if template.Description == "" || template.TaskCount == 0 || template.TaskCount != len(project.Tasks) {
+			t.Errorf("%s: listed as %+v, parsed %d tasks", template.Name, template, len(project.Tasks))
+		}
+		for i, task := range project.Tasks {
+			if task.ID != i+1 || task.Title == "" || task.Description == "" {
+				t.Errorf("%s: task %d = %+v, want an ID, title and description", template.Name, i+1, task)
+			}
+			if task.Status != StatusTodo {
+				t.Errorf("%s: task '%s' is %s, want todo", template.Name, task.Title, task.Status)
+			}
+		}
+	}
+}
+
+func TestCreateProjectFromTemplate(t *testing.T) {
+	m := newTestManager(t)
+
+	created, err := m.CreateProjectFromTemplate("site", "web-app")
+	if err != nil {
+		t.Fatalf("CreateProjectFromTemplate: %v", err)
+	}
+	template, err := loadTemplate("web-app")
+	if err != nil {
+		t.Fatalf("loadTemplate: %v", err)
+	}
+	if len(created.Tasks) != len(template.Tasks) {
+		t.Errorf("created %d tasks, template has %d", len(created.Tasks), len(template.Tasks))
+	}
+
+	m.InvalidateCache("site")
+	project, err := m.LoadProject("site")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if project.Name != "site" || len(project.Tasks) != len(template.Tasks) {
+		t.Errorf("reloaded project %q has %d tasks, want site with %d", project.Name, len(project.Tasks), len(template.Tasks))
+	}
+	for i, task := range project.Tasks {
+		if task.Title != template.Tasks[i].Title || len(task.Subtasks) != len(template.Tasks[i].Subtasks) || task.CreatedAt.IsZero() {
+			t.Errorf("reloaded task %d = %+v, want it to match the template", i+1, task)
+		}
+	}
+
+	failures := []struct {
+		name     string
+		project  string
+		template string
+		want     error
+	}{
+		{"existing project", "site", "cli-tool", ErrConflict},
+		{"unknown template", "other", "mobile-app", ErrNotFound},
+		{"path in template name", "other", "../templates/web-app", ErrNotFound},
+		{"empty template name", "other", "", ErrNotFound},
+	}
+	for _, tt := range failures {
+		if _, err := m.CreateProjectFromTemplate(tt.project, tt.template); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if m.ProjectExists("other") {
+		t.Error("a failed call created project 'other'")
+	}
+	m.InvalidateCache("site")
+	if project, err = m.LoadProject("site"); err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if n := len(project.Tasks); n != len(template.Tasks) {
+		t.Errorf("existing project has %d tasks after the conflict, want %d", n, len(template.Tasks))
+	}
+}
+
+func TestSubtaskTemplates(t *testing.T) {
+	templates, err := ListSubtaskTemplates()
+	if err != nil {
+		t.Fatalf("ListSubtaskTemplates: %v", err)
+	}
+	if len(templates) == 0 {
+		t.Fatal("no subtask templates")
+	}
+	for _, template := range templates {
+		if template.Name == "" || template.Description == "" || len(template.Subtasks) == 0 {
+			t.Errorf("template %+v is missing a name, description or subtasks", template)
+		}
+		seen := make(map[string]bool, len(template.Subtasks))
+		for _, title := range template.Subtasks {
+			if seen[title] {
+				t.Errorf("%s: subtask '%s' is listed twice", template.Name, title)
+			}
+			seen[title] = true
+		}
+		if got, err := GetSubtaskTemplate(template.Name); err != nil || got.Name != template.Name {
+			t.Errorf("GetSubtaskTemplate(%q) = %+v, %v", template.Name, got, err)
+		}
+	}
+
+	if _, err := GetSubtaskTemplate("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown template: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimelineEventType identifies what happened in a timeline event
+type TimelineEventType string
+
+const (
+	TimelineCreated        TimelineEventType = "created"
+	TimelineUpdated        TimelineEventType = "updated"
+	TimelineCompleted      TimelineEventType = "completed"
+	TimelineChoiceCreated  TimelineEventType = "choice_created"
+	TimelineChoiceResolved TimelineEventType = "choice_resolved"
+)
+
+// TimelineEvent is a single dated entry in a task's history
+type TimelineEvent struct {
+	Time         time.Time         `json:"time"`
+	Type         TimelineEventType `json:"type"`
+	SubtaskTitle string            `json:"subtask_title,omitempty"`
+	Description  string            `json:"description"`
+}
+
+// Timeline returns the task's history as a chronologically ordered list of
+// events built from its timestamps, its subtasks' timestamps and its choices
+func (t *Task) Timeline() []TimelineEvent {
+	var events []TimelineEvent
+
+	events = append(events, itemTimeline(t.Title, "", t.CreatedAt, t.UpdatedAt, t.CompletedAt)...)
+	events = append(events, choiceTimeline(t.Choices, "")...)
+
+	for _, subtask := range t.Subtasks {
+		events = append(events, itemTimeline(subtask.Title, subtask.Title, subtask.CreatedAt, subtask.UpdatedAt, subtask.CompletedAt)...)
+		events = append(events, choiceTimeline(subtask.Choices, subtask.Title)...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events
+}
+
+// itemTimeline builds the created/updated/completed events for a task or subtask
+func itemTimeline(title, subtaskTitle string, createdAt, updatedAt time.Time, completedAt *time.Time) []TimelineEvent {
+	kind := "Task"
+	if subtaskTitle != "" {
+		kind = "Subtask"
+	}
+
+	var events []TimelineEvent
+	if !createdAt.IsZero() {
+		events = append(events, TimelineEvent{
+			Time:         createdAt,
+			Type:         TimelineCreated,
+			SubtaskTitle: subtaskTitle,
+			Description:  fmt.Sprintf("%s '%s' created", kind, title),
+		})
+	}
+	if completedAt != nil {
+		events = append(events, TimelineEvent{
+			Time:         *completedAt,
+			Type:         TimelineCompleted,
+			SubtaskTitle: subtaskTitle,
+			Description:  fmt.Sprintf("%s '%s' completed", kind, title),
+		})
+	}
+	// Only report the last update when it adds information beyond creation/completion
+	if !updatedAt.IsZero() && updatedAt.After(createdAt) && (completedAt == nil || !updatedAt.Equal(*completedAt)) {
+		events = append(events, TimelineEvent{
+			Time:         updatedAt,
+			Type:         TimelineUpdated,
+			SubtaskTitle: subtaskTitle,
+			Description:  fmt.Sprintf("%s '%s' last updated", kind, title),
+		})
+	}
+	return events
+}
+
+// choiceTimeline builds the created/resolved events for a list of choices
+func choiceTimeline(choices []Choice, subtaskTitle string) []TimelineEvent {
+	var events []TimelineEvent
+	for _, choice := range choices {
+		if !choice.CreatedAt.IsZero() {
+			events = append(events, TimelineEvent{
+				Time:         choice.CreatedAt,
+				Type:         TimelineChoiceCreated,
+				SubtaskTitle: subtaskTitle,
+				Description:  fmt.Sprintf("Choice raised: %s", choice.Question),
+			})
+		}
+		if choice.ResolvedAt != nil {
+			description := fmt.Sprintf("Choice resolved: %s -> %s", choice.Question, choice.Selected)
+			if choice.Reasoning != "" {
+				description += fmt.Sprintf(" (%s)", choice.Reasoning)
+			}
+			events = append(events, TimelineEvent{
+				Time:         *choice.ResolvedAt,
+				Type:         TimelineChoiceResolved,
+				SubtaskTitle: subtaskTitle,
+				Description:  description,
+			})
+		}
+	}
+	return events
+}
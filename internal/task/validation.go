@@ -12,17 +12,17 @@ func ValidateTaskStatus(status string) (TaskStatus, error) {
 	case StatusTodo, StatusInProgress, StatusDone, StatusBlocked:
 		return TaskStatus(status), nil
 	default:
-		return "", fmt.Errorf("invalid task status: %s. Valid options: todo, in_progress, done, blocked", status)
+		return "", NewError(ErrInvalidInput, "invalid task status: %s. Valid options: todo, in_progress, done, blocked", status)
 	}
 }
 
 // ValidateTaskCategory checks if a task category is valid
 func ValidateTaskCategory(category string) (TaskCategory, error) {
 	switch TaskCategory(category) {
-	case CategoryMVP, CategoryAI, CategoryUX, CategoryInfra:
+	case CategoryMVP, CategoryAI, CategoryUX, CategoryInfra, CategoryGeneral:
 		return TaskCategory(category), nil
 	default:
-		return "", fmt.Errorf("invalid task category: %s. Valid options: [MVP], [AI], [UX], [INFRA]", category)
+		return "", NewError(ErrInvalidInput, "invalid task category: %s. Valid options: [MVP], [AI], [UX], [INFRA], [GENERAL]", category)
 	}
 }
 
@@ -32,7 +32,7 @@ func ValidateTaskPriority(priority string) (TaskPriority, error) {
 	case PriorityP0, PriorityP1, PriorityP2, PriorityP3:
 		return TaskPriority(priority), nil
 	default:
-		return "", fmt.Errorf("invalid task priority: %s. Valid options: P0, P1, P2, P3", priority)
+		return "", NewError(ErrInvalidInput, "invalid task priority: %s. Valid options: P0, P1, P2, P3", priority)
 	}
 }
 
@@ -42,66 +42,178 @@ func ValidateTaskComplexity(complexity string) (TaskComplexity, error) {
 	case ComplexityLow, ComplexityMedium, ComplexityHigh:
 		return TaskComplexity(complexity), nil
 	default:
-		return "", fmt.Errorf("invalid task complexity: %s. Valid options: low, medium, high", complexity)
+		return "", NewError(ErrInvalidInput, "invalid task complexity: %s. Valid options: low, medium, high", complexity)
 	}
 }
 
 // ValidateProjectName checks if a project name is valid
 func ValidateProjectName(name string) error {
 	if strings.TrimSpace(name) == "" {
-		return fmt.Errorf("project name cannot be empty")
+		return NewError(ErrInvalidInput, "project name cannot be empty")
 	}
 
 	// Check for invalid characters that might cause file system issues
 	invalidChars := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
 	for _, char := range invalidChars {
 		if strings.Contains(name, char) {
-			return fmt.Errorf("project name contains invalid character: %s", char)
+			return NewError(ErrInvalidInput, "project name contains invalid character: %s", char)
 		}
 	}
 
 	return nil
 }
 
-// ValidateTaskTitle checks if a task title is valid
-func ValidateTaskTitle(title string) error {
-	if strings.TrimSpace(title) == "" {
-		return fmt.Errorf("task title cannot be empty")
+// ValidateTaskTitle checks if a task title is valid and returns it trimmed.
+// Titles are matched exactly, so stored titles must never carry edge whitespace.
+func ValidateTaskTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", NewError(ErrInvalidInput, "task title cannot be empty")
 	}
 
 	if len(title) > 200 {
-		return fmt.Errorf("task title too long (max 200 characters)")
+		return "", NewError(ErrInvalidInput, "task title too long (max 200 characters)")
 	}
 
-	return nil
+	return title, nil
 }
 
 // ValidateTaskDescription checks if a task description is valid
 func ValidateTaskDescription(description string) error {
 	if strings.TrimSpace(description) == "" {
-		return fmt.Errorf("task description cannot be empty")
+		return NewError(ErrInvalidInput, "task description cannot be empty")
 	}
 
 	if len(description) > 5000 {
-		return fmt.Errorf("task description too long (max 5000 characters)")
+		return NewError(ErrInvalidInput, "task description too long (max 5000 characters)")
 	}
 
 	return nil
 }
 
+// ValidateProjectDescription checks a project description and returns it
+// trimmed; an empty description is allowed. The description is written under
+// the project's title heading, so none of its lines can be a heading.
+func ValidateProjectDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if len(description) > 5000 {
+		return "", NewError(ErrInvalidInput, "project description too long (max 5000 characters)")
+	}
+	for _, line := range strings.Split(description, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			return "", NewError(ErrInvalidInput, "project description lines cannot start with '#': %s", strings.TrimSpace(line))
+		}
+	}
+	return description, nil
+}
+
+// NormalizeTag returns the canonical form of a tag: trimmed and lowercased
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ValidateTag checks if a tag is valid and returns its normalized form
+func ValidateTag(tag string) (string, error) {
+	normalized := NormalizeTag(tag)
+	if normalized == "" {
+		return "", NewError(ErrInvalidInput, "tag cannot be empty")
+	}
+	if len(normalized) > 50 {
+		return "", NewError(ErrInvalidInput, "tag too long (max 50 characters)")
+	}
+	if strings.ContainsAny(normalized, ",\n") {
+		return "", NewError(ErrInvalidInput, "tag cannot contain commas or newlines: %s", tag)
+	}
+	return normalized, nil
+}
+
+// ValidateDoneCriterion checks a definition-of-done criterion and returns it trimmed.
+// Criteria are stored one per markdown line, so they cannot contain newlines.
+func ValidateDoneCriterion(criterion string) (string, error) {
+	criterion = strings.TrimSpace(criterion)
+	if criterion == "" {
+		return "", NewError(ErrInvalidInput, "done criterion cannot be empty")
+	}
+	if len(criterion) > 500 {
+		return "", NewError(ErrInvalidInput, "done criterion too long (max 500 characters)")
+	}
+	if strings.ContainsAny(criterion, "\r\n") {
+		return "", NewError(ErrInvalidInput, "done criterion cannot contain newlines: %s", criterion)
+	}
+	return criterion, nil
+}
+
+// ValidateComment checks a task comment and returns its author and text
+// trimmed. Comments are stored one per markdown line, so runs of whitespace
+// in the text, including newlines, become single spaces.
+func ValidateComment(author, text string) (string, string, error) {
+	author = strings.TrimSpace(author)
+	if len(author) > 100 {
+		return "", "", NewError(ErrInvalidInput, "comment author too long (max 100 characters)")
+	}
+	if strings.ContainsAny(author, "*\r\n") {
+		return "", "", NewError(ErrInvalidInput, "comment author cannot contain '*' or newlines: %s", author)
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return "", "", NewError(ErrInvalidInput, "comment text cannot be empty")
+	}
+	if len(text) > 2000 {
+		return "", "", NewError(ErrInvalidInput, "comment text too long (max 2000 characters)")
+	}
+	return author, text, nil
+}
+
+// ValidateAssignee checks a task assignee and returns it trimmed. Assignees
+// are written on a markdown heading line, so they cannot contain newlines.
+func ValidateAssignee(assignee string) (string, error) {
+	assignee = strings.TrimSpace(assignee)
+	if assignee == "" {
+		return "", NewError(ErrInvalidInput, "assignee cannot be empty")
+	}
+	if len(assignee) > 100 {
+		return "", NewError(ErrInvalidInput, "assignee too long (max 100 characters)")
+	}
+	if strings.ContainsAny(assignee, "\r\n") {
+		return "", NewError(ErrInvalidInput, "assignee cannot contain newlines: %s", assignee)
+	}
+	return assignee, nil
+}
+
+// ValidateBlockedReason checks why a task is blocked and returns the reason
+// trimmed. Reasons are written on a markdown heading line, so runs of
+// whitespace, including newlines, become single spaces.
+func ValidateBlockedReason(reason string) (string, error) {
+	reason = strings.Join(strings.Fields(reason), " ")
+	if reason == "" {
+		return "", NewError(ErrInvalidInput, "blocked reason cannot be empty")
+	}
+	if len(reason) > 500 {
+		return "", NewError(ErrInvalidInput, "blocked reason too long (max 500 characters)")
+	}
+	return reason, nil
+}
+
 // ValidateChoice checks if a choice is valid
 func ValidateChoice(choice Choice) error {
 	if strings.TrimSpace(choice.Question) == "" {
-		return fmt.Errorf("choice question cannot be empty")
+		return NewError(ErrInvalidInput, "choice question cannot be empty")
+	}
+	if strings.ContainsAny(choice.Question, "\r\n") {
+		return NewError(ErrInvalidInput, "choice question cannot contain newlines")
 	}
 
 	if len(choice.Options) < 2 {
-		return fmt.Errorf("choice must have at least 2 options")
+		return NewError(ErrInvalidInput, "choice must have at least 2 options")
 	}
 
 	for i, option := range choice.Options {
 		if strings.TrimSpace(option) == "" {
-			return fmt.Errorf("choice option %d cannot be empty", i+1)
+			return NewError(ErrInvalidInput, "choice option %d cannot be empty", i+1)
+		}
+		if strings.ContainsAny(option, "\r\n") {
+			return NewError(ErrInvalidInput, "choice option %d cannot contain newlines", i+1)
 		}
 	}
 
@@ -114,13 +226,114 @@ func ValidateChoice(choice Choice) error {
 			}
 		}
 		if !found {
-			return fmt.Errorf("selected option '%s' is not in the available options", choice.Selected)
+			return NewError(ErrInvalidInput, "selected option '%s' is not in the available options", choice.Selected)
 		}
 	}
 
 	return nil
 }
 
+// ValidateProject checks a loaded project for structural problems such as
+// malformed choices, duplicate task titles and dependencies on missing tasks
+func ValidateProject(project *Project) []ValidationIssue {
+	var issues []ValidationIssue
+
+	taskIDs := make(map[int]bool, len(project.Tasks))
+	for _, task := range project.Tasks {
+		taskIDs[task.ID] = true
+	}
+
+	seenTitles := make(map[string]bool, len(project.Tasks))
+	for _, task := range project.Tasks {
+		if seenTitles[task.Title] {
+			issues = append(issues, ValidationIssue{
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Type:      "duplicate_title",
+				Message:   fmt.Sprintf("Task title '%s' is used by more than one task", task.Title),
+			})
+		}
+		seenTitles[task.Title] = true
+
+		for _, depID := range task.Dependencies {
+			if !taskIDs[depID] {
+				issues = append(issues, ValidationIssue{
+					TaskID:    task.ID,
+					TaskTitle: task.Title,
+					Type:      "missing_dependency",
+					Message:   fmt.Sprintf("Depends on task %d, which does not exist", depID),
+				})
+			}
+		}
+
+		for _, choice := range task.Choices {
+			if err := validateParsedChoice(choice); err != nil {
+				issues = append(issues, ValidationIssue{
+					TaskID:    task.ID,
+					TaskTitle: task.Title,
+					Type:      "invalid_choice",
+					Message:   fmt.Sprintf("Choice '%s': %v", choice.Question, err),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// validateParsedChoice checks a choice read back from markdown. Resolved
+// choices with a single option are decision records (e.g. breakdown reasoning)
+// and are allowed; pending choices need at least two options to be answerable.
+func validateParsedChoice(choice Choice) error {
+	if len(choice.Options) == 0 {
+		return fmt.Errorf("choice has no options")
+	}
+	if choice.ResolvedAt != nil && len(choice.Options) == 1 {
+		if strings.TrimSpace(choice.Question) == "" {
+			return fmt.Errorf("choice question cannot be empty")
+		}
+		return nil
+	}
+	return ValidateChoice(choice)
+}
+
+// ResolveTaskTitle finds a task by title. An exact title match always wins; when
+// allowPartial is set, a case-insensitive substring that matches exactly one task
+// title is accepted too. Ambiguous substrings return an error listing the candidates.
+func ResolveTaskTitle(project *Project, query string, allowPartial bool) (*Task, int, error) {
+	for i := range project.Tasks {
+		if project.Tasks[i].Title == query {
+			return &project.Tasks[i], i, nil
+		}
+	}
+
+	if allowPartial && strings.TrimSpace(query) != "" {
+		needle := strings.ToLower(strings.TrimSpace(query))
+		var matches []int
+		for i := range project.Tasks {
+			if strings.Contains(strings.ToLower(project.Tasks[i].Title), needle) {
+				matches = append(matches, i)
+			}
+		}
+
+		switch len(matches) {
+		case 1:
+			return &project.Tasks[matches[0]], matches[0], nil
+		case 0:
+			// fall through to the not-found error below
+		default:
+			candidates := make([]string, len(matches))
+			for j, index := range matches {
+				candidates[j] = fmt.Sprintf("'%s'", project.Tasks[index].Title)
+			}
+			return nil, -1, NewError(ErrInvalidInput, "task title '%s' is ambiguous; it matches %d tasks: %s",
+				query, len(matches), strings.Join(candidates, ", "))
+		}
+	}
+
+	return nil, -1, NewError(ErrNotFound, "task '%s' not found in project '%s'", query, project.Name)
+}
+
 // SanitizeProjectName sanitizes a project name for file system use
 func SanitizeProjectName(name string) string {
 	// Replace invalid characters with underscores
@@ -191,8 +404,60 @@ func ShouldAutoMarkTaskDone(task *Task) bool {
 	return false
 }
 
+// hoursSince returns the hours elapsed since t, comparing both times in UTC so
+// the result doesn't depend on the zone t was recorded or parsed in
+func hoursSince(t time.Time) float64 {
+	return time.Now().UTC().Sub(t.UTC()).Hours()
+}
+
+// daysSince returns the days elapsed since t, compared in UTC
+func daysSince(t time.Time) float64 {
+	return hoursSince(t) / 24
+}
+
+// CompletionRules are the thresholds for flagging tasks that may need attention
+// and whether parents are completed automatically
+type CompletionRules struct {
+	// StaleDays is how long an in-progress task can go without updates
+	StaleDays int `json:"stale_days"`
+	// TodoAgeDays is how long a todo task without subtasks can wait
+	TodoAgeDays int `json:"todo_age_days"`
+	// SubtaskStaleDays is how long an in-progress subtask can go without updates
+	SubtaskStaleDays int `json:"subtask_stale_days"`
+	// KeepParentsOpen stops a task being marked done automatically once all
+	// its subtasks are done
+	KeepParentsOpen bool `json:"keep_parents_open,omitempty"`
+}
+
+// DefaultCompletionRules returns the default completion rules. The zero value
+// of CompletionRules behaves the same.
+func DefaultCompletionRules() CompletionRules {
+	return CompletionRules{
+		StaleDays:        7,
+		TodoAgeDays:      14,
+		SubtaskStaleDays: 5,
+	}
+}
+
+// withDefaults fills unset (non-positive) thresholds from DefaultCompletionRules
+func (r CompletionRules) withDefaults() CompletionRules {
+	defaults := DefaultCompletionRules()
+	if r.StaleDays <= 0 {
+		r.StaleDays = defaults.StaleDays
+	}
+	if r.TodoAgeDays <= 0 {
+		r.TodoAgeDays = defaults.TodoAgeDays
+	}
+	if r.SubtaskStaleDays <= 0 {
+		r.SubtaskStaleDays = defaults.SubtaskStaleDays
+	}
+	return r
+}
+
 // ShouldPromptForCompletion evaluates if we should ask the LLM about task completion
-func ShouldPromptForCompletion(task *Task) bool {
+func ShouldPromptForCompletion(task *Task, rules CompletionRules) bool {
+	rules = rules.withDefaults()
+
 	// Don't prompt if already done or blocked
 	if task.Status == StatusDone || task.Status == StatusBlocked {
 		return false
@@ -201,24 +466,24 @@ func ShouldPromptForCompletion(task *Task) bool {
 	// Prompt if task has been in progress for more than estimated time
 	if task.Status == StatusInProgress && task.EstimatedHours > 0 {
 		// If task was updated more than estimated hours ago, prompt
-		hoursSinceUpdate := time.Since(task.UpdatedAt).Hours()
+		hoursSinceUpdate := hoursSince(task.UpdatedAt)
 		if hoursSinceUpdate > float64(task.EstimatedHours) {
 			return true
 		}
 	}
 
-	// Prompt if task has been in progress for more than 7 days without updates
+	// Prompt if task has been in progress for more than StaleDays without updates
 	if task.Status == StatusInProgress {
-		daysSinceUpdate := time.Since(task.UpdatedAt).Hours() / 24
-		if daysSinceUpdate > 7 {
+		daysSinceUpdate := daysSince(task.UpdatedAt)
+		if daysSinceUpdate > float64(rules.StaleDays) {
 			return true
 		}
 	}
 
-	// Prompt if task has no subtasks and has been todo for more than 14 days
+	// Prompt if task has no subtasks and has been todo for more than TodoAgeDays
 	if task.Status == StatusTodo && len(task.Subtasks) == 0 {
-		daysSinceCreation := time.Since(task.CreatedAt).Hours() / 24
-		if daysSinceCreation > 14 {
+		daysSinceCreation := daysSince(task.CreatedAt)
+		if daysSinceCreation > float64(rules.TodoAgeDays) {
 			return true
 		}
 	}
@@ -227,7 +492,7 @@ func ShouldPromptForCompletion(task *Task) bool {
 }
 
 // AutoUpdateTaskStatuses updates task statuses based on automatic rules
-func AutoUpdateTaskStatuses(project *Project) ([]string, bool) {
+func AutoUpdateTaskStatuses(project *Project, rules CompletionRules) ([]string, bool) {
 	var updates []string
 	hasChanges := false
 
@@ -235,9 +500,8 @@ func AutoUpdateTaskStatuses(project *Project) ([]string, bool) {
 		task := &project.Tasks[i]
 
 		// Check if task should be auto-marked as done
-		if task.Status != StatusDone && ShouldAutoMarkTaskDone(task) {
-			task.Status = StatusDone
-			task.UpdatedAt = time.Now()
+		if !rules.KeepParentsOpen && task.Status != StatusDone && ShouldAutoMarkTaskDone(task) {
+			task.SetStatus(StatusDone)
 			updates = append(updates, fmt.Sprintf("Auto-completed task '%s' (all subtasks done)", task.Title))
 			hasChanges = true
 		}
@@ -271,13 +535,12 @@ func validateCompletionConsistency(task *Task) []string {
 			if task.Subtasks[i].Status != StatusDone {
 				hasIncompleteSubtasks = true
 				// Auto-complete the subtask to maintain consistency
-				task.Subtasks[i].Status = StatusDone
-				task.Subtasks[i].UpdatedAt = time.Now()
+				task.Subtasks[i].SetStatus(StatusDone)
 				updates = append(updates, fmt.Sprintf("Auto-completed subtask '%s' for consistency (main task was done)", task.Subtasks[i].Title))
 			}
 		}
 		if hasIncompleteSubtasks {
-			task.UpdatedAt = time.Now()
+			task.UpdatedAt = time.Now().UTC()
 		}
 	}
 
@@ -292,8 +555,7 @@ func autoUpdateSubtaskCompletion(task *Task) []string {
 	if task.Status == StatusDone {
 		for i := range task.Subtasks {
 			if task.Subtasks[i].Status != StatusDone {
-				task.Subtasks[i].Status = StatusDone
-				task.Subtasks[i].UpdatedAt = time.Now()
+				task.Subtasks[i].SetStatus(StatusDone)
 				updates = append(updates, fmt.Sprintf("Auto-completed subtask '%s' (main task done)", task.Subtasks[i].Title))
 			}
 		}
@@ -303,27 +565,45 @@ func autoUpdateSubtaskCompletion(task *Task) []string {
 }
 
 // GetTasksNeedingAttention returns tasks that might need manual review
-func GetTasksNeedingAttention(project *Project) []TaskAttention {
+func GetTasksNeedingAttention(project *Project, rules CompletionRules) []TaskAttention {
 	var attention []TaskAttention
+	rules = rules.withDefaults()
 
-	for _, task := range project.Tasks {
-		if ShouldPromptForCompletion(&task) {
-			reason := getAttentionReason(&task)
+	taskByID := make(map[int]*Task, len(project.Tasks))
+	for i := range project.Tasks {
+		taskByID[project.Tasks[i].ID] = &project.Tasks[i]
+	}
+
+	// Index into the slices so each TaskAttention points at its own task and subtask
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		if reason, blocked := getBlockedReason(task, taskByID); blocked {
 			attention = append(attention, TaskAttention{
-				Task:   &task,
+				Task:     task,
+				Reason:   reason,
+				Type:     AttentionTypeBlocked,
+				Severity: blockedSeverity(task.Priority),
+			})
+		}
+
+		if ShouldPromptForCompletion(task, rules) {
+			reason := getAttentionReason(task, rules)
+			attention = append(attention, TaskAttention{
+				Task:   task,
 				Reason: reason,
 				Type:   AttentionTypeCompletion,
 			})
 		}
 
 		// Check for stale subtasks
-		for _, subtask := range task.Subtasks {
+		for j := range task.Subtasks {
+			subtask := &task.Subtasks[j]
 			if subtask.Status == StatusInProgress {
-				daysSinceUpdate := time.Since(subtask.UpdatedAt).Hours() / 24
-				if daysSinceUpdate > 5 {
+				daysSinceUpdate := daysSince(subtask.UpdatedAt)
+				if daysSinceUpdate > float64(rules.SubtaskStaleDays) {
 					attention = append(attention, TaskAttention{
-						Task:    &task,
-						Subtask: &subtask,
+						Task:    task,
+						Subtask: subtask,
 						Reason:  fmt.Sprintf("Subtask '%s' has been in progress for %.1f days", subtask.Title, daysSinceUpdate),
 						Type:    AttentionTypeStale,
 					})
@@ -335,25 +615,70 @@ func GetTasksNeedingAttention(project *Project) []TaskAttention {
 	return attention
 }
 
+// getBlockedReason reports whether an unfinished task is blocked, either marked
+// blocked or waiting on dependencies that aren't done, naming the blocking tasks.
+// Dependencies on task IDs that no longer exist are ignored.
+func getBlockedReason(task *Task, taskByID map[int]*Task) (string, bool) {
+	if task.Status == StatusDone {
+		return "", false
+	}
+
+	var blocking []string
+	for _, depID := range task.Dependencies {
+		if dep, exists := taskByID[depID]; exists && dep.Status != StatusDone {
+			blocking = append(blocking, fmt.Sprintf("'%s'", dep.Title))
+		}
+	}
+
+	marked := "Task is marked blocked"
+	if task.BlockedReason != "" {
+		marked += fmt.Sprintf(" (%s)", task.BlockedReason)
+	}
+
+	switch {
+	case len(blocking) > 0 && task.Status == StatusBlocked:
+		return fmt.Sprintf("%s and waiting on %s", marked, strings.Join(blocking, ", ")), true
+	case len(blocking) > 0:
+		return fmt.Sprintf("Task is waiting on incomplete dependencies: %s", strings.Join(blocking, ", ")), true
+	case task.Status == StatusBlocked:
+		return marked, true
+	}
+	return "", false
+}
+
+// blockedSeverity rates a blocked task by its priority: 5 for P0 down to 2 for P3
+func blockedSeverity(priority TaskPriority) int {
+	switch priority {
+	case PriorityP0:
+		return 5
+	case PriorityP1:
+		return 4
+	case PriorityP3:
+		return 2
+	default:
+		return 3
+	}
+}
+
 // getAttentionReason generates a human-readable reason for why a task needs attention
-func getAttentionReason(task *Task) string {
+func getAttentionReason(task *Task, rules CompletionRules) string {
 	if task.Status == StatusInProgress && task.EstimatedHours > 0 {
-		hoursSinceUpdate := time.Since(task.UpdatedAt).Hours()
+		hoursSinceUpdate := hoursSince(task.UpdatedAt)
 		if hoursSinceUpdate > float64(task.EstimatedHours) {
 			return fmt.Sprintf("Task has been in progress for %.1f hours (estimated: %d hours)", hoursSinceUpdate, task.EstimatedHours)
 		}
 	}
 
 	if task.Status == StatusInProgress {
-		daysSinceUpdate := time.Since(task.UpdatedAt).Hours() / 24
-		if daysSinceUpdate > 7 {
+		daysSinceUpdate := daysSince(task.UpdatedAt)
+		if daysSinceUpdate > float64(rules.StaleDays) {
 			return fmt.Sprintf("Task has been in progress for %.1f days without updates", daysSinceUpdate)
 		}
 	}
 
 	if task.Status == StatusTodo && len(task.Subtasks) == 0 {
-		daysSinceCreation := time.Since(task.CreatedAt).Hours() / 24
-		if daysSinceCreation > 14 {
+		daysSinceCreation := daysSince(task.CreatedAt)
+		if daysSinceCreation > float64(rules.TodoAgeDays) {
 			return fmt.Sprintf("Task has been todo for %.1f days - might need breakdown or action", daysSinceCreation)
 		}
 	}
@@ -0,0 +1,318 @@
+package task
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// daysAgo returns the time d days before now, in UTC
+func daysAgo(d float64) time.Time {
+	return time.Now().UTC().Add(-time.Duration(d * 24 * float64(time.Hour)))
+}
+
+func TestShouldPromptForCompletionThresholds(t *testing.T) {
+	custom := CompletionRules{StaleDays: 3, TodoAgeDays: 10, SubtaskStaleDays: 2}
+
+	// An hour either side of each threshold, so the test doesn't depend on
+	// how long it takes to run
+	const margin = 1.0 / 24
+	tests := []struct {
+		name  string
+		rules CompletionRules
+		task  Task
+		want  bool
+	}{
+		{"in progress inside default stale days", CompletionRules{}, Task{Status: StatusInProgress, UpdatedAt: daysAgo(7 - margin)}, false},
+		{"in progress past default stale days", CompletionRules{}, Task{Status: StatusInProgress, UpdatedAt: daysAgo(7 + margin)}, true},
+		{"in progress inside custom stale days", custom, Task{Status: StatusInProgress, UpdatedAt: daysAgo(3 - margin)}, false},
+		{"in progress past custom stale days", custom, Task{Status: StatusInProgress, UpdatedAt: daysAgo(3 + margin)}, true},
+		{"todo inside default age", CompletionRules{}, Task{Status: StatusTodo, CreatedAt: daysAgo(14 - margin)}, false},
+		{"todo past default age", CompletionRules{}, Task{Status: StatusTodo, CreatedAt: daysAgo(14 + margin)}, true},
+		{"todo inside custom age", custom, Task{Status: StatusTodo, CreatedAt: daysAgo(10 - margin)}, false},
+		{"todo past custom age", custom, Task{Status: StatusTodo, CreatedAt: daysAgo(10 + margin)}, true},
+		{"old todo with subtasks", custom, Task{Status: StatusTodo, CreatedAt: daysAgo(30), Subtasks: []Subtask{{Title: "Step"}}}, false},
+		{"inside estimated hours", custom, Task{Status: StatusInProgress, EstimatedHours: 4, UpdatedAt: time.Now().UTC().Add(-3 * time.Hour)}, false},
+		{"past estimated hours", custom, Task{Status: StatusInProgress, EstimatedHours: 4, UpdatedAt: time.Now().UTC().Add(-5 * time.Hour)}, true},
+		{"blocked past stale days", custom, Task{Status: StatusBlocked, UpdatedAt: daysAgo(30)}, false},
+		{"done past stale days", custom, Task{Status: StatusDone, UpdatedAt: daysAgo(30)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldPromptForCompletion(&tt.task, tt.rules); got != tt.want {
+				t.Errorf("ShouldPromptForCompletion = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTasksNeedingAttentionSubtaskThreshold(t *testing.T) {
+	rules := CompletionRules{SubtaskStaleDays: 2}
+	const margin = 1.0 / 24
+	project := &Project{Tasks: []Task{{
+		ID:        1,
+		Title:     "Task",
+		Status:    StatusInProgress,
+		UpdatedAt: time.Now().UTC(),
+		Subtasks: []Subtask{
+			{Title: "Fresh", Status: StatusInProgress, UpdatedAt: daysAgo(2 - margin)},
+			{Title: "Stale", Status: StatusInProgress, UpdatedAt: daysAgo(2 + margin)},
+			{Title: "Old todo", Status: StatusTodo, UpdatedAt: daysAgo(30)},
+		},
+	}}}
+
+	var stale []string
+	for _, item := range GetTasksNeedingAttention(project, rules) {
+		if item.Type == AttentionTypeStale {
+			stale = append(stale, item.Subtask.Title)
+		}
+	}
+	if len(stale) != 1 || stale[0] != "Stale" {
+		t.Errorf("stale subtasks = %v, want [Stale]", stale)
+	}
+}
+
+func TestGetTasksNeedingAttentionPointsAtDistinctItems(t *testing.T) {
+	rules := CompletionRules{SubtaskStaleDays: 2}
+	stale := func(title string) Subtask {
+		return Subtask{Title: title, Status: StatusInProgress, UpdatedAt: daysAgo(5)}
+	}
+	project := &Project{Tasks: []Task{
+		{ID: 1, Title: "API", Status: StatusInProgress, UpdatedAt: time.Now().UTC(), Subtasks: []Subtask{stale("List"), stale("Create")}},
+		{ID: 2, Title: "UI", Status: StatusInProgress, UpdatedAt: time.Now().UTC(), Subtasks: []Subtask{stale("Form")}},
+		{ID: 3, Title: "Docs", Status: StatusInProgress, UpdatedAt: time.Now().UTC(), Subtasks: []Subtask{stale("Guide")}},
+	}}
+
+	var got []string
+	for _, item := range GetTasksNeedingAttention(project, rules) {
+		if item.Type != AttentionTypeStale {
+			continue
+		}
+		got = append(got, item.Task.Title+"/"+item.Subtask.Title)
+		// Each item refers to the project's own elements, not loop copies
+		index := slices.IndexFunc(project.Tasks, func(task Task) bool { return task.Title == item.Task.Title })
+		if index < 0 || item.Task != &project.Tasks[index] {
+			t.Errorf("item for %q does not point into project.Tasks", item.Task.Title)
+			continue
+		}
+		subtasks := project.Tasks[index].Subtasks
+		if j := slices.IndexFunc(subtasks, func(s Subtask) bool { return s.Title == item.Subtask.Title }); j < 0 || item.Subtask != &subtasks[j] {
+			t.Errorf("item for subtask %q does not point into its task's subtasks", item.Subtask.Title)
+		}
+	}
+	if want := []string{"API/List", "API/Create", "UI/Form", "Docs/Guide"}; !slices.Equal(got, want) {
+		t.Errorf("stale subtasks = %v, want %v", got, want)
+	}
+}
+
+func TestGetTasksNeedingAttentionBlocked(t *testing.T) {
+	tests := []struct {
+		name         string
+		task         Task
+		wantReason   string
+		wantSeverity int
+	}{
+		{"waiting on an incomplete dependency", Task{Priority: PriorityP1, Dependencies: []int{1, 2}},
+			"Task is waiting on incomplete dependencies: 'Schema'", 4},
+		{"marked blocked", Task{Priority: PriorityP0, Status: StatusBlocked, BlockedReason: "Needs sign-off"},
+			"Task is marked blocked (Needs sign-off)", 5},
+		{"marked blocked and waiting", Task{Priority: PriorityP3, Status: StatusBlocked, Dependencies: []int{1}},
+			"Task is marked blocked and waiting on 'Schema'", 2},
+		{"default priority", Task{Priority: PriorityP2, Dependencies: []int{1}},
+			"Task is waiting on incomplete dependencies: 'Schema'", 3},
+		{"only done dependencies", Task{Priority: PriorityP0, Dependencies: []int{2}}, "", 0},
+		{"missing dependency", Task{Priority: PriorityP0, Dependencies: []int{99}}, "", 0},
+		{"done task with an incomplete dependency", Task{Priority: PriorityP0, Status: StatusDone, Dependencies: []int{1}}, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.task.ID, tt.task.Title = 3, "Handlers"
+			if tt.task.Status == "" {
+				tt.task.Status = StatusTodo
+			}
+			tt.task.UpdatedAt, tt.task.CreatedAt = time.Now().UTC(), time.Now().UTC()
+			project := &Project{Tasks: []Task{
+				{ID: 1, Title: "Schema", Status: StatusInProgress, UpdatedAt: time.Now().UTC()},
+				{ID: 2, Title: "Design", Status: StatusDone, UpdatedAt: time.Now().UTC()},
+				tt.task,
+			}}
+
+			var blocked []TaskAttention
+			for _, item := range GetTasksNeedingAttention(project, CompletionRules{}) {
+				if item.Type == AttentionTypeBlocked {
+					blocked = append(blocked, item)
+				}
+			}
+			if tt.wantReason == "" {
+				if len(blocked) != 0 {
+					t.Errorf("blocked items = %+v, want none", blocked)
+				}
+				return
+			}
+			if len(blocked) != 1 {
+				t.Fatalf("got %d blocked items, want 1: %+v", len(blocked), blocked)
+			}
+			if item := blocked[0]; item.Task != &project.Tasks[2] || item.Reason != tt.wantReason || item.Severity != tt.wantSeverity {
+				t.Errorf("blocked item = %q %q severity %d, want %q severity %d",
+					item.Task.Title, item.Reason, item.Severity, tt.wantReason, tt.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestAutoUpdateTaskStatusesKeepParentsOpen(t *testing.T) {
+	newProject := func() *Project {
+		return &Project{Tasks: []Task{{
+			ID:       1,
+			Title:    "Task",
+			Status:   StatusInProgress,
+			Subtasks: []Subtask{{Title: "Step", Status: StatusDone}},
+		}}}
+	}
+
+	project := newProject()
+	if _, changed := AutoUpdateTaskStatuses(project, CompletionRules{}); !changed || project.Tasks[0].Status != StatusDone {
+		t.Errorf("default rules: changed = %v, status = %s, want the task completed", changed, project.Tasks[0].Status)
+	}
+
+	project = newProject()
+	if updates, changed := AutoUpdateTaskStatuses(project, CompletionRules{KeepParentsOpen: true}); changed || project.Tasks[0].Status != StatusInProgress {
+		t.Errorf("KeepParentsOpen: changed = %v (%v), status = %s, want the task left in progress", changed, updates, project.Tasks[0].Status)
+	}
+}
+
+func TestValidateProjectReportsChoiceWithoutOptions(t *testing.T) {
+	m := newTestManager(t)
+	content := m.generateMarkdown(testProject(
+		Task{Title: "Pick a store", Choices: []Choice{{Question: "Which store?", Options: []string{"Files", "Database"}}}},
+		Task{Title: "Build it", Dependencies: []int{7}},
+	))
+	// An edit by hand left the choice with no options
+	content = replaceOnce(t, content, "- [ ] Files\n- [ ] Database\n", "")
+
+	project, err := m.parseMarkdown(content)
+	if err != nil {
+		t.Fatalf("parseMarkdown: %v", err)
+	}
+	if choices := project.Tasks[0].Choices; len(choices) != 1 || len(choices[0].Options) != 0 {
+		t.Fatalf("choices after parse = %+v, want one choice without options", choices)
+	}
+
+	issues := ValidateProject(project)
+	types := make(map[string]int)
+	for _, issue := range issues {
+		types[issue.Type]++
+	}
+	if types["invalid_choice"] != 1 || types["missing_dependency"] != 1 || len(issues) != 2 {
+		t.Errorf("issues = %+v, want one invalid_choice and one missing_dependency", issues)
+	}
+}
+
+func TestResolveTaskTitle(t *testing.T) {
+	project := &Project{Name: "p", Tasks: []Task{
+		{ID: 1, Title: "Write API docs"},
+		{ID: 2, Title: "Write API tests"},
+		{ID: 3, Title: "Deploy"},
+	}}
+
+	tests := []struct {
+		name      string
+		query     string
+		partial   bool
+		wantIndex int
+		wantErr   error
+	}{
+		{"exact match", "Deploy", false, 2, nil},
+		{"exact match wins over partial", "Deploy", true, 2, nil},
+		{"unique partial match", "api tests", true, 1, nil},
+		{"partial match disabled", "api tests", false, -1, ErrNotFound},
+		{"ambiguous partial match", "write api", true, -1, ErrInvalidInput},
+		{"no match", "Release", true, -1, ErrNotFound},
+		{"blank query", "  ", true, -1, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, index, err := ResolveTaskTitle(project, tt.query, tt.partial)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || got != nil || index != -1 {
+					t.Fatalf("ResolveTaskTitle(%q) = %v, %d, %v; want error %v", tt.query, got, index, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || index != tt.wantIndex || got != &project.Tasks[tt.wantIndex] {
+				t.Fatalf("ResolveTaskTitle(%q) = %v, %d, %v; want task %d", tt.query, got, index, err, tt.wantIndex)
+			}
+		})
+	}
+
+	// The ambiguity error names every candidate so the caller can pick one
+	_, _, err := ResolveTaskTitle(project, "write api", true)
+	for _, title := range []string{"'Write API docs'", "'Write API tests'"} {
+		if err == nil || !strings.Contains(err.Error(), title) {
+			t.Errorf("ambiguity error %v does not name %s", err, title)
+		}
+	}
+}
+
+func TestValidateTaskCategory(t *testing.T) {
+	for _, category := range AllCategories() {
+		if got, err := ValidateTaskCategory(string(category)); err != nil || got != category {
+			t.Errorf("ValidateTaskCategory(%q) = %q, %v; want it accepted", category, got, err)
+		}
+	}
+	for _, invalid := range []string{"", "GENERAL", "[general]", "[OTHER]", "MVP"} {
+		if _, err := ValidateTaskCategory(invalid); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ValidateTaskCategory(%q) = %v, want ErrInvalidInput", invalid, err)
+		}
+	}
+}
+
+func TestStalenessIgnoresTimezones(t *testing.T) {
+	// Run as if the server were far from UTC
+	local := time.Local
+	time.Local = time.FixedZone("UTC-10", -10*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	instant := time.Now().Add(-36 * time.Hour)
+	zones := []*time.Location{time.UTC, time.Local, time.FixedZone("UTC+14", 14*60*60), time.FixedZone("UTC+5:30", 5*60*60+30*60)}
+	for _, zone := range zones {
+		at := instant.In(zone)
+		if hours := hoursSince(at); math.Abs(hours-36) > 0.01 {
+			t.Errorf("hoursSince in %s = %v, want 36", zone, hours)
+		}
+		if days := daysSince(at); math.Abs(days-1.5) > 0.001 {
+			t.Errorf("daysSince in %s = %v, want 1.5", zone, days)
+		}
+
+		// The same instant recorded in any zone gets the same verdict
+		rules := CompletionRules{StaleDays: 1}
+		task := Task{Status: StatusInProgress, UpdatedAt: at}
+		if !ShouldPromptForCompletion(&task, rules) {
+			t.Errorf("task updated 36 hours ago in %s is not stale after a day", zone)
+		}
+		rules.StaleDays = 2
+		if ShouldPromptForCompletion(&task, rules) {
+			t.Errorf("task updated 36 hours ago in %s is stale after two days", zone)
+		}
+	}
+
+	// A timestamp written in local time comes back as the same instant in UTC
+	m := newTestManager(t)
+	updated := time.Date(2024, 5, 1, 22, 30, 0, 0, time.Local)
+	project := testProject(Task{Title: "Ship it"})
+	project.Tasks[0].UpdatedAt = updated
+	parsed := roundTrip(t, m, project)
+	if got := parsed.Tasks[0].UpdatedAt; !got.Equal(updated) || got.Location() != time.UTC {
+		t.Errorf("UpdatedAt after round trip = %s, want %s in UTC", got, updated.UTC())
+	}
+
+	// New timestamps are recorded in UTC
+	task := Task{Status: StatusTodo}
+	task.SetStatus(StatusInProgress)
+	if task.UpdatedAt.Location() != time.UTC {
+		t.Errorf("SetStatus recorded UpdatedAt in %s, want UTC", task.UpdatedAt.Location())
+	}
+}
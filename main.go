@@ -2,40 +2,55 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"mcp-task-manager-go/internal/server"
 )
 
 func main() {
+	// Command-line flags override the TRANSPORT, HOST and PORT environment variables
+	transportFlag := flag.String("transport", "", "Transport to serve: stdio, sse or streamable-http (default from TRANSPORT, else stdio)")
+	hostFlag := flag.String("host", "", "Host to listen on for sse and streamable-http (default from HOST, else 0.0.0.0)")
+	portFlag := flag.String("port", "", "Port to listen on for sse and streamable-http (default from PORT, else 8050)")
+	flag.Parse()
+
 	// Create the MCP server
 	mcpServer, err := server.NewTaskManagerServer()
 	if err != nil {
 		log.Fatalf("Failed to create MCP server: %v", err)
 	}
 
-	// Get transport type from environment (default to stdio)
-	transport := os.Getenv("TRANSPORT")
-	if transport == "" {
-		transport = "stdio"
+	// Validate transport settings before starting, so bad values fail clearly
+	if err := mcpServer.SetTransport(*transportFlag, *hostFlag, *portFlag); err != nil {
+		log.Fatalf("Invalid transport configuration: %v", err)
 	}
 
+	// Serve until SIGINT or SIGTERM, then let in-flight requests finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Start the server based on transport type
-	ctx := context.Background()
-	switch transport {
+	switch mcpServer.Transport() {
 	case "sse":
 		fmt.Println("Starting MCP server with SSE transport...")
 		if err := mcpServer.ServeSSE(ctx); err != nil {
 			log.Fatalf("SSE server error: %v", err)
 		}
+	case "streamable-http":
+		fmt.Println("Starting MCP server with streamable HTTP transport...")
+		if err := mcpServer.ServeHTTP(ctx); err != nil {
+			log.Fatalf("HTTP server error: %v", err)
+		}
 	case "stdio":
-		fmt.Println("Starting MCP server with stdio transport...")
+		// stdout carries the JSON-RPC stream, so announce on stderr
+		fmt.Fprintln(os.Stderr, "Starting MCP server with stdio transport...")
 		if err := mcpServer.ServeStdio(ctx); err != nil {
 			log.Fatalf("Stdio server error: %v", err)
 		}
-	default:
-		log.Fatalf("Unknown transport type: %s", transport)
 	}
 }